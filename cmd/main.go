@@ -2,42 +2,90 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
+	"os"
 	"syscall"
 	"time"
 
 	"github.com/oklog/run"
 	"github.com/sagar23sj/go-ecommerce/internal/api"
 	"github.com/sagar23sj/go-ecommerce/internal/app"
-	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/app/product"
+	"github.com/sagar23sj/go-ecommerce/internal/app/webhook"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
-	"github.com/sagar23sj/go-ecommerce/internal/repository"
 	"go.uber.org/zap"
 )
 
 func main() {
 
 	ctx := context.Background()
+
+	//settings come from the YAML file named by CONFIG_FILE, overridden by environment variables
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		logger.Fatalw(ctx, "error occured while loading configuration", zap.Error(err))
+	}
+
+	//run database migrations and exit when invoked as `main migrate [-dry-run]`
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := migrate(ctx, cfg, os.Args[2:])
+		if err != nil {
+			logger.Fatalw(ctx, "error occured while migrating database", zap.Error(err))
+		}
+		return
+	}
+
+	//print a signed user token and exit when invoked as `main token -role <role> ...`
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		err := issueToken(ctx, cfg, os.Args[2:])
+		if err != nil {
+			logger.Fatalw(ctx, "error occured while issuing token", zap.Error(err))
+		}
+		return
+	}
+
 	logger.Infow(ctx, "Starting E-Commerce Application....")
 	defer logger.Infow(ctx, "Shutting Down E-Commerce Application...")
 
-	sqlDB, err := repository.InitializeDatabase()
+	repos, err := initializeRepositories(ctx, cfg.Database, cfg.Order.Currency)
 	if err != nil {
 		logger.Fatalw(ctx, "error occured while initializing database object",
 			zap.Error(err),
 		)
 	}
+	defer closeRepositories(ctx, repos)
+
+	//bring database schema up to date before serving requests
+	_, err = repos.Migrator.Migrate(ctx, false)
+	if err != nil {
+		logger.Fatalw(ctx, "error occured while migrating database", zap.Error(err))
+	}
+
+	//data priced in another currency than the catalog cannot be served
+	err = repos.Migrator.CheckCurrency(ctx)
+	if err != nil {
+		logger.Fatalw(ctx, "error occured while checking database currency", zap.Error(err))
+	}
 
 	//initialize service dependencies
-	services := app.NewServices(sqlDB)
+	services := app.NewServices(repos, cfg)
+
+	authenticator, err := initializeAuthenticator(ctx, cfg.Auth)
+	if err != nil {
+		logger.Fatalw(ctx, "error occured while initializing authentication", zap.Error(err))
+	}
 
 	//initialize router
-	router := api.NewRouter(services)
+	router := api.NewRouter(services, authenticator)
 
 	var group run.Group
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", constants.HTTPPort),
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler: router,
 	}
 
@@ -45,7 +93,7 @@ func main() {
 	group.Add(
 		func() error {
 			{
-				logger.Infow(ctx, "Starting HTTP Server", zap.Int("port", constants.HTTPPort))
+				logger.Infow(ctx, "Starting HTTP Server", zap.Int("port", cfg.Server.Port))
 
 				err := srv.ListenAndServe()
 				if err != nil {
@@ -57,7 +105,7 @@ func main() {
 		func(err error) {
 			logger.Infow(ctx, "Shutting HTTP server down gracefully...", zap.Error(err))
 
-			ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+			ctx, cancel := context.WithTimeout(ctx, cfg.Server.ShutdownTimeout)
 			defer cancel()
 
 			err = srv.Shutdown(ctx)
@@ -69,6 +117,59 @@ func main() {
 		},
 	)
 
+	sinks, err := event.NewSinks(cfg.Outbox)
+	if err != nil {
+		logger.Fatalw(ctx, "error occured while initializing event sinks", zap.Error(err))
+	}
+
+	//fan every relayed event out to the matching webhook subscriptions
+	sinks = append(sinks, services.WebhookService)
+
+	relay := event.NewRelay(repos.OutboxRepo, sinks, cfg.Outbox)
+	relayCtx, stopRelay := context.WithCancel(ctx)
+
+	//Adding outbox relay delivering domain events to run group
+	group.Add(
+		func() error {
+			logger.Infow(ctx, "Starting outbox relay", zap.Strings("sinks", cfg.Outbox.SinkNames()))
+			return relay.Run(relayCtx)
+		},
+		func(err error) {
+			logger.Infow(ctx, "Stopping outbox relay...", zap.Error(err))
+			stopRelay()
+		},
+	)
+
+	dispatcher := webhook.NewDispatcher(repos.WebhookSubscriptionRepo, repos.WebhookDeliveryRepo, cfg.Webhook)
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+
+	//Adding webhook dispatcher posting deliveries to subscriptions to run group
+	group.Add(
+		func() error {
+			logger.Infow(ctx, "Starting webhook dispatcher")
+			return dispatcher.Run(dispatcherCtx)
+		},
+		func(err error) {
+			logger.Infow(ctx, "Stopping webhook dispatcher...", zap.Error(err))
+			stopDispatcher()
+		},
+	)
+
+	sweeper := product.NewReservationSweeper(services.ProductService, cfg.Inventory)
+	sweeperCtx, stopSweeper := context.WithCancel(ctx)
+
+	//Adding sweeper releasing expired stock reservations to run group
+	group.Add(
+		func() error {
+			logger.Infow(ctx, "Starting reservation sweeper", zap.Duration("reservation_ttl", cfg.Inventory.ReservationTTL))
+			return sweeper.Run(sweeperCtx)
+		},
+		func(err error) {
+			logger.Infow(ctx, "Stopping reservation sweeper...", zap.Error(err))
+			stopSweeper()
+		},
+	)
+
 	//Adding graceful shutdown handler to run group
 	group.Add(
 		run.SignalHandler(
@@ -87,3 +188,113 @@ func main() {
 	}
 
 }
+
+func migrate(ctx context.Context, cfg config.Config, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "list pending migrations without applying them")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	repos, err := initializeRepositories(ctx, cfg.Database, cfg.Order.Currency)
+	if err != nil {
+		return err
+	}
+	defer closeRepositories(ctx, repos)
+
+	migrations, err := repos.Migrator.Migrate(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(migrations) == 0 {
+		logger.Infow(ctx, "database schema is up to date")
+		return nil
+	}
+
+	for _, m := range migrations {
+		if *dryRun {
+			logger.Infow(ctx, "pending migration",
+				zap.Int("version", m.Version),
+				zap.String("description", m.Description),
+			)
+		}
+	}
+
+	return nil
+}
+
+func issueToken(ctx context.Context, cfg config.Config, args []string) error {
+	flags := flag.NewFlagSet("token", flag.ExitOnError)
+	subject := flags.String("subject", "", "subject of the token, defaults to the role")
+	role := flags.String("role", string(auth.RoleCustomer), "role of the token: customer, ops or admin")
+	customerID := flags.Int64("customer-id", 0, "customer the token acts for, required for the customer role")
+	ttl := flags.Duration("ttl", 24*time.Hour, "validity of the token")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if !auth.Role(*role).IsValid() {
+		return fmt.Errorf("invalid role: %s", *role)
+	}
+
+	if auth.Role(*role) == auth.RoleCustomer && *customerID <= 0 {
+		return fmt.Errorf("customer-id is required for the customer role")
+	}
+
+	if *subject == "" {
+		*subject = *role
+	}
+
+	authenticator, err := initializeAuthenticator(ctx, cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	token, err := authenticator.IssueToken(auth.Principal{
+		Subject:    *subject,
+		Role:       auth.Role(*role),
+		CustomerID: *customerID,
+	}, *ttl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
+
+func initializeAuthenticator(ctx context.Context, cfg config.AuthConfig) (*auth.Authenticator, error) {
+	apiKeys, err := auth.ParseAPIKeys(cfg.APIKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.JWTSecret == "" && len(apiKeys) == 0 {
+		logger.Warnw(ctx, "no AUTH_JWT_SECRET or AUTH_API_KEYS configured, only public APIs are reachable")
+	}
+
+	return auth.NewAuthenticator(cfg.JWTSecret, apiKeys), nil
+}
+
+func initializeRepositories(ctx context.Context, cfg config.DatabaseConfig, currency string) (app.Repositories, error) {
+	repos, err := app.NewRepositories(cfg.Driver, cfg.DSN, currency)
+	if err != nil {
+		logger.Errorw(ctx, "error occured while opening database",
+			zap.Error(err),
+			zap.String("driver", cfg.Driver),
+		)
+		return app.Repositories{}, err
+	}
+
+	return repos, nil
+}
+
+func closeRepositories(ctx context.Context, repos app.Repositories) {
+	err := repos.Close()
+	if err != nil {
+		logger.Errorw(ctx, "error occured while closing database connection", zap.Error(err))
+	}
+}
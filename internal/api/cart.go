@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/cart"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"go.uber.org/zap"
+)
+
+func createCartHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := cartSvc.CreateCart(ctx, callingCustomer(r))
+		if err != nil {
+			logger.Errorw(ctx, "error occured while creating cart",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusInternalServerError, apperrors.ErrInternalServerError)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
+
+func getCartHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cartID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := cartSvc.GetCart(ctx, callingCustomer(r), cartID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching cart",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func addCartItemHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cartID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		var req dto.AddCartItemRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating add cart item request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := cartSvc.AddCartItem(ctx, callingCustomer(r), cartID, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while adding cart item",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func updateCartItemHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cartID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		productID, err := parseIDParam(r, "product_id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		var req dto.UpdateCartItemRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating update cart item request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := cartSvc.UpdateCartItem(ctx, callingCustomer(r), cartID, productID, req.Quantity)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while updating cart item",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func removeCartItemHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cartID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		productID, err := parseIDParam(r, "product_id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := cartSvc.RemoveCartItem(ctx, callingCustomer(r), cartID, productID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while removing cart item",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func checkoutCartHandler(cartSvc cart.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cartID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := cartSvc.Checkout(ctx, callingCustomer(r), cartID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while checking out cart",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/cart/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartAPITestSuite struct {
+	suite.Suite
+	cartSvc *mocks.Service
+	router  chi.Router
+}
+
+func TestCartAPITestSuite(t *testing.T) {
+	suite.Run(t, new(CartAPITestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CartAPITestSuite) SetupTest() {
+	suite.cartSvc = &mocks.Service{}
+	suite.router = chi.NewRouter()
+}
+
+// this function executes after all tests executed
+func (suite *CartAPITestSuite) TearDownTest() {
+	suite.cartSvc.AssertExpectations(suite.T())
+}
+
+func (suite *CartAPITestSuite) TestAddCartItemHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		cartID             interface{}
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:   "Success",
+			cartID: 1,
+			input:  `{"product_id": 1, "quantity": 2}`,
+			setup: func() {
+				suite.cartSvc.On("AddCartItem", mock.Anything, int64(1), int64(1), dto.AddCartItemRequest{ProductID: 1, Quantity: 2}).Return(dto.Cart{
+					ID:     1,
+					Status: "Open",
+					Items:  []dto.CartItem{{ProductID: 1, Quantity: 2, Price: money.New(1000, "INR"), LineTotal: money.New(2000, "INR")}},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Quantity Missing",
+			cartID:             1,
+			input:              `{"product_id": 1}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Invalid CartID In Request",
+			cartID:             "w",
+			input:              `{"product_id": 1, "quantity": 2}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Fail Because Cart Not Found",
+			cartID: 1,
+			input:  `{"product_id": 1, "quantity": 2}`,
+			setup: func() {
+				suite.cartSvc.On("AddCartItem", mock.Anything, int64(1), int64(1), mock.Anything).Return(dto.Cart{}, apperrors.CartNotFound{ID: 1})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Fail Because Cart Checked Out",
+			cartID: 1,
+			input:  `{"product_id": 1, "quantity": 2}`,
+			setup: func() {
+				suite.cartSvc.On("AddCartItem", mock.Anything, int64(1), int64(1), mock.Anything).Return(dto.Cart{}, apperrors.CartNotOpen{ID: 1, Status: "CheckedOut"})
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.With(authenticatedAs(testCustomer)).Post("/carts/{id}/items", addCartItemHandler(suite.cartSvc))
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/carts/%v/items", test.cartID), bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CartAPITestSuite) TestCheckoutCartHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		cartID             interface{}
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:   "Success",
+			cartID: 1,
+			setup: func() {
+				suite.cartSvc.On("Checkout", mock.Anything, int64(1), int64(1)).Return(dto.Order{
+					ID:          1,
+					Products:    []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:      money.New(2000, "INR"),
+					FinalAmount: money.New(2000, "INR"),
+					Status:      "Placed",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "Fail Because Cart Empty",
+			cartID: 1,
+			setup: func() {
+				suite.cartSvc.On("Checkout", mock.Anything, int64(1), int64(1)).Return(dto.Order{}, apperrors.CartEmpty{ID: 1})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "Fail Because Something Went Wrong",
+			cartID: 1,
+			setup: func() {
+				suite.cartSvc.On("Checkout", mock.Anything, int64(1), int64(1)).Return(dto.Order{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.With(authenticatedAs(testCustomer)).Post("/carts/{id}/checkout", checkoutCartHandler(suite.cartSvc))
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/carts/%v/checkout", test.cartID), bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/coupon"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"go.uber.org/zap"
+)
+
+func createCouponHandler(couponSvc coupon.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.CreateCouponRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating create coupon request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := couponSvc.CreateCoupon(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while creating coupon",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
+
+func listCouponsHandler(couponSvc coupon.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := couponSvc.ListCoupons(ctx)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching coupons list",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func getCouponHandler(couponSvc coupon.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		couponID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := couponSvc.GetCoupon(ctx, couponID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching coupon",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/coupon/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CouponAPITestSuite struct {
+	suite.Suite
+	couponSvc *mocks.Service
+	router    chi.Router
+}
+
+func TestCouponAPITestSuite(t *testing.T) {
+	suite.Run(t, new(CouponAPITestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CouponAPITestSuite) SetupTest() {
+	suite.couponSvc = &mocks.Service{}
+	suite.router = chi.NewRouter()
+}
+
+// this function executes after all tests executed
+func (suite *CouponAPITestSuite) TearDownTest() {
+	suite.couponSvc.AssertExpectations(suite.T())
+}
+
+func (suite *CouponAPITestSuite) TestCreateCouponHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			input: `{"code": " welcome10 ", "discount_type": "percentage", "percent": 10, "categories": ["Premium", "Premium"], "usage_limit": 100}`,
+			setup: func() {
+				suite.couponSvc.On("CreateCoupon", mock.Anything, dto.CreateCouponRequest{
+					Code:         "WELCOME10",
+					DiscountType: "percentage",
+					Percent:      10,
+					Categories:   []string{"Premium"},
+					UsageLimit:   100,
+				}).Return(dto.Coupon{ID: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Fail Because Percentage Above 100",
+			input:              `{"code": "HALFOFF", "discount_type": "percentage", "percent": 150}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Fixed Coupon Takes A Percent",
+			input:              `{"code": "HALFOFF", "discount_type": "fixed", "percent": 50}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Discount Type Unknown",
+			input:              `{"code": "HALFOFF", "discount_type": "lottery", "percent": 50}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Validity Window Empty",
+			input:              `{"code": "HALFOFF", "discount_type": "fixed", "amount": {"minor_units": 5000, "currency": "INR"}, "valid_from": "2023-06-01T00:00:00Z", "valid_until": "2023-05-01T00:00:00Z"}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Code Already Exists",
+			input: `{"code": "WELCOME10", "discount_type": "fixed", "amount": {"minor_units": 500, "currency": "INR"}}`,
+			setup: func() {
+				suite.couponSvc.On("CreateCoupon", mock.Anything, mock.Anything).
+					Return(dto.Coupon{}, apperrors.CouponCodeExists{Code: "WELCOME10"})
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/admin/coupons", createCouponHandler(suite.couponSvc))
+			req, err := http.NewRequest(http.MethodPost, "/admin/coupons", bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CouponAPITestSuite) TestGetCouponHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		path               string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			path: "/admin/coupons/1",
+			setup: func() {
+				suite.couponSvc.On("GetCoupon", mock.Anything, int64(1)).Return(dto.Coupon{ID: 1, Code: "WELCOME10"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Fail Because Coupon Not Found",
+			path: "/admin/coupons/2",
+			setup: func() {
+				suite.couponSvc.On("GetCoupon", mock.Anything, int64(2)).Return(dto.Coupon{}, apperrors.CouponNotFound{ID: 2})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Fail Because Invalid Coupon ID",
+			path:               "/admin/coupons/abc",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Get("/admin/coupons/{id}", getCouponHandler(suite.couponSvc))
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
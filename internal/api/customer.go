@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/customer"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"go.uber.org/zap"
+)
+
+func createCustomerHandler(customerSvc customer.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.CreateCustomerRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating create customer request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := customerSvc.CreateCustomer(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while creating customer",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
+
+func getCustomerHandler(customerSvc customer.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := customerSvc.GetCustomerByID(ctx, nil, callingCustomer(r))
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching customer",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func updateCustomerHandler(customerSvc customer.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.UpdateCustomerRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating update customer request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := customerSvc.UpdateCustomer(ctx, callingCustomer(r), req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while updating customer",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/customer/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CustomerAPITestSuite struct {
+	suite.Suite
+	customerSvc *mocks.Service
+	router      chi.Router
+}
+
+func TestCustomerAPITestSuite(t *testing.T) {
+	suite.Run(t, new(CustomerAPITestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CustomerAPITestSuite) SetupTest() {
+	suite.customerSvc = &mocks.Service{}
+	suite.router = chi.NewRouter()
+}
+
+// this function executes after all tests executed
+func (suite *CustomerAPITestSuite) TearDownTest() {
+	suite.customerSvc.AssertExpectations(suite.T())
+}
+
+func (suite *CustomerAPITestSuite) TestCreateCustomerHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			input: `{"name": "Jane", "email": "Jane@Example.com"}`,
+			setup: func() {
+				suite.customerSvc.On("CreateCustomer", mock.Anything, dto.CreateCustomerRequest{
+					Name:  "Jane",
+					Email: "jane@example.com",
+				}).Return(dto.Customer{ID: 1, Name: "Jane", Email: "jane@example.com"}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Fail Because Email Invalid",
+			input:              `{"name": "Jane", "email": "jane"}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Email Already Registered",
+			input: `{"name": "Jane", "email": "jane@example.com"}`,
+			setup: func() {
+				suite.customerSvc.On("CreateCustomer", mock.Anything, mock.Anything).Return(dto.Customer{}, apperrors.CustomerEmailExists{Email: "jane@example.com"})
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/customers", createCustomerHandler(suite.customerSvc))
+			req, err := http.NewRequest(http.MethodPost, "/customers", bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CustomerAPITestSuite) TestGetCustomerHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		principal          auth.Principal
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			principal: testCustomer,
+			setup: func() {
+				suite.customerSvc.On("GetCustomerByID", mock.Anything, mock.Anything, int64(1)).Return(dto.Customer{ID: 1, Name: "Jane"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "Fail Because Customer Not Found",
+			principal: auth.Principal{Role: auth.RoleCustomer, CustomerID: 2},
+			setup: func() {
+				suite.customerSvc.On("GetCustomerByID", mock.Anything, mock.Anything, int64(2)).Return(dto.Customer{}, apperrors.CustomerNotFound{ID: 2})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Fail Because Caller Is Not A Customer",
+			principal:          auth.Principal{Subject: "backoffice", Role: auth.RoleAdmin},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.With(authenticatedAs(test.principal), middleware.RequireRole(auth.RoleCustomer)).Get("/customers/me", getCustomerHandler(suite.customerSvc))
+			req, err := http.NewRequest(http.MethodGet, "/customers/me", bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
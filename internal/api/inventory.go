@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/product"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"go.uber.org/zap"
+)
+
+func adjustInventoryHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.InventoryAdjustmentRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating inventory adjustment request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.AdjustInventory(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while adjusting inventory",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func lowStockReportHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := productSvc.LowStockReport(ctx)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while building low stock report",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InventoryAPITestSuite struct {
+	suite.Suite
+	productSvc *mocks.Service
+	router     chi.Router
+}
+
+func TestInventoryAPITestSuite(t *testing.T) {
+	suite.Run(t, new(InventoryAPITestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *InventoryAPITestSuite) SetupTest() {
+	suite.productSvc = &mocks.Service{}
+	suite.router = chi.NewRouter()
+}
+
+// this function executes after all tests executed
+func (suite *InventoryAPITestSuite) TearDownTest() {
+	suite.productSvc.AssertExpectations(suite.T())
+}
+
+func (suite *InventoryAPITestSuite) TestAdjustInventoryHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			input: `{"adjustments":[{"product_id":1,"delta":5,"reason":"restock"},{"product_id":2,"delta":-1,"reason":"correction"}]}`,
+			setup: func() {
+				suite.productSvc.On("AdjustInventory", mock.Anything, dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+					{ProductID: 1, Delta: 5, Reason: "restock"},
+					{ProductID: 2, Delta: -1, Reason: "correction"},
+				}}).Return(dto.InventoryAdjustmentList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Adjustments Empty",
+			input:              `{"adjustments":[]}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Delta Is Zero",
+			input:              `{"adjustments":[{"product_id":1,"delta":0,"reason":"correction"}]}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Stock Would Go Negative",
+			input: `{"adjustments":[{"product_id":1,"delta":-50,"reason":"correction"}]}`,
+			setup: func() {
+				suite.productSvc.On("AdjustInventory", mock.Anything, mock.Anything).
+					Return(dto.InventoryAdjustmentList{}, apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 50, QuantityRemaining: 10})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/inventory/adjustments", adjustInventoryHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodPost, "/inventory/adjustments", bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *InventoryAPITestSuite) TestLowStockReportHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		setup              func()
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.productSvc.On("LowStockReport", mock.Anything).Return(dto.LowStockReport{Products: []dto.LowStockProduct{
+					{ProductID: 1, Name: "XYZ", Quantity: 4, AvailableToSell: 4, ReorderThreshold: 5, ReorderQuantity: 20},
+				}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"error_code":0,"error_message":"","data":{"products":[{"product_id":1,"name":"XYZ","quantity":4,"reserved":0,` +
+				`"available_to_sell":4,"reorder_threshold":5,"reorder_quantity":20}]}}`,
+		},
+		{
+			name: "Fail Because Of Database Error",
+			setup: func() {
+				suite.productSvc.On("LowStockReport", mock.Anything).Return(dto.LowStockReport{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Get("/inventory/low-stock", lowStockReportHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodGet, "/inventory/low-stock", nil)
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+			if test.expectedBody != "" {
+				suite.JSONEq(test.expectedBody, recorder.Body.String())
+			}
+		})
+		suite.TearDownTest()
+	}
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sagar23sj/go-ecommerce/internal/app/order"
@@ -14,6 +16,12 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	//idempotencyKeyHeader lets clients retry POST /orders without placing the order twice
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 func createOrderHandler(orderSvc order.Service) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -43,6 +51,16 @@ func createOrderHandler(orderSvc order.Service) func(w http.ResponseWriter, r *h
 			return
 		}
 
+		req.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
+		if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+			logger.Errorw(ctx, "error occured while validating idempotency key",
+				zap.Int("length", len(req.IdempotencyKey)),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrIdempotencyKeyTooLong)
+			return
+		}
+
+		req.CustomerID = callingCustomer(r)
 		orderInfo, err := orderSvc.CreateOrder(ctx, req)
 		if err != nil {
 			logger.Errorw(ctx, "error occured while creating order",
@@ -57,7 +75,7 @@ func createOrderHandler(orderSvc order.Service) func(w http.ResponseWriter, r *h
 	}
 }
 
-func getOrderDetailsHandler(orderSvc order.Service) func(w http.ResponseWriter, r *http.Request) {
+func getOrderDetailsHandler(orderSvc order.Service, scope customerScope) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		rawOrderID := chi.URLParam(r, "id")
@@ -72,7 +90,7 @@ func getOrderDetailsHandler(orderSvc order.Service) func(w http.ResponseWriter,
 			return
 		}
 
-		response, err := orderSvc.GetOrderDetailsByID(ctx, int64(orderID))
+		response, err := orderSvc.GetOrderDetailsByID(ctx, scope(r), int64(orderID))
 		if err != nil {
 			logger.Errorw(ctx, "error occured while fetching order info",
 				zap.Error(err),
@@ -110,7 +128,7 @@ func updateOrderStatusHandler(orderSvc order.Service) func(w http.ResponseWriter
 			return
 		}
 
-		orderInfo, err := orderSvc.UpdateOrderStatus(ctx, req.OrderID, req.Status)
+		orderInfo, err := orderSvc.UpdateOrderStatus(ctx, caller(r), req.OrderID, req.Status, req.Reason)
 		if err != nil {
 			logger.Errorw(ctx, "error occured while updating order status",
 				zap.Error(err),
@@ -124,19 +142,134 @@ func updateOrderStatusHandler(orderSvc order.Service) func(w http.ResponseWriter
 	}
 }
 
-func listOrdersHandler(orderSvc order.Service) func(w http.ResponseWriter, r *http.Request) {
+func getOrderHistoryHandler(orderSvc order.Service, scope customerScope) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rawOrderID := chi.URLParam(r, "id")
+		orderID, err := strconv.Atoi(rawOrderID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while converting orderID to an integer",
+				zap.Error(err),
+				zap.String("id", rawOrderID),
+			)
+
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := orderSvc.GetOrderHistory(ctx, scope(r), int64(orderID))
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching order history",
+				zap.Error(err),
+			)
+
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func listOrdersHandler(orderSvc order.Service, scope customerScope) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		response, err := orderSvc.ListOrders(ctx)
+
+		req, err := parseListOrdersRequest(r.URL.Query())
+		if err != nil {
+			logger.Errorw(ctx, "error occured while parsing list orders query",
+				zap.Error(err),
+				zap.String("query", r.URL.RawQuery),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating list orders request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := orderSvc.ListOrders(ctx, scope(r), req)
 		if err != nil {
 			logger.Errorw(ctx, "error occured while fetching orders list",
 				zap.Error(err),
 			)
 
-			middleware.ErrorResponse(ctx, w, http.StatusInternalServerError, apperrors.ErrInternalServerError)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
 			return
 		}
 
 		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
 	}
 }
+
+func listShippingMethodsHandler(orderSvc order.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		//amounts of the catalog are converted into the requested currency
+		currency := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("currency")))
+		response, err := orderSvc.ListShippingMethods(ctx, currency)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching shipping methods",
+				zap.Error(err),
+			)
+
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+// parseListOrdersRequest reads the filters, sort and page of an order listing from the query string,
+// statuses may be repeated or comma separated and timestamps are RFC3339
+func parseListOrdersRequest(query url.Values) (req dto.ListOrdersRequest, err error) {
+	req = dto.ListOrdersRequest{
+		Currency: strings.ToUpper(strings.TrimSpace(query.Get("currency"))),
+		SortBy:   query.Get("sort_by"),
+	}
+
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				req.Statuses = append(req.Statuses, status)
+			}
+		}
+	}
+
+	if req.SortDesc, err = parseSortOrderQuery(query); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	if req.CreatedFrom, err = parseTimeQuery(query, "created_from"); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	if req.CreatedTo, err = parseTimeQuery(query, "created_to"); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	if req.MinAmount, err = parseMinorUnitsQuery(query, "min_amount"); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	if req.MaxAmount, err = parseMinorUnitsQuery(query, "max_amount"); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	if req.Page, req.PageSize, err = parsePageQuery(query); err != nil {
+		return dto.ListOrdersRequest{}, err
+	}
+
+	return req, nil
+}
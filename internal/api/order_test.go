@@ -8,13 +8,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sagar23sj/go-ecommerce/internal/app/order/mocks"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -52,12 +57,12 @@ func (suite *OrderAPITestSuite) TestGetOrderDetailsHandler() {
 			name:    "Success",
 			orderID: 1,
 			setup: func() {
-				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1)).Return(dto.Order{
+				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1), int64(1)).Return(dto.Order{
 					ID:                 int64(1),
 					Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-					Amount:             20.0,
+					Amount:             money.New(2000, "INR"),
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        money.New(2000, "INR"),
 					Status:             "Placed",
 				}, nil)
 			},
@@ -67,7 +72,7 @@ func (suite *OrderAPITestSuite) TestGetOrderDetailsHandler() {
 			name:    "Fail Because Order Not Found",
 			orderID: 1,
 			setup: func() {
-				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1)).Return(dto.Order{}, apperrors.ProductNotFound{ID: 1})
+				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1), int64(1)).Return(dto.Order{}, apperrors.ProductNotFound{ID: 1})
 			},
 			expectedStatusCode: http.StatusNotFound,
 		},
@@ -82,7 +87,7 @@ func (suite *OrderAPITestSuite) TestGetOrderDetailsHandler() {
 			name:    "Fail Because Something Went Wrong",
 			orderID: 1,
 			setup: func() {
-				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1)).Return(dto.Order{}, errors.New("something went wrong"))
+				suite.orderSvc.On("GetOrderDetailsByID", mock.Anything, int64(1), int64(1)).Return(dto.Order{}, errors.New("something went wrong"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -93,7 +98,7 @@ func (suite *OrderAPITestSuite) TestGetOrderDetailsHandler() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			suite.router.Get("/orders/{id}", getOrderDetailsHandler(suite.orderSvc))
+			suite.router.With(authenticatedAs(testCustomer)).Get("/orders/{id}", getOrderDetailsHandler(suite.orderSvc, callingCustomer))
 			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/orders/%v", test.orderID), bytes.NewBuffer([]byte(``)))
 			if err != nil {
 				t.Errorf("error occured while making http request, error : %v", err.Error())
@@ -108,36 +113,189 @@ func (suite *OrderAPITestSuite) TestGetOrderDetailsHandler() {
 	}
 }
 
+func (suite *OrderAPITestSuite) TestGetOrderHistoryHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		orderID            interface{}
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:    "Success",
+			orderID: 1,
+			setup: func() {
+				suite.orderSvc.On("GetOrderHistory", mock.Anything, int64(1), int64(1)).Return(dto.OrderHistory{
+					OrderID: int64(1),
+					Status:  "Cancelled",
+					Events: []dto.OrderStatusEvent{
+						{FromStatus: "Placed", ToStatus: "Cancelled", Actor: "1", ActorRole: "customer"},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "Fail Because Order Not Found",
+			orderID: 1,
+			setup: func() {
+				suite.orderSvc.On("GetOrderHistory", mock.Anything, int64(1), int64(1)).Return(dto.OrderHistory{}, apperrors.OrderNotFound{ID: 1})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "Fail Because Invalid OrderID In Request",
+			orderID: "w",
+			setup: func() {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Fail Because Something Went Wrong",
+			orderID: 1,
+			setup: func() {
+				suite.orderSvc.On("GetOrderHistory", mock.Anything, int64(1), int64(1)).Return(dto.OrderHistory{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.With(authenticatedAs(testCustomer)).Get("/orders/{id}/history", getOrderHistoryHandler(suite.orderSvc, callingCustomer))
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/orders/%v/history", test.orderID), bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
 func (suite *OrderAPITestSuite) TestListOrdersHandler() {
 	t := suite.T()
+	defaultRequest := dto.ListOrdersRequest{Page: 1, PageSize: dto.DefaultPageSize}
+	createdFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxAmount := int64(5000)
+
 	testCases := []struct {
 		name               string
+		principal          auth.Principal
+		query              string
 		setup              func()
 		expectedStatusCode int
 	}{
 		{
-			name: "Success",
+			name:      "Success",
+			principal: testCustomer,
 			setup: func() {
-				suite.orderSvc.On("ListOrders", mock.Anything).Return([]dto.Order{
-					{
-						ID:                 int64(1),
-						Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-						Amount:             20.0,
-						DiscountPercentage: 0.0,
-						FinalAmount:        20.0,
-						Status:             "Placed",
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(1), defaultRequest).Return(dto.OrderList{
+					Orders: []dto.Order{
+						{
+							ID:                 int64(1),
+							Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+							Amount:             money.New(2000, "INR"),
+							DiscountPercentage: 0.0,
+							FinalAmount:        money.New(2000, "INR"),
+							Status:             "Placed",
+						},
 					},
+					Pagination: dto.Pagination{Page: 1, PageSize: dto.DefaultPageSize, TotalItems: 1, TotalPages: 1},
 				}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
 		{
-			name: "Fail Because Something Went Wrong",
+			name:      "Success With Filters Sort And Page",
+			principal: testCustomer,
+			query:     "?status=Placed,Dispatched&status=Completed&created_from=2023-01-01T00:00:00Z&currency=inr&max_amount=5000&sort_by=created_at&order=desc&page=3&page_size=5",
+			setup: func() {
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(1), dto.ListOrdersRequest{
+					Statuses:    []string{"Placed", "Dispatched", "Completed"},
+					CreatedFrom: &createdFrom,
+					Currency:    "INR",
+					MaxAmount:   &maxAmount,
+					SortBy:      "created_at",
+					SortDesc:    true,
+					Page:        3,
+					PageSize:    5,
+				}).Return(dto.OrderList{Orders: []dto.Order{}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Created From Not A Timestamp",
+			principal:          testCustomer,
+			query:              "?created_from=yesterday",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Sort Order Invalid",
+			principal:          testCustomer,
+			query:              "?order=sideways",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Page Size Too Large",
+			principal:          testCustomer,
+			query:              "?page_size=1000",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Amount Range Inverted",
+			principal:          testCustomer,
+			query:              "?currency=INR&min_amount=20&max_amount=10",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Amount Filter Without Currency",
+			principal:          testCustomer,
+			query:              "?min_amount=1000",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Sorted By Final Amount Without Currency",
+			principal:          testCustomer,
+			query:              "?sort_by=final_amount",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Fail Because Status Unknown",
+			principal: testCustomer,
+			query:     "?status=Shipped",
 			setup: func() {
-				suite.orderSvc.On("ListOrders", mock.Anything).Return([]dto.Order{}, errors.New("something went wrong"))
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(1), mock.Anything).Return(dto.OrderList{}, apperrors.OrderListQueryInvalid{Param: "status", Value: "Shipped"})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Fail Because Something Went Wrong",
+			principal: testCustomer,
+			setup: func() {
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(1), defaultRequest).Return(dto.OrderList{}, errors.New("something went wrong"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name:               "Fail Because Caller Not Authenticated",
+			principal:          auth.Principal{},
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
 	}
 
 	for _, test := range testCases {
@@ -145,8 +303,8 @@ func (suite *OrderAPITestSuite) TestListOrdersHandler() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			suite.router.Get("/orders", listOrdersHandler(suite.orderSvc))
-			req, err := http.NewRequest(http.MethodGet, "/orders", bytes.NewBuffer([]byte(``)))
+			suite.router.With(authenticatedAs(test.principal), middleware.RequireRole(auth.RoleCustomer)).Get("/orders", listOrdersHandler(suite.orderSvc, callingCustomer))
+			req, err := http.NewRequest(http.MethodGet, "/orders"+test.query, bytes.NewBuffer([]byte(``)))
 			if err != nil {
 				t.Errorf("error occured while making http request, error : %v", err.Error())
 			}
@@ -175,12 +333,12 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 				Status:  "Dispatched",
 			},
 			setup: func() {
-				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, int64(1), "Dispatched").Return(dto.Order{
+				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, testOps, int64(1), "Dispatched", "").Return(dto.Order{
 					ID:                 int64(1),
 					Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-					Amount:             20.0,
+					Amount:             money.New(2000, "INR"),
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        money.New(2000, "INR"),
 					Status:             "Dispatched",
 				}, nil)
 			},
@@ -193,7 +351,7 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 				Status:  "test",
 			},
 			setup: func() {
-				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, int64(1), "test").Return(dto.Order{}, apperrors.OrderStatusInvalid{ID: 1})
+				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, testOps, int64(1), "test", "").Return(dto.Order{}, apperrors.OrderStatusInvalid{ID: 1})
 			},
 			expectedStatusCode: http.StatusUnprocessableEntity,
 		},
@@ -204,7 +362,7 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 				Status:  "Cancelled",
 			},
 			setup: func() {
-				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, int64(1), "Cancelled").Return(dto.Order{}, apperrors.OrderUpdationInvalid{
+				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, testOps, int64(1), "Cancelled", "").Return(dto.Order{}, apperrors.OrderUpdationInvalid{
 					ID:             1,
 					RequestedState: "Cancelled",
 					CurrentState:   "Completed",
@@ -219,7 +377,7 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 				Status:  "Cancelled",
 			},
 			setup: func() {
-				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, int64(1), "Cancelled").Return(dto.Order{}, apperrors.OrderNotFound{ID: 1})
+				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, testOps, int64(1), "Cancelled", "").Return(dto.Order{}, apperrors.OrderNotFound{ID: 1})
 			},
 			expectedStatusCode: http.StatusNotFound,
 		},
@@ -230,7 +388,7 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 				Status:  "Cancelled",
 			},
 			setup: func() {
-				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, int64(1), "Cancelled").Return(dto.Order{}, errors.New("something went wrong"))
+				suite.orderSvc.On("UpdateOrderStatus", mock.Anything, testOps, int64(1), "Cancelled", "").Return(dto.Order{}, errors.New("something went wrong"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -259,7 +417,7 @@ func (suite *OrderAPITestSuite) TestUpdateOrderStatusHandler() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			suite.router.Patch("/orders/{id}/status", updateOrderStatusHandler(suite.orderSvc))
+			suite.router.With(authenticatedAs(testOps)).Patch("/orders/{id}/status", updateOrderStatusHandler(suite.orderSvc))
 			requestObj, err := json.Marshal(test.input)
 			if err != nil {
 				logger.Errorw(context.Background(), "error occured while marshaling json request")
@@ -284,9 +442,49 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 	testCases := []struct {
 		name               string
 		input              dto.CreateOrderRequest
+		idempotencyKey     string
 		setup              func()
 		expectedStatusCode int
 	}{
+		{
+			name: "Success With Idempotency Key",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+			},
+			idempotencyKey: "order-attempt-1",
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID:     1,
+					IdempotencyKey: "order-attempt-1",
+					Products:       []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				}).Return(dto.Order{ID: int64(1), Status: "Placed"}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Fail Because Idempotency Key Reused",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 3}},
+			},
+			idempotencyKey: "order-attempt-1",
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID:     1,
+					IdempotencyKey: "order-attempt-1",
+					Products:       []dto.ProductInfo{{ProductID: 1, Quantity: 3}},
+				}).Return(dto.Order{}, apperrors.IdempotencyKeyReused{Key: "order-attempt-1"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Idempotency Key Too Long",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+			},
+			idempotencyKey:     strings.Repeat("k", 256),
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "Success",
 			input: dto.CreateOrderRequest{
@@ -303,6 +501,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 			},
 			setup: func() {
 				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
 					Products: []dto.ProductInfo{
 						{
 							ProductID: 1,
@@ -316,14 +515,146 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 				}).Return(dto.Order{
 					ID:                 int64(1),
 					Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 2}},
-					Amount:             20.0,
+					Amount:             money.New(2000, "INR"),
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        money.New(2000, "INR"),
 					Status:             "Placed",
 				}, nil)
 			},
 			expectedStatusCode: http.StatusCreated,
 		},
+		{
+			name: "Success In Another Currency",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				Currency: " usd",
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
+					Products:   []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Currency:   "USD",
+				}).Return(dto.Order{
+					ID:           int64(1),
+					Products:     []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:       money.New(2400, "USD"),
+					FinalAmount:  money.New(2400, "USD"),
+					ExchangeRate: money.Rate{From: "INR", To: "USD", Value: 0.012},
+					Status:       "Placed",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Fail Because Currency Unsupported",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				Currency: "XYZ",
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Currency Has No Exchange Rate",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				Currency: "EUR",
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, mock.Anything).Return(dto.Order{}, apperrors.CurrencyUnavailable{Currency: "EUR"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Success With Tax In Region Of Shipping Address",
+			input: dto.CreateOrderRequest{
+				Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID:      1,
+					Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+				}).Return(dto.Order{
+					ID:          int64(1),
+					Products:    []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:      money.New(2000, "INR"),
+					TaxAmount:   money.New(360, "INR"),
+					TaxRegion:   "IN-MH",
+					FinalAmount: money.New(2360, "INR"),
+					Status:      "Placed",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Fail Because Shipping Address In Unknown Tax Region",
+			input: dto.CreateOrderRequest{
+				Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				ShippingAddress: &dto.Address{Line1: "1 Mount Road", City: "Chennai", State: "TN", PostalCode: "600002", Country: "IN"},
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, mock.Anything).Return(dto.Order{}, apperrors.TaxRegionUnknown{Region: "IN-TN"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Success Shipped To Address",
+			input: dto.CreateOrderRequest{
+				Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				ShippingMethod:  " Express ",
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", PostalCode: "411001", Country: "IN"},
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID:      1,
+					Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					ShippingMethod:  "express",
+					ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", PostalCode: "411001", Country: "IN"},
+				}).Return(dto.Order{
+					ID:              int64(1),
+					Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:          money.New(2000, "INR"),
+					ShippingMethod:  "express",
+					ShippingAmount:  money.New(500, "INR"),
+					ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", PostalCode: "411001", Country: "IN"},
+					FinalAmount:     money.New(2500, "INR"),
+					Status:          "Placed",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Fail Because Shipping Address Incomplete",
+			input: dto.CreateOrderRequest{
+				Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune"},
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Shipping Address Required",
+			input: dto.CreateOrderRequest{
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, mock.Anything).Return(dto.Order{}, apperrors.ShippingAddressRequired{Method: "standard"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Shipping Method Unknown",
+			input: dto.CreateOrderRequest{
+				Products:       []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				ShippingMethod: "drone",
+			},
+			setup: func() {
+				suite.orderSvc.On("CreateOrder", mock.Anything, mock.Anything).Return(dto.Order{}, apperrors.ShippingMethodUnknown{Method: "drone"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 		{
 			name: "Fail Because Missing Products In Order",
 			input: dto.CreateOrderRequest{
@@ -385,6 +716,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 			},
 			setup: func() {
 				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
 					Products: []dto.ProductInfo{
 						{
 							ProductID: 1,
@@ -415,6 +747,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 			},
 			setup: func() {
 				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
 					Products: []dto.ProductInfo{
 						{
 							ProductID: 1,
@@ -445,6 +778,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 			},
 			setup: func() {
 				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
 					Products: []dto.ProductInfo{
 						{
 							ProductID: 1,
@@ -475,6 +809,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 			},
 			setup: func() {
 				suite.orderSvc.On("CreateOrder", mock.Anything, dto.CreateOrderRequest{
+					CustomerID: 1,
 					Products: []dto.ProductInfo{
 						{
 							ProductID: 1,
@@ -496,7 +831,7 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			suite.router.Post("/orders", createOrderHandler(suite.orderSvc))
+			suite.router.With(authenticatedAs(testCustomer)).Post("/orders", createOrderHandler(suite.orderSvc))
 			requestObj, err := json.Marshal(test.input)
 			if err != nil {
 				logger.Errorw(context.Background(), "error occured while marshaling json request")
@@ -507,6 +842,67 @@ func (suite *OrderAPITestSuite) TestCreateOrderHandler() {
 				t.Errorf("error occured while making http request, error : %v", err.Error())
 			}
 
+			if test.idempotencyKey != "" {
+				req.Header.Set(idempotencyKeyHeader, test.idempotencyKey)
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *OrderAPITestSuite) TestListShippingMethodsHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		query              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.orderSvc.On("ListShippingMethods", mock.Anything, "").Return([]dto.ShippingMethod{
+					{Name: "standard", Type: "flat", Amount: money.New(4900, "INR"), Default: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Success In Another Currency",
+			query: "?currency=usd",
+			setup: func() {
+				suite.orderSvc.On("ListShippingMethods", mock.Anything, "USD").Return([]dto.ShippingMethod{
+					{Name: "standard", Type: "flat", Amount: money.New(59, "USD"), Default: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Fail Because Currency Has No Exchange Rate",
+			query: "?currency=EUR",
+			setup: func() {
+				suite.orderSvc.On("ListShippingMethods", mock.Anything, "EUR").Return(nil, apperrors.CurrencyUnavailable{Currency: "EUR"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Get("/shipping-methods", listShippingMethodsHandler(suite.orderSvc))
+			req, err := http.NewRequest(http.MethodGet, "/shipping-methods"+test.query, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
 			recorder := httptest.NewRecorder()
 			suite.router.ServeHTTP(recorder, req)
 
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sagar23sj/go-ecommerce/internal/app/product"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
 	"go.uber.org/zap"
@@ -45,16 +49,346 @@ func getProductHandler(productSvc product.Service) func(w http.ResponseWriter, r
 func listProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		response, err := productSvc.ListProducts(ctx)
+
+		req, err := parseListProductsRequest(r.URL.Query())
+		if err != nil {
+			logger.Errorw(ctx, "error occured while parsing list products query",
+				zap.Error(err),
+				zap.String("query", r.URL.RawQuery),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating list products request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.ListProducts(ctx, req)
 		if err != nil {
 			logger.Errorw(ctx, "error occured while fetching product list",
 				zap.Error(err),
 			)
 
-			middleware.ErrorResponse(ctx, w, http.StatusInternalServerError, apperrors.ErrInternalServerError)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
 			return
 		}
 
 		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
 	}
 }
+
+// parseListProductsRequest reads the filters, sort and page of a catalog search from the query string
+func parseListProductsRequest(query url.Values) (req dto.ListProductsRequest, err error) {
+	req = dto.ListProductsRequest{
+		Category:   query.Get("category"),
+		Query:      strings.TrimSpace(query.Get("q")),
+		NamePrefix: strings.TrimSpace(query.Get("name_prefix")),
+		SortBy:     query.Get("sort_by"),
+	}
+
+	if raw := query.Get("in_stock"); raw != "" {
+		if req.InStock, err = strconv.ParseBool(raw); err != nil {
+			return dto.ListProductsRequest{}, err
+		}
+	}
+
+	if req.MinPrice, err = parseMinorUnitsQuery(query, "min_price"); err != nil {
+		return dto.ListProductsRequest{}, err
+	}
+
+	if req.MaxPrice, err = parseMinorUnitsQuery(query, "max_price"); err != nil {
+		return dto.ListProductsRequest{}, err
+	}
+
+	if req.SortDesc, err = parseSortOrderQuery(query); err != nil {
+		return dto.ListProductsRequest{}, err
+	}
+
+	if req.Page, req.PageSize, err = parsePageQuery(query); err != nil {
+		return dto.ListProductsRequest{}, err
+	}
+
+	return req, nil
+}
+
+func createProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.CreateProductRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating create product request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.CreateProduct(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while creating product",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
+
+func replaceProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rawProductID := chi.URLParam(r, "id")
+		productID, err := strconv.Atoi(rawProductID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while converting productID to an integer",
+				zap.Error(err),
+				zap.String("id", rawProductID),
+			)
+
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		//PUT replaces every editable field, so the payload must be a complete product
+		var req dto.CreateProductRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating replace product request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.UpdateProduct(ctx, int64(productID), req.ToUpdateRequest())
+		if err != nil {
+			logger.Errorw(ctx, "error occured while replacing product",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func updateProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rawProductID := chi.URLParam(r, "id")
+		productID, err := strconv.Atoi(rawProductID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while converting productID to an integer",
+				zap.Error(err),
+				zap.String("id", rawProductID),
+			)
+
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		var req dto.UpdateProductRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating update product request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.UpdateProduct(ctx, int64(productID), req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while updating product",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func archiveProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rawProductID := chi.URLParam(r, "id")
+		productID, err := strconv.Atoi(rawProductID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while converting productID to an integer",
+				zap.Error(err),
+				zap.String("id", rawProductID),
+			)
+
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = productSvc.ArchiveProduct(ctx, int64(productID))
+		if err != nil {
+			logger.Errorw(ctx, "error occured while archiving product",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func restockProductHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		productID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		var req dto.RestockProductRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating restock product request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.RestockProduct(ctx, productID, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while restocking product",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func listStockMovementsHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		productID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		req := dto.ListStockMovementsRequest{ProductID: productID}
+		req.Page, req.PageSize, err = parsePageQuery(r.URL.Query())
+		if err != nil {
+			logger.Errorw(ctx, "error occured while parsing list stock movements query",
+				zap.Error(err),
+				zap.String("query", r.URL.RawQuery),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating list stock movements request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := productSvc.ListStockMovements(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching stock movements",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func reconcileStockHandler(productSvc product.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := productSvc.ReconcileStock(ctx)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while reconciling stock",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		//mismatches are reported in the response, the check itself succeeded
+		if len(response.Mismatches) > 0 {
+			logger.Warnw(ctx, "stock on hand differs from the stock ledger",
+				zap.Int("mismatches", len(response.Mismatches)),
+			)
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
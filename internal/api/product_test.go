@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -53,7 +55,7 @@ func (suite *ProductAPITestSuite) TestGetProductHandler() {
 					ID:       1,
 					Name:     "XYZ",
 					Category: "Premium",
-					Price:    100.0,
+					Price:    money.New(10000, "INR"),
 					Quantity: 10,
 				}, nil)
 			},
@@ -98,29 +100,79 @@ func (suite *ProductAPITestSuite) TestGetProductHandler() {
 
 func (suite *ProductAPITestSuite) TestListProductsHandler() {
 	t := suite.T()
+	defaultRequest := dto.ListProductsRequest{Page: 1, PageSize: dto.DefaultPageSize}
+	minPrice := int64(10000)
+
 	testCases := []struct {
 		name               string
+		query              string
 		setup              func()
 		expectedStatusCode int
 	}{
 		{
 			name: "Success",
 			setup: func() {
-				suite.productSvc.On("ListProducts", mock.Anything).Return([]dto.Product{
-					{ID: 1,
-						Name:     "XYZ",
-						Category: "Premium",
-						Price:    100.0,
-						Quantity: 10,
+				suite.productSvc.On("ListProducts", mock.Anything, defaultRequest).Return(dto.ProductList{
+					Products: []dto.Product{
+						{ID: 1,
+							Name:     "XYZ",
+							Category: "Premium",
+							Price:    money.New(10000, "INR"),
+							Quantity: 10,
+						},
 					},
+					Pagination: dto.Pagination{Page: 1, PageSize: dto.DefaultPageSize, TotalItems: 1, TotalPages: 1},
 				}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:  "Success With Filters Sort And Page",
+			query: "?category=Premium&min_price=10000&in_stock=true&name_prefix=%20ip&sort_by=price&order=desc&page=2&page_size=5",
+			setup: func() {
+				suite.productSvc.On("ListProducts", mock.Anything, dto.ListProductsRequest{
+					Category:   "Premium",
+					MinPrice:   &minPrice,
+					InStock:    true,
+					NamePrefix: "ip",
+					SortBy:     "price",
+					SortDesc:   true,
+					Page:       2,
+					PageSize:   5,
+				}).Return(dto.ProductList{Products: []dto.Product{}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because In Stock Not A Boolean",
+			query:              "?in_stock=maybe",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Price Range Inverted",
+			query:              "?min_price=20&max_price=10",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Page Invalid",
+			query:              "?page=0",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Category Unknown",
+			query: "?category=Luxury",
+			setup: func() {
+				suite.productSvc.On("ListProducts", mock.Anything, mock.Anything).Return(dto.ProductList{}, apperrors.ProductListQueryInvalid{Param: "category", Value: "Luxury"})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "Fail Because Something Went Wrong",
 			setup: func() {
-				suite.productSvc.On("ListProducts", mock.Anything).Return([]dto.Product{}, errors.New("something went wrong"))
+				suite.productSvc.On("ListProducts", mock.Anything, defaultRequest).Return(dto.ProductList{}, errors.New("something went wrong"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -132,7 +184,428 @@ func (suite *ProductAPITestSuite) TestListProductsHandler() {
 			test.setup()
 
 			suite.router.Get("/products", listProductHandler(suite.productSvc))
-			req, err := http.NewRequest(http.MethodGet, "/products", bytes.NewBuffer([]byte(``)))
+			req, err := http.NewRequest(http.MethodGet, "/products"+test.query, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductAPITestSuite) TestCreateProductHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		input              dto.CreateProductRequest
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Quantity: 10,
+			},
+			setup: func() {
+				suite.productSvc.On("CreateProduct", mock.Anything, dto.CreateProductRequest{
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    money.New(10000, "INR"),
+					Quantity: 10,
+				}).Return(dto.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    money.New(10000, "INR"),
+					Quantity: 10,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Fail Because Name Missing",
+			input: dto.CreateProductRequest{
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Price Invalid",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(-100, "INR"),
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Price Currency Unsupported",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "XYZ"),
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Price In Another Currency Than The Catalog",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "USD"),
+			},
+			setup: func() {
+				suite.productSvc.On("CreateProduct", mock.Anything, mock.Anything).Return(dto.Product{}, apperrors.CurrencyMismatch{Currency: "USD", Expected: "INR"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Other Prices Repeat A Currency",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Prices:   []money.Money{money.New(150, "USD"), money.New(140, "USD")},
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Other Price In Catalog Currency",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Prices:   []money.Money{money.New(9000, "INR")},
+			},
+			setup: func() {
+				suite.productSvc.On("CreateProduct", mock.Anything, mock.Anything).Return(dto.Product{}, apperrors.PriceCurrencyRepeated{Currency: "INR"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Fail Because Weight Negative",
+			input: dto.CreateProductRequest{
+				Name:        "XYZ",
+				Category:    "Premium",
+				Price:       money.New(10000, "INR"),
+				WeightGrams: -1,
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Dimensions Incomplete",
+			input: dto.CreateProductRequest{
+				Name:       "XYZ",
+				Category:   "Premium",
+				Price:      money.New(10000, "INR"),
+				Dimensions: &dto.Dimensions{LengthMM: 300, WidthMM: 200},
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Category Invalid",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Luxury",
+				Price:    money.New(10000, "INR"),
+			},
+			setup: func() {
+				suite.productSvc.On("CreateProduct", mock.Anything, mock.Anything).Return(dto.Product{}, apperrors.ProductCategoryInvalid{Category: "Luxury"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/products", createProductHandler(suite.productSvc))
+			requestObj, err := json.Marshal(test.input)
+			if err != nil {
+				t.Errorf("error occured while marshaling json request, error : %v", err.Error())
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/products", bytes.NewBuffer(requestObj))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductAPITestSuite) TestUpdateProductHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		productID          interface{}
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			productID: 1,
+			input:     `{"price": {"minor_units": 15000, "currency": "INR"}}`,
+			setup: func() {
+				suite.productSvc.On("UpdateProduct", mock.Anything, int64(1), mock.Anything).Return(dto.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    money.New(15000, "INR"),
+					Quantity: 10,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because No Fields To Update",
+			productID:          1,
+			input:              `{}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Success Updating Weight And Dimensions",
+			productID: 1,
+			input:     `{"weight_grams": 850, "dimensions": {"length_mm": 300, "width_mm": 200, "height_mm": 120}}`,
+			setup: func() {
+				weight := int64(850)
+				suite.productSvc.On("UpdateProduct", mock.Anything, int64(1), dto.UpdateProductRequest{
+					WeightGrams: &weight,
+					Dimensions:  &dto.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 120},
+				}).Return(dto.Product{
+					ID:          1,
+					Name:        "XYZ",
+					Category:    "Premium",
+					Price:       money.New(10000, "INR"),
+					WeightGrams: 850,
+					Dimensions:  &dto.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 120},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Weight Negative",
+			productID:          1,
+			input:              `{"weight_grams": -5}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Invalid ProductID In Request",
+			productID:          "w",
+			input:              `{"price": {"minor_units": 15000, "currency": "INR"}}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Fail Because Product Archived",
+			productID: 1,
+			input:     `{"price": {"minor_units": 15000, "currency": "INR"}}`,
+			setup: func() {
+				suite.productSvc.On("UpdateProduct", mock.Anything, int64(1), mock.Anything).Return(dto.Product{}, apperrors.ProductArchived{ID: 1})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Patch("/products/{id}", updateProductHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/products/%v", test.productID), bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductAPITestSuite) TestArchiveProductHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		productID          interface{}
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			productID: 1,
+			setup: func() {
+				suite.productSvc.On("ArchiveProduct", mock.Anything, int64(1)).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:      "Fail Because Product Not Found",
+			productID: 1,
+			setup: func() {
+				suite.productSvc.On("ArchiveProduct", mock.Anything, int64(1)).Return(apperrors.ProductNotFound{ID: 1})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Delete("/products/{id}", archiveProductHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/products/%v", test.productID), bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductAPITestSuite) TestListStockMovementsHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		path               string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			path: "/products/1/stock-movements?page=2&page_size=5",
+			setup: func() {
+				suite.productSvc.On("ListStockMovements", mock.Anything, dto.ListStockMovementsRequest{
+					ProductID: 1,
+					Page:      2,
+					PageSize:  5,
+				}).Return(dto.StockMovementList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Invalid Product ID",
+			path:               "/products/abc/stock-movements",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Page Size Too Large",
+			path:               "/products/1/stock-movements?page_size=500",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Fail Because Product Not Found",
+			path: "/products/1/stock-movements",
+			setup: func() {
+				suite.productSvc.On("ListStockMovements", mock.Anything, mock.Anything).
+					Return(dto.StockMovementList{}, apperrors.ProductNotFound{ID: 1})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Get("/products/{id}/stock-movements", listStockMovementsHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodGet, test.path, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductAPITestSuite) TestRestockProductHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		productID          interface{}
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			productID: 1,
+			input:     `{"quantity": 10}`,
+			setup: func() {
+				suite.productSvc.On("RestockProduct", mock.Anything, int64(1), dto.RestockProductRequest{Quantity: 10}).
+					Return(dto.Product{ID: 1, Quantity: 12}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Quantity Not Positive",
+			productID:          1,
+			input:              `{"quantity": -2}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Fail Because Product Archived",
+			productID: 1,
+			input:     `{"quantity": 10}`,
+			setup: func() {
+				suite.productSvc.On("RestockProduct", mock.Anything, int64(1), mock.Anything).
+					Return(dto.Product{}, apperrors.ProductArchived{ID: 1})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/products/{id}/restock", restockProductHandler(suite.productSvc))
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/products/%v/restock", test.productID), bytes.NewBuffer([]byte(test.input)))
 			if err != nil {
 				t.Errorf("error occured while making http request, error : %v", err.Error())
 			}
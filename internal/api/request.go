@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/order"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// parseIDParam reads a numeric url param, logging the raw value when it is invalid
+func parseIDParam(r *http.Request, name string) (int64, error) {
+	rawID := chi.URLParam(r, name)
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		logger.Errorw(r.Context(), "error occured while converting url param to an integer",
+			zap.Error(err),
+			zap.String(name, rawID),
+		)
+		return 0, err
+	}
+
+	return int64(id), nil
+}
+
+// parseTimeQuery reads an optional RFC3339 query param, nil when it is absent
+func parseTimeQuery(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// parseMinorUnitsQuery reads an optional amount query param in minor units, nil when it is absent
+func parseMinorUnitsQuery(query url.Values, name string) (*int64, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// parseSortOrderQuery reads the optional `order` query param, true when it asks for a descending sort
+func parseSortOrderQuery(query url.Values) (bool, error) {
+	switch query.Get("order") {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	}
+
+	return false, fmt.Errorf("invalid order: %s", query.Get("order"))
+}
+
+// parsePageQuery reads the `page` and `page_size` query params, defaulting to the first page of dto.DefaultPageSize
+func parsePageQuery(query url.Values) (page, pageSize int, err error) {
+	page, pageSize = 1, dto.DefaultPageSize
+
+	if raw := query.Get("page"); raw != "" {
+		if page, err = strconv.Atoi(raw); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if raw := query.Get("page_size"); raw != "" {
+		if pageSize, err = strconv.Atoi(raw); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return page, pageSize, nil
+}
+
+// customerScope picks the customer whose data a request may see
+type customerScope func(r *http.Request) int64
+
+// callingCustomer scopes the request to the authenticated customer,
+// routes using it must require auth.RoleCustomer
+func callingCustomer(r *http.Request) int64 {
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	return principal.CustomerID
+}
+
+// caller returns the authenticated principal of the request
+func caller(r *http.Request) auth.Principal {
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	return principal
+}
+
+// allCustomers lifts the customer scope for the admin APIs
+func allCustomers(r *http.Request) int64 {
+	return order.AllCustomers
+}
@@ -4,19 +4,34 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sagar23sj/go-ecommerce/internal/app"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	appMiddleware "github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
 )
 
-func NewRouter(deps app.Dependencies) chi.Router {
+func NewRouter(deps app.Dependencies, authenticator *auth.Authenticator) chi.Router {
 	router := chi.NewRouter()
+	router.Use(appMiddleware.Authenticate(authenticator))
 
 	//order APIs
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.Logger)
 
-		r.Post("/orders", createOrderHandler(deps.OrderService))
-		r.Get("/orders", listOrdersHandler(deps.OrderService))
-		r.Get("/orders/{id}", getOrderDetailsHandler(deps.OrderService))
-		r.Patch("/orders/{id}/status", updateOrderStatusHandler(deps.OrderService))
+		//the shipping catalog is public so shipping can be shown before checkout
+		r.Get("/shipping-methods", listShippingMethodsHandler(deps.OrderService))
+
+		//the order service decides which status each role may request
+		r.With(appMiddleware.RequireRole(auth.RoleCustomer, auth.RoleOps, auth.RoleAdmin)).
+			Patch("/orders/{id}/status", updateOrderStatusHandler(deps.OrderService))
+
+		//orders are scoped to the calling customer
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireRole(auth.RoleCustomer))
+
+			r.Post("/orders", createOrderHandler(deps.OrderService))
+			r.Get("/orders", listOrdersHandler(deps.OrderService, callingCustomer))
+			r.Get("/orders/{id}", getOrderDetailsHandler(deps.OrderService, callingCustomer))
+			r.Get("/orders/{id}/history", getOrderHistoryHandler(deps.OrderService, callingCustomer))
+		})
 
 	})
 
@@ -27,6 +42,95 @@ func NewRouter(deps app.Dependencies) chi.Router {
 		r.Get("/products/{id}", getProductHandler(deps.ProductService))
 		r.Get("/products", listProductHandler(deps.ProductService))
 
+		//ops keep prices and stock up to date, only admins change the catalog itself
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireRole(auth.RoleOps, auth.RoleAdmin))
+
+			r.Put("/products/{id}", replaceProductHandler(deps.ProductService))
+			r.Patch("/products/{id}", updateProductHandler(deps.ProductService))
+			r.Post("/products/{id}/restock", restockProductHandler(deps.ProductService))
+			r.Get("/products/{id}/stock-movements", listStockMovementsHandler(deps.ProductService))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireRole(auth.RoleAdmin))
+
+			r.Post("/products", createProductHandler(deps.ProductService))
+			r.Delete("/products/{id}", archiveProductHandler(deps.ProductService))
+		})
+
+	})
+
+	//inventory APIs, warehouse staff adjust the stock of many products at once and see what to reorder
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.Logger)
+		r.Use(appMiddleware.RequireRole(auth.RoleOps, auth.RoleAdmin))
+
+		r.Post("/inventory/adjustments", adjustInventoryHandler(deps.ProductService))
+		r.Get("/inventory/low-stock", lowStockReportHandler(deps.ProductService))
+
+	})
+
+	//customer APIs
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.Logger)
+
+		//customers are registered by the services issuing their tokens
+		r.With(appMiddleware.RequireRole(auth.RoleOps, auth.RoleAdmin)).
+			Post("/customers", createCustomerHandler(deps.CustomerService))
+
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireRole(auth.RoleCustomer))
+
+			r.Get("/customers/me", getCustomerHandler(deps.CustomerService))
+			r.Patch("/customers/me", updateCustomerHandler(deps.CustomerService))
+		})
+
+	})
+
+	//cart APIs, carts are scoped to the calling customer
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.Logger)
+		r.Use(appMiddleware.RequireRole(auth.RoleCustomer))
+
+		r.Post("/carts", createCartHandler(deps.CartService))
+		r.Get("/carts/{id}", getCartHandler(deps.CartService))
+		r.Post("/carts/{id}/items", addCartItemHandler(deps.CartService))
+		r.Put("/carts/{id}/items/{product_id}", updateCartItemHandler(deps.CartService))
+		r.Delete("/carts/{id}/items/{product_id}", removeCartItemHandler(deps.CartService))
+		r.Post("/carts/{id}/checkout", checkoutCartHandler(deps.CartService))
+
+	})
+
+	//admin APIs, not scoped to a customer
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.Logger)
+		r.Use(appMiddleware.RequireRole(auth.RoleOps, auth.RoleAdmin))
+
+		r.Get("/admin/orders", listOrdersHandler(deps.OrderService, allCustomers))
+		r.Get("/admin/orders/{id}", getOrderDetailsHandler(deps.OrderService, allCustomers))
+		r.Get("/admin/orders/{id}/history", getOrderHistoryHandler(deps.OrderService, allCustomers))
+		r.Get("/admin/stock-reconciliation", reconcileStockHandler(deps.ProductService))
+
+		//ops watch and replay deliveries, only admins register partner endpoints
+		r.Get("/admin/webhook-deliveries", listWebhookDeliveriesHandler(deps.WebhookService))
+		r.Post("/admin/webhook-deliveries/{id}/replay", replayWebhookDeliveryHandler(deps.WebhookService))
+
+		//ops look coupons up, only admins issue them
+		r.Get("/admin/coupons", listCouponsHandler(deps.CouponService))
+		r.Get("/admin/coupons/{id}", getCouponHandler(deps.CouponService))
+
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireRole(auth.RoleAdmin))
+
+			r.Post("/admin/coupons", createCouponHandler(deps.CouponService))
+
+			r.Post("/admin/webhooks", createWebhookSubscriptionHandler(deps.WebhookService))
+			r.Get("/admin/webhooks", listWebhookSubscriptionsHandler(deps.WebhookService))
+			r.Get("/admin/webhooks/{id}", getWebhookSubscriptionHandler(deps.WebhookService))
+			r.Delete("/admin/webhooks/{id}", deleteWebhookSubscriptionHandler(deps.WebhookService))
+		})
+
 	})
 
 	return router
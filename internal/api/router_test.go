@@ -0,0 +1,302 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app"
+	cartMocks "github.com/sagar23sj/go-ecommerce/internal/app/cart/mocks"
+	couponMocks "github.com/sagar23sj/go-ecommerce/internal/app/coupon/mocks"
+	customerMocks "github.com/sagar23sj/go-ecommerce/internal/app/customer/mocks"
+	orderMocks "github.com/sagar23sj/go-ecommerce/internal/app/order/mocks"
+	productMocks "github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
+	webhookMocks "github.com/sagar23sj/go-ecommerce/internal/app/webhook/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	testCustomer = auth.Principal{Subject: "customer-1", Role: auth.RoleCustomer, CustomerID: 1}
+	testOps      = auth.Principal{Subject: "warehouse", Role: auth.RoleOps}
+)
+
+// authenticatedAs stores the principal in the request context the way middleware.Authenticate does,
+// a zero principal leaves the request anonymous
+func authenticatedAs(principal auth.Principal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal.Role == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+type RouterTestSuite struct {
+	suite.Suite
+	orderSvc      *orderMocks.Service
+	productSvc    *productMocks.Service
+	webhookSvc    *webhookMocks.Service
+	couponSvc     *couponMocks.Service
+	authenticator *auth.Authenticator
+	router        http.Handler
+}
+
+func TestRouterTestSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *RouterTestSuite) SetupTest() {
+	suite.orderSvc = &orderMocks.Service{}
+	suite.productSvc = &productMocks.Service{}
+	suite.webhookSvc = &webhookMocks.Service{}
+	suite.couponSvc = &couponMocks.Service{}
+	suite.authenticator = auth.NewAuthenticator("test-secret", map[string]auth.Principal{
+		"ops-key": testOps,
+	})
+
+	suite.router = NewRouter(app.Dependencies{
+		OrderService:    suite.orderSvc,
+		ProductService:  suite.productSvc,
+		CartService:     &cartMocks.Service{},
+		CustomerService: &customerMocks.Service{},
+		WebhookService:  suite.webhookSvc,
+		CouponService:   suite.couponSvc,
+	}, suite.authenticator)
+}
+
+// this function executes after all tests executed
+func (suite *RouterTestSuite) TearDownTest() {
+	suite.orderSvc.AssertExpectations(suite.T())
+	suite.productSvc.AssertExpectations(suite.T())
+	suite.webhookSvc.AssertExpectations(suite.T())
+	suite.couponSvc.AssertExpectations(suite.T())
+}
+
+func (suite *RouterTestSuite) TestRoleGates() {
+	t := suite.T()
+
+	customerToken, err := suite.authenticator.IssueToken(testCustomer, time.Hour)
+	suite.Require().NoError(err)
+
+	expiredToken, err := suite.authenticator.IssueToken(testCustomer, -time.Hour)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name               string
+		method             string
+		path               string
+		headers            map[string]string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:   "Success For Anonymous Product Listing",
+			method: http.MethodGet,
+			path:   "/products",
+			setup: func() {
+				suite.productSvc.On("ListProducts", mock.Anything, mock.Anything).Return(dto.ProductList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Orders Require Authentication",
+			method:             http.MethodGet,
+			path:               "/orders",
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Success For Customer Token Listing Own Orders",
+			method: http.MethodGet,
+			path:   "/orders",
+			headers: map[string]string{
+				auth.AuthorizationHeader: "Bearer " + customerToken,
+			},
+			setup: func() {
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(1), mock.Anything).Return(dto.OrderList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because Token Expired",
+			method: http.MethodGet,
+			path:   "/orders",
+			headers: map[string]string{
+				auth.AuthorizationHeader: "Bearer " + expiredToken,
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Success For Anonymous Shipping Methods",
+			method: http.MethodGet,
+			path:   "/shipping-methods",
+			setup: func() {
+				suite.orderSvc.On("ListShippingMethods", mock.Anything, "").Return([]dto.ShippingMethod{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because Customer Cannot Create Products",
+			method: http.MethodPost,
+			path:   "/products",
+			headers: map[string]string{
+				auth.AuthorizationHeader: "Bearer " + customerToken,
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Fail Because Ops Cannot Archive Products",
+			method: http.MethodDelete,
+			path:   "/products/1",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Success For Ops Listing All Orders",
+			method: http.MethodGet,
+			path:   "/admin/orders",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup: func() {
+				suite.orderSvc.On("ListOrders", mock.Anything, int64(0), mock.Anything).Return(dto.OrderList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because Customer Cannot Read Stock Movements",
+			method: http.MethodGet,
+			path:   "/products/1/stock-movements",
+			headers: map[string]string{
+				auth.AuthorizationHeader: "Bearer " + customerToken,
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Fail Because Customer Cannot Adjust Inventory",
+			method: http.MethodPost,
+			path:   "/inventory/adjustments",
+			headers: map[string]string{
+				auth.AuthorizationHeader: "Bearer " + customerToken,
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Success For Ops Reading Low Stock Report",
+			method: http.MethodGet,
+			path:   "/inventory/low-stock",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup: func() {
+				suite.productSvc.On("LowStockReport", mock.Anything).Return(dto.LowStockReport{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Success For Ops Reconciling Stock",
+			method: http.MethodGet,
+			path:   "/admin/stock-reconciliation",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup: func() {
+				suite.productSvc.On("ReconcileStock", mock.Anything).Return(dto.StockReconciliation{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because Ops Cannot Register Webhooks",
+			method: http.MethodPost,
+			path:   "/admin/webhooks",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Success For Ops Listing Webhook Deliveries",
+			method: http.MethodGet,
+			path:   "/admin/webhook-deliveries?status=dead",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup: func() {
+				suite.webhookSvc.On("ListDeliveries", mock.Anything, mock.Anything).Return(dto.WebhookDeliveryList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because Ops Cannot Create Coupons",
+			method: http.MethodPost,
+			path:   "/admin/coupons",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Success For Ops Listing Coupons",
+			method: http.MethodGet,
+			path:   "/admin/coupons",
+			headers: map[string]string{
+				auth.APIKeyHeader: "ops-key",
+			},
+			setup: func() {
+				suite.couponSvc.On("ListCoupons", mock.Anything).Return([]dto.Coupon{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Fail Because API Key Unknown",
+			method: http.MethodGet,
+			path:   "/admin/orders",
+			headers: map[string]string{
+				auth.APIKeyHeader: "unknown-key",
+			},
+			setup:              func() {},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			req, err := http.NewRequest(test.method, test.path, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
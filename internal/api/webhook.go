@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/webhook"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/middleware"
+	"go.uber.org/zap"
+)
+
+func createWebhookSubscriptionHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req dto.CreateWebhookSubscriptionRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while decoding request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestBody)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating create webhook subscription request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := webhookSvc.CreateSubscription(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while creating webhook subscription",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusCreated, response)
+	}
+}
+
+func listWebhookSubscriptionsHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		response, err := webhookSvc.ListSubscriptions(ctx)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching webhook subscriptions list",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func getWebhookSubscriptionHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		subscriptionID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := webhookSvc.GetSubscription(ctx, subscriptionID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching webhook subscription",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+func deleteWebhookSubscriptionHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		subscriptionID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = webhookSvc.DeleteSubscription(ctx, subscriptionID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while deleting webhook subscription",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listWebhookDeliveriesHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		req, err := parseListWebhookDeliveriesRequest(r.URL.Query())
+		if err != nil {
+			logger.Errorw(ctx, "error occured while parsing list webhook deliveries query",
+				zap.Error(err),
+				zap.String("query", r.URL.RawQuery),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			logger.Errorw(ctx, "error occured while validating list webhook deliveries request",
+				zap.Error(err),
+			)
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		response, err := webhookSvc.ListDeliveries(ctx, req)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while fetching webhook deliveries list",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
+
+// parseListWebhookDeliveriesRequest reads the subscription and status filters and the page of a delivery listing
+func parseListWebhookDeliveriesRequest(query url.Values) (req dto.ListWebhookDeliveriesRequest, err error) {
+	req = dto.ListWebhookDeliveriesRequest{
+		Status: query.Get("status"),
+	}
+
+	if raw := query.Get("subscription_id"); raw != "" {
+		if req.SubscriptionID, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return dto.ListWebhookDeliveriesRequest{}, err
+		}
+	}
+
+	if req.Page, req.PageSize, err = parsePageQuery(query); err != nil {
+		return dto.ListWebhookDeliveriesRequest{}, err
+	}
+
+	return req, nil
+}
+
+func replayWebhookDeliveryHandler(webhookSvc webhook.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		deliveryID, err := parseIDParam(r, "id")
+		if err != nil {
+			middleware.ErrorResponse(ctx, w, http.StatusBadRequest, apperrors.ErrInvalidRequestParam)
+			return
+		}
+
+		response, err := webhookSvc.ReplayDelivery(ctx, deliveryID)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while replaying webhook delivery",
+				zap.Error(err),
+			)
+			statusCode, errResponse := apperrors.MapError(err)
+			middleware.ErrorResponse(ctx, w, statusCode, errResponse)
+			return
+		}
+
+		middleware.SuccessResponse(ctx, w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sagar23sj/go-ecommerce/internal/app/webhook/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookAPITestSuite struct {
+	suite.Suite
+	webhookSvc *mocks.Service
+	router     chi.Router
+}
+
+func TestWebhookAPITestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookAPITestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *WebhookAPITestSuite) SetupTest() {
+	suite.webhookSvc = &mocks.Service{}
+	suite.router = chi.NewRouter()
+}
+
+// this function executes after all tests executed
+func (suite *WebhookAPITestSuite) TearDownTest() {
+	suite.webhookSvc.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookAPITestSuite) TestCreateWebhookSubscriptionHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		input              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			input: `{"url": "https://partner.example.com/hooks", "secret": "0123456789abcdef", "event_types": ["OrderPlaced", "OrderPlaced"]}`,
+			setup: func() {
+				suite.webhookSvc.On("CreateSubscription", mock.Anything, dto.CreateWebhookSubscriptionRequest{
+					URL:        "https://partner.example.com/hooks",
+					Secret:     "0123456789abcdef",
+					EventTypes: []string{"OrderPlaced"},
+				}).Return(dto.WebhookSubscription{ID: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Fail Because URL Is Not Absolute",
+			input:              `{"url": "/hooks", "secret": "0123456789abcdef", "event_types": ["OrderPlaced"]}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Fail Because Secret Too Short",
+			input:              `{"url": "https://partner.example.com/hooks", "secret": "short", "event_types": ["OrderPlaced"]}`,
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Event Type Cannot Be Subscribed",
+			input: `{"url": "https://partner.example.com/hooks", "secret": "0123456789abcdef", "event_types": ["StockAdjusted"]}`,
+			setup: func() {
+				suite.webhookSvc.On("CreateSubscription", mock.Anything, mock.Anything).
+					Return(dto.WebhookSubscription{}, apperrors.WebhookEventTypeInvalid{EventType: "StockAdjusted"})
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/admin/webhooks", createWebhookSubscriptionHandler(suite.webhookSvc))
+			req, err := http.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewBuffer([]byte(test.input)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookAPITestSuite) TestDeleteWebhookSubscriptionHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		subscriptionID     string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:           "Success",
+			subscriptionID: "1",
+			setup: func() {
+				suite.webhookSvc.On("DeleteSubscription", mock.Anything, int64(1)).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Fail Because Invalid Subscription ID",
+			subscriptionID:     "abc",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "Fail Because Subscription Does Not Exist",
+			subscriptionID: "1",
+			setup: func() {
+				suite.webhookSvc.On("DeleteSubscription", mock.Anything, int64(1)).Return(apperrors.WebhookSubscriptionNotFound{ID: 1})
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Delete("/admin/webhooks/{id}", deleteWebhookSubscriptionHandler(suite.webhookSvc))
+			req, err := http.NewRequest(http.MethodDelete, "/admin/webhooks/"+test.subscriptionID, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookAPITestSuite) TestListWebhookDeliveriesHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		query              string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			query: "?subscription_id=1&status=dead&page=2&page_size=5",
+			setup: func() {
+				suite.webhookSvc.On("ListDeliveries", mock.Anything, dto.ListWebhookDeliveriesRequest{
+					SubscriptionID: 1,
+					Status:         "dead",
+					Page:           2,
+					PageSize:       5,
+				}).Return(dto.WebhookDeliveryList{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fail Because Invalid Subscription ID",
+			query:              "?subscription_id=abc",
+			setup:              func() {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail Because Status Is Unknown",
+			query: "?status=lost",
+			setup: func() {
+				suite.webhookSvc.On("ListDeliveries", mock.Anything, mock.Anything).
+					Return(dto.WebhookDeliveryList{}, apperrors.WebhookDeliveryStatusInvalid{Status: "lost"})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Get("/admin/webhook-deliveries", listWebhookDeliveriesHandler(suite.webhookSvc))
+			req, err := http.NewRequest(http.MethodGet, "/admin/webhook-deliveries"+test.query, bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookAPITestSuite) TestReplayWebhookDeliveryHandler() {
+	t := suite.T()
+	testCases := []struct {
+		name               string
+		setup              func()
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.webhookSvc.On("ReplayDelivery", mock.Anything, int64(1)).Return(dto.WebhookDelivery{ID: 1, Status: "pending"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Fail Because Delivery Is Not Dead",
+			setup: func() {
+				suite.webhookSvc.On("ReplayDelivery", mock.Anything, int64(1)).
+					Return(dto.WebhookDelivery{}, apperrors.WebhookDeliveryNotDead{ID: 1, Status: "delivered"})
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			suite.router.Post("/admin/webhook-deliveries/{id}/replay", replayWebhookDeliveryHandler(suite.webhookSvc))
+			req, err := http.NewRequest(http.MethodPost, "/admin/webhook-deliveries/1/replay", bytes.NewBuffer([]byte(``)))
+			if err != nil {
+				t.Errorf("error occured while making http request, error : %v", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+			suite.router.ServeHTTP(recorder, req)
+
+			suite.Equal(test.expectedStatusCode, recorder.Code)
+		})
+		suite.TearDownTest()
+	}
+}
@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"fmt"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+// cart lifecycle, a cart is editable only while Open
+const (
+	CartOpen        = "Open"
+	CartCheckingOut = "CheckingOut"
+	CartCheckedOut  = "CheckedOut"
+)
+
+func MapCartRepoToCartDto(cart repository.Cart, items []dto.CartItem) dto.Cart {
+	return dto.Cart{
+		ID:        int64(cart.ID),
+		Status:    cart.Status,
+		Items:     items,
+		OrderID:   cart.OrderID,
+		CreatedAt: cart.CreatedAt,
+		UpdatedAt: cart.UpdatedAt,
+	}
+}
+
+// MapCartItemsToOrderRequest builds the order placed by the checkout of the cart, keyed by the cart
+// so a retried checkout replays the order placed by an interrupted one
+func MapCartItemsToOrderRequest(cart repository.Cart, cartItems []repository.CartItem) dto.CreateOrderRequest {
+	products := make([]dto.ProductInfo, 0)
+	for _, item := range cartItems {
+		products = append(products, dto.ProductInfo{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return dto.CreateOrderRequest{
+		CustomerID:     cart.CustomerID,
+		IdempotencyKey: fmt.Sprintf("cart-%d", cart.ID),
+		Products:       products,
+	}
+}
@@ -0,0 +1,383 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/order"
+	"github.com/sagar23sj/go-ecommerce/internal/app/pricing"
+	"github.com/sagar23sj/go-ecommerce/internal/app/product"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type service struct {
+	cartRepo   repository.CartStorer
+	productSvc product.Service
+	orderSvc   order.Service
+	cfg        config.OrderConfig
+	pricing    *pricing.Engine
+}
+
+type Service interface {
+	//every cart belongs to the customer who created it, carts of other customers are not found
+	CreateCart(ctx context.Context, customerID int64) (dto.Cart, error)
+	GetCart(ctx context.Context, customerID, cartID int64) (dto.Cart, error)
+	AddCartItem(ctx context.Context, customerID, cartID int64, item dto.AddCartItemRequest) (dto.Cart, error)
+	UpdateCartItem(ctx context.Context, customerID, cartID, productID, quantity int64) (dto.Cart, error)
+	RemoveCartItem(ctx context.Context, customerID, cartID, productID int64) (dto.Cart, error)
+	Checkout(ctx context.Context, customerID, cartID int64) (dto.Order, error)
+}
+
+func NewService(cartRepo repository.CartStorer, productSvc product.Service, orderSvc order.Service, cfg config.OrderConfig) Service {
+	return &service{
+		cartRepo:   cartRepo,
+		productSvc: productSvc,
+		orderSvc:   orderSvc,
+		cfg:        cfg,
+		pricing:    pricing.MustNewEngine(cfg.Currency, cfg.ActivePromotions()),
+	}
+}
+
+func (cs *service) CreateCart(ctx context.Context, customerID int64) (dto.Cart, error) {
+	cartDB, err := cs.cartRepo.CreateCart(ctx, nil, repository.Cart{CustomerID: customerID, Status: CartOpen})
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	return MapCartRepoToCartDto(cartDB, []dto.CartItem{}), nil
+}
+
+func (cs *service) GetCart(ctx context.Context, customerID, cartID int64) (dto.Cart, error) {
+	cartDB, err := cs.getCart(ctx, nil, customerID, cartID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	return cs.priceCart(ctx, nil, cartDB)
+}
+
+func (cs *service) AddCartItem(ctx context.Context, customerID, cartID int64, item dto.AddCartItemRequest) (cart dto.Cart, err error) {
+	//initializing database transaction
+	tx, err := cs.cartRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	defer func() {
+		txErr := cs.cartRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	cartDB, err := cs.getOpenCart(ctx, tx, customerID, cartID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	cartItems, err := cs.cartRepo.GetCartItems(ctx, tx, cartID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	//adding a product already in the cart increases its quantity
+	quantity := item.Quantity
+	for _, cartItem := range cartItems {
+		if cartItem.ProductID == item.ProductID {
+			quantity = quantity + cartItem.Quantity
+		}
+	}
+
+	err = cs.validateCartItem(ctx, tx, item.ProductID, quantity)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.cartRepo.UpsertCartItem(ctx, tx, repository.CartItem{
+		CartID:    cartID,
+		ProductID: item.ProductID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	return cs.priceCart(ctx, tx, cartDB)
+}
+
+func (cs *service) UpdateCartItem(ctx context.Context, customerID, cartID, productID, quantity int64) (cart dto.Cart, err error) {
+	//initializing database transaction
+	tx, err := cs.cartRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	defer func() {
+		txErr := cs.cartRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	cartDB, err := cs.getOpenCart(ctx, tx, customerID, cartID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.ensureCartItemExists(ctx, tx, cartID, productID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.validateCartItem(ctx, tx, productID, quantity)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.cartRepo.UpsertCartItem(ctx, tx, repository.CartItem{
+		CartID:    cartID,
+		ProductID: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	return cs.priceCart(ctx, tx, cartDB)
+}
+
+func (cs *service) RemoveCartItem(ctx context.Context, customerID, cartID, productID int64) (cart dto.Cart, err error) {
+	//initializing database transaction
+	tx, err := cs.cartRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	defer func() {
+		txErr := cs.cartRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	cartDB, err := cs.getOpenCart(ctx, tx, customerID, cartID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.ensureCartItemExists(ctx, tx, cartID, productID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	err = cs.cartRepo.DeleteCartItem(ctx, tx, cartID, productID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	return cs.priceCart(ctx, tx, cartDB)
+}
+
+func (cs *service) Checkout(ctx context.Context, customerID, cartID int64) (dto.Order, error) {
+	orderRequest, err := cs.startCheckout(ctx, customerID, cartID)
+	if err != nil {
+		return dto.Order{}, err
+	}
+
+	//the order is created in its own transaction, the CheckingOut state keeps the cart locked against edits meanwhile.
+	//Keyed by the cart, the order placed by a checkout interrupted before the cart was checked out is replayed on retry.
+	order, err := cs.orderSvc.CreateOrder(ctx, orderRequest)
+	if err != nil {
+		_, reopenErr := cs.cartRepo.TransitionCartStatus(ctx, nil, cartID, CartCheckingOut, CartOpen, 0)
+		if reopenErr != nil {
+			return dto.Order{}, fmt.Errorf("error occured while reopening cart after failed checkout: %w", reopenErr)
+		}
+
+		return dto.Order{}, err
+	}
+
+	_, err = cs.cartRepo.TransitionCartStatus(ctx, nil, cartID, CartCheckingOut, CartCheckedOut, order.ID)
+	if err != nil {
+		return dto.Order{}, fmt.Errorf("error occured while marking cart as checked out: %w", err)
+	}
+
+	return order, nil
+}
+
+// startCheckout locks an open cart for checkout and builds the order request from its items,
+// a cart left checking out by an interrupted checkout is checked out again
+func (cs *service) startCheckout(ctx context.Context, customerID, cartID int64) (orderRequest dto.CreateOrderRequest, err error) {
+	//initializing database transaction
+	tx, err := cs.cartRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.CreateOrderRequest{}, err
+	}
+
+	defer func() {
+		txErr := cs.cartRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	cartDB, err := cs.getCart(ctx, tx, customerID, cartID)
+	if err != nil {
+		return dto.CreateOrderRequest{}, err
+	}
+
+	//cart already checked out, return error CartNotOpen
+	if cartDB.Status != CartOpen && cartDB.Status != CartCheckingOut {
+		return dto.CreateOrderRequest{}, apperrors.CartNotOpen{ID: cartID, Status: cartDB.Status}
+	}
+
+	cartItems, err := cs.cartRepo.GetCartItems(ctx, tx, cartID)
+	if err != nil {
+		return dto.CreateOrderRequest{}, err
+	}
+
+	if len(cartItems) == 0 {
+		return dto.CreateOrderRequest{}, apperrors.CartEmpty{ID: cartID}
+	}
+
+	if cartDB.Status == CartOpen {
+		updated, err := cs.cartRepo.TransitionCartStatus(ctx, tx, cartID, CartOpen, CartCheckingOut, 0)
+		if err != nil {
+			return dto.CreateOrderRequest{}, err
+		}
+
+		//checked out meanwhile, return error CartNotOpen
+		if !updated {
+			return dto.CreateOrderRequest{}, apperrors.CartNotOpen{ID: cartID, Status: cartDB.Status}
+		}
+	}
+
+	return MapCartItemsToOrderRequest(cartDB, cartItems), nil
+}
+
+func (cs *service) getCart(ctx context.Context, tx repository.Transaction, customerID, cartID int64) (repository.Cart, error) {
+	cartDB, err := cs.cartRepo.GetCartByID(ctx, tx, cartID)
+	if err != nil {
+		return repository.Cart{}, err
+	}
+
+	//cart not found or owned by another customer, return error CartNotFound
+	if cartDB.ID == 0 || cartDB.CustomerID != customerID {
+		return repository.Cart{}, apperrors.CartNotFound{ID: cartID}
+	}
+
+	return cartDB, nil
+}
+
+func (cs *service) getOpenCart(ctx context.Context, tx repository.Transaction, customerID, cartID int64) (repository.Cart, error) {
+	cartDB, err := cs.getCart(ctx, tx, customerID, cartID)
+	if err != nil {
+		return repository.Cart{}, err
+	}
+
+	//cart already checked out, return error CartNotOpen
+	if cartDB.Status != CartOpen {
+		return repository.Cart{}, apperrors.CartNotOpen{ID: cartID, Status: cartDB.Status}
+	}
+
+	return cartDB, nil
+}
+
+func (cs *service) ensureCartItemExists(ctx context.Context, tx repository.Transaction, cartID, productID int64) error {
+	cartItems, err := cs.cartRepo.GetCartItems(ctx, tx, cartID)
+	if err != nil {
+		return err
+	}
+
+	for _, cartItem := range cartItems {
+		if cartItem.ProductID == productID {
+			return nil
+		}
+	}
+
+	return apperrors.CartItemNotFound{CartID: cartID, ProductID: productID}
+}
+
+// validateCartItem applies the same product checks as order creation so checkout does not fail late
+func (cs *service) validateCartItem(ctx context.Context, tx repository.Transaction, productID, quantity int64) error {
+	productInfo, err := cs.productSvc.GetProductByID(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	if productInfo.Archived {
+		return apperrors.ProductArchived{ID: productID}
+	}
+
+	if quantity > cs.cfg.MaxProductQuantity {
+		return apperrors.ProductQuantityExceeded{
+			ID:            productID,
+			QuantityAsked: quantity,
+			QuantityLimit: cs.cfg.MaxProductQuantity,
+		}
+	}
+
+	if productInfo.AvailableToSell < quantity {
+		return apperrors.ProductQuantityInsufficient{
+			ID:                productID,
+			QuantityAsked:     quantity,
+			QuantityRemaining: productInfo.AvailableToSell,
+		}
+	}
+
+	return nil
+}
+
+// priceCart prices every cart item at the current catalog price and applies the promotions of orders
+func (cs *service) priceCart(ctx context.Context, tx repository.Transaction, cartDB repository.Cart) (dto.Cart, error) {
+	cartItems, err := cs.cartRepo.GetCartItems(ctx, tx, int64(cartDB.ID))
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	items := make([]dto.CartItem, 0)
+	lines := make([]pricing.Line, 0, len(cartItems))
+
+	for _, cartItem := range cartItems {
+		productInfo, err := cs.productSvc.GetProductByID(ctx, tx, cartItem.ProductID)
+		if err != nil {
+			return dto.Cart{}, err
+		}
+
+		line := pricing.Line{
+			ProductID: cartItem.ProductID,
+			Category:  productInfo.Category,
+			Price:     productInfo.Price,
+			Quantity:  cartItem.Quantity,
+		}
+		lines = append(lines, line)
+
+		items = append(items, dto.CartItem{
+			ProductID: cartItem.ProductID,
+			Name:      productInfo.Name,
+			Category:  productInfo.Category,
+			Price:     productInfo.Price,
+			Quantity:  cartItem.Quantity,
+			LineTotal: line.Total(),
+		})
+	}
+
+	cart := MapCartRepoToCartDto(cartDB, items)
+	quote, err := cs.pricing.Price(lines)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	cart.Amount = quote.Amount
+	cart.DiscountPercentage = quote.DiscountPercentage
+	cart.Discounts = quote.Discounts
+	cart.FinalAmount = quote.FinalAmount
+
+	return cart, nil
+}
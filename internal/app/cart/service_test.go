@@ -0,0 +1,271 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+	orderMock "github.com/sagar23sj/go-ecommerce/internal/app/order/mocks"
+	productMock "github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartServiceTestSuite struct {
+	suite.Suite
+	service        Service
+	cartRepo       *mocks.CartStorer
+	productService *productMock.Service
+	orderService   *orderMock.Service
+}
+
+func TestCartServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CartServiceTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CartServiceTestSuite) SetupTest() {
+	suite.cartRepo = &mocks.CartStorer{}
+	suite.productService = &productMock.Service{}
+	suite.orderService = &orderMock.Service{}
+
+	suite.service = NewService(suite.cartRepo, suite.productService, suite.orderService, config.Default().Order)
+}
+
+// this function executes after all tests executed
+func (suite *CartServiceTestSuite) TearDownTest() {
+	suite.cartRepo.AssertExpectations(suite.T())
+	suite.productService.AssertExpectations(suite.T())
+	suite.orderService.AssertExpectations(suite.T())
+}
+
+func (suite *CartServiceTestSuite) TestGetCart() {
+	testCases := []struct {
+		name           string
+		setup          func()
+		expectedOutput dto.Cart
+		expectedErr    error
+	}{
+		{
+			name: "Success With Premium Discount",
+			setup: func() {
+				suite.cartRepo.On("GetCartByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, mock.Anything, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 2},
+					{CartID: 1, ProductID: 2, Quantity: 2},
+					{CartID: 1, ProductID: 3, Quantity: 2},
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, mock.Anything, int64(1)).Return(dto.Product{ID: 1, Price: money.New(1000, "INR"), Category: "Premium"}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, mock.Anything, int64(2)).Return(dto.Product{ID: 2, Price: money.New(2000, "INR"), Category: "Premium"}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, mock.Anything, int64(3)).Return(dto.Product{ID: 3, Price: money.New(3000, "INR"), Category: "Premium"}, nil)
+			},
+			expectedOutput: dto.Cart{
+				ID:                 1,
+				Status:             CartOpen,
+				Amount:             money.New(12000, "INR"),
+				DiscountPercentage: 10.0,
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: money.New(1200, "INR")}},
+				FinalAmount:        money.New(10800, "INR"),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Cart Not Found",
+			setup: func() {
+				suite.cartRepo.On("GetCartByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Cart{}, nil)
+			},
+			expectedOutput: dto.Cart{},
+			expectedErr:    apperrors.CartNotFound{ID: 1},
+		},
+		{
+			name: "Fail Because Cart Belongs To Another Customer",
+			setup: func() {
+				suite.cartRepo.On("GetCartByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 2, Status: CartOpen}, nil)
+			},
+			expectedOutput: dto.Cart{},
+			expectedErr:    apperrors.CartNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			cart, err := suite.service.GetCart(context.Background(), 1, 1)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput.ID, cart.ID)
+			suite.Equal(test.expectedOutput.Amount, cart.Amount)
+			suite.Equal(test.expectedOutput.DiscountPercentage, cart.DiscountPercentage)
+			suite.Equal(test.expectedOutput.FinalAmount, cart.FinalAmount)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CartServiceTestSuite) TestAddCartItem() {
+	testCases := []struct {
+		name        string
+		input       dto.AddCartItemRequest
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name:  "Success When Product Already In Cart",
+			input: dto.AddCartItemRequest{ProductID: 1, Quantity: 2},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 3},
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{ID: 1, Price: money.New(1000, "INR"), Category: "Budget", Quantity: 10, AvailableToSell: 10}, nil)
+				suite.cartRepo.On("UpsertCartItem", mock.Anything, tx, repository.CartItem{CartID: 1, ProductID: 1, Quantity: 5}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Fail Because Cart Already Checked Out",
+			input: dto.AddCartItemRequest{ProductID: 1, Quantity: 2},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartCheckedOut}, nil)
+			},
+			expectedErr: apperrors.CartNotOpen{ID: 1, Status: CartCheckedOut},
+		},
+		{
+			name:  "Fail Because Product Quantity Exceeded",
+			input: dto.AddCartItemRequest{ProductID: 1, Quantity: 8},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 3},
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{ID: 1, Price: money.New(1000, "INR"), Category: "Budget", Quantity: 20, AvailableToSell: 20}, nil)
+			},
+			expectedErr: apperrors.ProductQuantityExceeded{ID: 1, QuantityAsked: 11, QuantityLimit: 10},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			_, err := suite.service.AddCartItem(context.Background(), 1, 1, test.input)
+			suite.Equal(test.expectedErr, err)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CartServiceTestSuite) TestCheckout() {
+	orderRequest := dto.CreateOrderRequest{CustomerID: 1, IdempotencyKey: "cart-1", Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}}}
+
+	testCases := []struct {
+		name           string
+		setup          func()
+		expectedOutput dto.Order
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 2},
+				}, nil)
+				suite.cartRepo.On("TransitionCartStatus", mock.Anything, tx, int64(1), CartOpen, CartCheckingOut, int64(0)).Return(true, nil)
+				suite.orderService.On("CreateOrder", mock.Anything, orderRequest).Return(dto.Order{ID: 7, Status: "Placed"}, nil)
+				suite.cartRepo.On("TransitionCartStatus", mock.Anything, nil, int64(1), CartCheckingOut, CartCheckedOut, int64(7)).Return(true, nil)
+			},
+			expectedOutput: dto.Order{ID: 7, Status: "Placed"},
+			expectedErr:    nil,
+		},
+		{
+			name: "Success Replaying Order Of Interrupted Checkout",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartCheckingOut}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 2},
+				}, nil)
+				suite.orderService.On("CreateOrder", mock.Anything, orderRequest).Return(dto.Order{ID: 7, Status: "Placed"}, nil)
+				suite.cartRepo.On("TransitionCartStatus", mock.Anything, nil, int64(1), CartCheckingOut, CartCheckedOut, int64(7)).Return(true, nil)
+			},
+			expectedOutput: dto.Order{ID: 7, Status: "Placed"},
+			expectedErr:    nil,
+		},
+		{
+			name: "Fail Because Cart Already Checked Out",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartCheckedOut}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CartNotOpen{ID: 1, Status: CartCheckedOut},
+		},
+		{
+			name: "Fail Because Cart Empty",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CartEmpty{ID: 1},
+		},
+		{
+			name: "Fail And Reopen Cart When Order Creation Failed",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.cartRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.cartRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.cartRepo.On("GetCartByID", mock.Anything, tx, int64(1)).Return(repository.Cart{ID: 1, CustomerID: 1, Status: CartOpen}, nil)
+				suite.cartRepo.On("GetCartItems", mock.Anything, tx, int64(1)).Return([]repository.CartItem{
+					{CartID: 1, ProductID: 1, Quantity: 2},
+				}, nil)
+				suite.cartRepo.On("TransitionCartStatus", mock.Anything, tx, int64(1), CartOpen, CartCheckingOut, int64(0)).Return(true, nil)
+				suite.orderService.On("CreateOrder", mock.Anything, orderRequest).Return(dto.Order{}, errors.New("something went wrong"))
+				suite.cartRepo.On("TransitionCartStatus", mock.Anything, nil, int64(1), CartCheckingOut, CartOpen, int64(0)).Return(true, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    errors.New("something went wrong"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			order, err := suite.service.Checkout(context.Background(), 1, 1)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput.ID, order.ID)
+		})
+		suite.TearDownTest()
+	}
+}
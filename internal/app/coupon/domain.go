@@ -0,0 +1,87 @@
+package coupon
+
+import (
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+// validityReason explains why the coupon cannot be redeemed at the time, empty within its validity window
+func validityReason(coupon repository.Coupon, at time.Time) string {
+	if !coupon.ValidFrom.IsZero() && at.Before(coupon.ValidFrom) {
+		return "coupon is not valid yet"
+	}
+
+	if !coupon.ValidUntil.IsZero() && !at.Before(coupon.ValidUntil) {
+		return "coupon has expired"
+	}
+
+	return ""
+}
+
+// MapCreateRequestToRepo stores the coupon in the currency of the catalog, the amount of a fixed coupon is priced in it
+func MapCreateRequestToRepo(req dto.CreateCouponRequest, currency string) repository.Coupon {
+	coupon := repository.Coupon{
+		Code:             req.Code,
+		DiscountType:     req.DiscountType,
+		Percent:          req.Percent,
+		Currency:         currency,
+		Categories:       req.Categories,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+	}
+
+	if req.Amount != nil {
+		coupon.Amount = req.Amount.MinorUnits
+	}
+
+	if req.ValidFrom != nil {
+		coupon.ValidFrom = req.ValidFrom.UTC()
+	}
+
+	if req.ValidUntil != nil {
+		coupon.ValidUntil = req.ValidUntil.UTC()
+	}
+
+	return coupon
+}
+
+func MapCouponRepoToDto(coupon repository.Coupon) dto.Coupon {
+	categories := coupon.Categories
+	if categories == nil {
+		categories = make([]string, 0)
+	}
+
+	var amount *money.Money
+	if coupon.DiscountType == constants.PromotionFixed {
+		fixedAmount := money.New(coupon.Amount, coupon.Currency)
+		amount = &fixedAmount
+	}
+
+	return dto.Coupon{
+		ID:               int64(coupon.ID),
+		Code:             coupon.Code,
+		DiscountType:     coupon.DiscountType,
+		Percent:          coupon.Percent,
+		Amount:           amount,
+		Categories:       categories,
+		ValidFrom:        timeOrNil(coupon.ValidFrom),
+		ValidUntil:       timeOrNil(coupon.ValidUntil),
+		UsageLimit:       coupon.UsageLimit,
+		PerCustomerLimit: coupon.PerCustomerLimit,
+		TimesUsed:        coupon.TimesUsed,
+		CreatedAt:        coupon.CreatedAt,
+		UpdatedAt:        coupon.UpdatedAt,
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,174 @@
+package coupon
+
+import (
+	"context"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/product"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+var now = time.Now
+
+type service struct {
+	couponRepo repository.CouponStorer
+	currency   string
+}
+
+type Service interface {
+	CreateCoupon(ctx context.Context, req dto.CreateCouponRequest) (dto.Coupon, error)
+	GetCoupon(ctx context.Context, couponID int64) (dto.Coupon, error)
+	ListCoupons(ctx context.Context) ([]dto.Coupon, error)
+	//GetRedeemableCoupon returns the coupon of the code when it is valid now and has uses left for the customer,
+	//the usage limits are only guaranteed by RedeemCoupon
+	GetRedeemableCoupon(ctx context.Context, tx repository.Transaction, customerID int64, code string) (dto.Coupon, error)
+	//RedeemCoupon records the use of the coupon by an order within the transaction of the order,
+	//it fails with CouponUsageExceeded once a usage limit is reached by concurrent orders
+	RedeemCoupon(ctx context.Context, tx repository.Transaction, coupon dto.Coupon, customerID, orderID int64, amount money.Money) error
+}
+
+// NewService returns the coupon service of a catalog priced in currency, fixed coupons take amounts of it off
+func NewService(couponRepo repository.CouponStorer, currency string) Service {
+	return &service{
+		couponRepo: couponRepo,
+		currency:   currency,
+	}
+}
+
+func (cs *service) CreateCoupon(ctx context.Context, req dto.CreateCouponRequest) (coupon dto.Coupon, err error) {
+	//product category invalid, return error ProductCategoryInvalid
+	for _, category := range req.Categories {
+		if !product.ProductType(category).IsValid() {
+			return dto.Coupon{}, apperrors.ProductCategoryInvalid{Category: category}
+		}
+	}
+
+	//fixed amount in another currency than the catalog, return error CurrencyMismatch
+	if req.Amount != nil && req.Amount.Currency != cs.currency {
+		return dto.Coupon{}, apperrors.CurrencyMismatch{Currency: req.Amount.Currency, Expected: cs.currency}
+	}
+
+	//initializing database transaction
+	tx, err := cs.couponRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Coupon{}, err
+	}
+
+	defer func() {
+		txErr := cs.couponRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	existingCoupon, err := cs.couponRepo.GetCouponByCode(ctx, tx, req.Code)
+	if err != nil {
+		return dto.Coupon{}, err
+	}
+
+	//code already taken, return error CouponCodeExists
+	if existingCoupon.ID != 0 {
+		return dto.Coupon{}, apperrors.CouponCodeExists{Code: req.Code}
+	}
+
+	couponDB, err := cs.couponRepo.CreateCoupon(ctx, tx, MapCreateRequestToRepo(req, cs.currency))
+	if err != nil {
+		return dto.Coupon{}, err
+	}
+
+	return MapCouponRepoToDto(couponDB), nil
+}
+
+func (cs *service) GetCoupon(ctx context.Context, couponID int64) (dto.Coupon, error) {
+	couponDB, err := cs.couponRepo.GetCouponByID(ctx, nil, couponID)
+	if err != nil {
+		return dto.Coupon{}, err
+	}
+
+	if couponDB.ID == 0 {
+		return dto.Coupon{}, apperrors.CouponNotFound{ID: couponID}
+	}
+
+	return MapCouponRepoToDto(couponDB), nil
+}
+
+func (cs *service) ListCoupons(ctx context.Context) ([]dto.Coupon, error) {
+	coupons := make([]dto.Coupon, 0)
+
+	couponsDB, err := cs.couponRepo.ListCoupons(ctx, nil)
+	if err != nil {
+		return coupons, err
+	}
+
+	for _, coupon := range couponsDB {
+		coupons = append(coupons, MapCouponRepoToDto(coupon))
+	}
+
+	return coupons, nil
+}
+
+func (cs *service) GetRedeemableCoupon(ctx context.Context, tx repository.Transaction, customerID int64, code string) (dto.Coupon, error) {
+	code = dto.NormalizeCouponCode(code)
+
+	couponDB, err := cs.couponRepo.GetCouponByCode(ctx, tx, code)
+	if err != nil {
+		return dto.Coupon{}, err
+	}
+
+	//coupon unknown, outside its validity window or priced in another currency, return error CouponNotRedeemable
+	if couponDB.ID == 0 {
+		return dto.Coupon{}, apperrors.CouponNotRedeemable{Code: code, Reason: "unknown coupon code"}
+	}
+
+	reason := validityReason(couponDB, now())
+	if couponDB.DiscountType == constants.PromotionFixed && couponDB.Currency != cs.currency {
+		reason = "coupon is priced in " + couponDB.Currency
+	}
+
+	if reason != "" {
+		return dto.Coupon{}, apperrors.CouponNotRedeemable{Code: code, Reason: reason}
+	}
+
+	//coupon used up overall or by the customer, return error CouponUsageExceeded
+	if couponDB.UsageLimit > 0 && couponDB.TimesUsed >= couponDB.UsageLimit {
+		return dto.Coupon{}, apperrors.CouponUsageExceeded{Code: code}
+	}
+
+	if couponDB.PerCustomerLimit > 0 {
+		customerRedemptions, err := cs.couponRepo.CountCouponRedemptions(ctx, tx, int64(couponDB.ID), customerID)
+		if err != nil {
+			return dto.Coupon{}, err
+		}
+
+		if customerRedemptions >= couponDB.PerCustomerLimit {
+			return dto.Coupon{}, apperrors.CouponUsageExceeded{Code: code}
+		}
+	}
+
+	return MapCouponRepoToDto(couponDB), nil
+}
+
+func (cs *service) RedeemCoupon(ctx context.Context, tx repository.Transaction, coupon dto.Coupon, customerID, orderID int64, amount money.Money) error {
+	redeemed, err := cs.couponRepo.RedeemCoupon(ctx, tx, repository.CouponRedemption{
+		CouponID:   coupon.ID,
+		CustomerID: customerID,
+		OrderID:    orderID,
+		Amount:     amount.MinorUnits,
+		Currency:   amount.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	//another order took the last use first, return error CouponUsageExceeded
+	if !redeemed {
+		return apperrors.CouponUsageExceeded{Code: coupon.Code}
+	}
+
+	return nil
+}
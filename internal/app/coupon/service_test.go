@@ -0,0 +1,313 @@
+package coupon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CouponServiceTestSuite struct {
+	suite.Suite
+	service    Service
+	couponRepo *mocks.CouponStorer
+}
+
+func TestCouponServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CouponServiceTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CouponServiceTestSuite) SetupTest() {
+	suite.couponRepo = &mocks.CouponStorer{}
+	suite.service = NewService(suite.couponRepo, "INR")
+}
+
+// this function executes after all tests executed
+func (suite *CouponServiceTestSuite) TearDownTest() {
+	suite.couponRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CouponServiceTestSuite) TestCreateCoupon() {
+	tx := &storm.DB{}
+	req := dto.CreateCouponRequest{
+		Code:         "WELCOME10",
+		DiscountType: "percentage",
+		Percent:      10.0,
+		Categories:   []string{"Premium"},
+		UsageLimit:   100,
+	}
+	usd := money.New(500, "USD")
+
+	testCases := []struct {
+		name        string
+		req         dto.CreateCouponRequest
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			req:  req,
+			setup: func() {
+				suite.couponRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(repository.Coupon{}, nil).Once()
+				suite.couponRepo.On("CreateCoupon", mock.Anything, tx, repository.Coupon{
+					Code:         "WELCOME10",
+					DiscountType: "percentage",
+					Percent:      10.0,
+					Currency:     "INR",
+					Categories:   []string{"Premium"},
+					UsageLimit:   100,
+				}).Return(repository.Coupon{ID: 1, Code: "WELCOME10", DiscountType: "percentage", Percent: 10.0,
+					Currency: "INR", Categories: []string{"Premium"}, UsageLimit: 100}, nil).Once()
+				suite.couponRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Code Already Exists",
+			req:  req,
+			setup: func() {
+				suite.couponRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(repository.Coupon{ID: 3, Code: "WELCOME10"}, nil).Once()
+				suite.couponRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil).Once()
+			},
+			expectedErr: apperrors.CouponCodeExists{Code: "WELCOME10"},
+		},
+		{
+			name: "Fail Because Category Invalid",
+			req: dto.CreateCouponRequest{
+				Code:         "LUXURY",
+				DiscountType: "fixed",
+				Amount:       &money.Money{MinorUnits: 10000, Currency: "INR"},
+				Categories:   []string{"Luxury"},
+			},
+			setup:       func() {},
+			expectedErr: apperrors.ProductCategoryInvalid{Category: "Luxury"},
+		},
+		{
+			name: "Fail Because Amount In Another Currency",
+			req: dto.CreateCouponRequest{
+				Code:         "WELCOME5",
+				DiscountType: "fixed",
+				Amount:       &usd,
+			},
+			setup:       func() {},
+			expectedErr: apperrors.CurrencyMismatch{Currency: "USD", Expected: "INR"},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			coupon, err := suite.service.CreateCoupon(context.Background(), test.req)
+			suite.Equal(test.expectedErr, err)
+			if err == nil {
+				suite.Equal(int64(1), coupon.ID)
+				suite.Equal([]string{"Premium"}, coupon.Categories)
+				suite.Nil(coupon.ValidUntil)
+			}
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CouponServiceTestSuite) TestGetCoupon() {
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.couponRepo.On("GetCouponByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Coupon{ID: 1, Code: "WELCOME10"}, nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Coupon Not Found",
+			setup: func() {
+				suite.couponRepo.On("GetCouponByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Coupon{}, nil).Once()
+			},
+			expectedErr: apperrors.CouponNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			coupon, err := suite.service.GetCoupon(context.Background(), 1)
+			suite.Equal(test.expectedErr, err)
+			if err == nil {
+				suite.Equal("WELCOME10", coupon.Code)
+				suite.Equal([]string{}, coupon.Categories)
+			}
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CouponServiceTestSuite) TestGetRedeemableCoupon() {
+	tx := &storm.DB{}
+	now = func() time.Time { return time.Date(2023, 05, 18, 00, 00, 00, 00, time.UTC) }
+	defer func() { now = time.Now }()
+
+	welcome := repository.Coupon{
+		ID:               1,
+		Code:             "WELCOME10",
+		DiscountType:     "percentage",
+		Percent:          10.0,
+		Currency:         "INR",
+		ValidFrom:        time.Date(2023, 05, 01, 00, 00, 00, 00, time.UTC),
+		ValidUntil:       time.Date(2023, 06, 01, 00, 00, 00, 00, time.UTC),
+		UsageLimit:       100,
+		PerCustomerLimit: 1,
+		TimesUsed:        10,
+	}
+
+	testCases := []struct {
+		name        string
+		code        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success With Code Of Any Case",
+			code: " welcome10 ",
+			setup: func() {
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(welcome, nil).Once()
+				suite.couponRepo.On("CountCouponRedemptions", mock.Anything, tx, int64(1), int64(1)).Return(int64(0), nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Code Unknown",
+			code: "UNKNOWN",
+			setup: func() {
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "UNKNOWN").Return(repository.Coupon{}, nil).Once()
+			},
+			expectedErr: apperrors.CouponNotRedeemable{Code: "UNKNOWN", Reason: "unknown coupon code"},
+		},
+		{
+			name: "Fail Because Coupon Not Valid Yet",
+			code: "WELCOME10",
+			setup: func() {
+				notYetValid := welcome
+				notYetValid.ValidFrom = time.Date(2023, 05, 20, 00, 00, 00, 00, time.UTC)
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(notYetValid, nil).Once()
+			},
+			expectedErr: apperrors.CouponNotRedeemable{Code: "WELCOME10", Reason: "coupon is not valid yet"},
+		},
+		{
+			name: "Fail Because Coupon Expired",
+			code: "WELCOME10",
+			setup: func() {
+				expired := welcome
+				expired.ValidUntil = time.Date(2023, 05, 18, 00, 00, 00, 00, time.UTC)
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(expired, nil).Once()
+			},
+			expectedErr: apperrors.CouponNotRedeemable{Code: "WELCOME10", Reason: "coupon has expired"},
+		},
+		{
+			name: "Fail Because Fixed Coupon Priced In Another Currency",
+			code: "WELCOME10",
+			setup: func() {
+				dollars := welcome
+				dollars.DiscountType = "fixed"
+				dollars.Percent = 0
+				dollars.Amount = 500
+				dollars.Currency = "USD"
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(dollars, nil).Once()
+			},
+			expectedErr: apperrors.CouponNotRedeemable{Code: "WELCOME10", Reason: "coupon is priced in USD"},
+		},
+		{
+			name: "Fail Because Coupon Used Up",
+			code: "WELCOME10",
+			setup: func() {
+				usedUp := welcome
+				usedUp.TimesUsed = 100
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(usedUp, nil).Once()
+			},
+			expectedErr: apperrors.CouponUsageExceeded{Code: "WELCOME10"},
+		},
+		{
+			name: "Fail Because Customer Already Redeemed Coupon",
+			code: "WELCOME10",
+			setup: func() {
+				suite.couponRepo.On("GetCouponByCode", mock.Anything, tx, "WELCOME10").Return(welcome, nil).Once()
+				suite.couponRepo.On("CountCouponRedemptions", mock.Anything, tx, int64(1), int64(1)).Return(int64(1), nil).Once()
+			},
+			expectedErr: apperrors.CouponUsageExceeded{Code: "WELCOME10"},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			coupon, err := suite.service.GetRedeemableCoupon(context.Background(), tx, 1, test.code)
+			suite.Equal(test.expectedErr, err)
+			if err == nil {
+				suite.Equal(int64(1), coupon.ID)
+			}
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CouponServiceTestSuite) TestRedeemCoupon() {
+	tx := &storm.DB{}
+	welcome := dto.Coupon{ID: 1, Code: "WELCOME10"}
+	redemption := repository.CouponRedemption{CouponID: 1, CustomerID: 1, OrderID: 5, Amount: 1250, Currency: "INR"}
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.couponRepo.On("RedeemCoupon", mock.Anything, tx, redemption).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Usage Limit Reached By Concurrent Order",
+			setup: func() {
+				suite.couponRepo.On("RedeemCoupon", mock.Anything, tx, redemption).Return(false, nil).Once()
+			},
+			expectedErr: apperrors.CouponUsageExceeded{Code: "WELCOME10"},
+		},
+		{
+			name: "Fail Because Something Wrong With Storing Redemption",
+			setup: func() {
+				suite.couponRepo.On("RedeemCoupon", mock.Anything, tx, redemption).Return(false, errors.New("db error")).Once()
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			err := suite.service.RedeemCoupon(context.Background(), tx, welcome, 1, 5, money.New(1250, "INR"))
+			suite.Equal(test.expectedErr, err)
+		})
+		suite.TearDownTest()
+	}
+}
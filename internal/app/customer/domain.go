@@ -0,0 +1,51 @@
+package customer
+
+import (
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+func MapRepoObjectToDto(repoObj repository.Customer) dto.Customer {
+	addresses := make([]dto.Address, 0)
+	for _, address := range repoObj.Addresses {
+		addresses = append(addresses, dto.Address(address))
+	}
+
+	return dto.Customer{
+		ID:        int64(repoObj.ID),
+		Name:      repoObj.Name,
+		Email:     repoObj.Email,
+		Addresses: addresses,
+		CreatedAt: repoObj.CreatedAt,
+		UpdatedAt: repoObj.UpdatedAt,
+	}
+}
+
+func MapCreateRequestToRepo(req dto.CreateCustomerRequest) repository.Customer {
+	return repository.Customer{
+		Name:      req.Name,
+		Email:     req.Email,
+		Addresses: mapAddressesToRepo(req.Addresses),
+	}
+}
+
+func applyCustomerUpdates(customerDB repository.Customer, req dto.UpdateCustomerRequest) repository.Customer {
+	if req.Name != nil {
+		customerDB.Name = *req.Name
+	}
+
+	if req.Addresses != nil {
+		customerDB.Addresses = mapAddressesToRepo(*req.Addresses)
+	}
+
+	return customerDB
+}
+
+func mapAddressesToRepo(addresses []dto.Address) []repository.Address {
+	repoAddresses := make([]repository.Address, 0)
+	for _, address := range addresses {
+		repoAddresses = append(repoAddresses, repository.Address(address))
+	}
+
+	return repoAddresses
+}
@@ -0,0 +1,105 @@
+package customer
+
+import (
+	"context"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type service struct {
+	customerRepo repository.CustomerStorer
+}
+
+type Service interface {
+	CreateCustomer(ctx context.Context, customerDetails dto.CreateCustomerRequest) (dto.Customer, error)
+	GetCustomerByID(ctx context.Context, tx repository.Transaction, customerID int64) (dto.Customer, error)
+	UpdateCustomer(ctx context.Context, customerID int64, customerDetails dto.UpdateCustomerRequest) (dto.Customer, error)
+}
+
+func NewService(customerRepo repository.CustomerStorer) Service {
+	return &service{
+		customerRepo: customerRepo,
+	}
+}
+
+func (cs *service) CreateCustomer(ctx context.Context, customerDetails dto.CreateCustomerRequest) (customer dto.Customer, err error) {
+	//initializing database transaction
+	tx, err := cs.customerRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	defer func() {
+		txErr := cs.customerRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	existingCustomer, err := cs.customerRepo.GetCustomerByEmail(ctx, tx, customerDetails.Email)
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	//email already registered, return error CustomerEmailExists
+	if existingCustomer.ID != 0 {
+		return dto.Customer{}, apperrors.CustomerEmailExists{Email: customerDetails.Email}
+	}
+
+	customerDB, err := cs.customerRepo.CreateCustomer(ctx, tx, MapCreateRequestToRepo(customerDetails))
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	return MapRepoObjectToDto(customerDB), nil
+}
+
+func (cs *service) GetCustomerByID(ctx context.Context, tx repository.Transaction, customerID int64) (dto.Customer, error) {
+	customerDB, err := cs.customerRepo.GetCustomerByID(ctx, tx, customerID)
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	//customer not found, return error CustomerNotFound
+	if customerDB.ID == 0 {
+		return dto.Customer{}, apperrors.CustomerNotFound{ID: customerID}
+	}
+
+	return MapRepoObjectToDto(customerDB), nil
+}
+
+func (cs *service) UpdateCustomer(ctx context.Context, customerID int64, customerDetails dto.UpdateCustomerRequest) (customer dto.Customer, err error) {
+	//initializing database transaction
+	tx, err := cs.customerRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	defer func() {
+		txErr := cs.customerRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	customerDB, err := cs.customerRepo.GetCustomerByID(ctx, tx, customerID)
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	//customer not found, return error CustomerNotFound
+	if customerDB.ID == 0 {
+		return dto.Customer{}, apperrors.CustomerNotFound{ID: customerID}
+	}
+
+	customerDB, err = cs.customerRepo.UpdateCustomer(ctx, tx, applyCustomerUpdates(customerDB, customerDetails))
+	if err != nil {
+		return dto.Customer{}, err
+	}
+
+	return MapRepoObjectToDto(customerDB), nil
+}
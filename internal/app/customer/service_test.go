@@ -0,0 +1,177 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CustomerServiceTestSuite struct {
+	suite.Suite
+	service      Service
+	customerRepo *mocks.CustomerStorer
+}
+
+func TestCustomerServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CustomerServiceTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *CustomerServiceTestSuite) SetupTest() {
+	suite.customerRepo = &mocks.CustomerStorer{}
+
+	suite.service = NewService(suite.customerRepo)
+}
+
+// this function executes after all tests executed
+func (suite *CustomerServiceTestSuite) TearDownTest() {
+	suite.customerRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CustomerServiceTestSuite) TestCreateCustomer() {
+	address := dto.Address{Line1: "1 Main Street", City: "Pune", PostalCode: "411001", Country: "IN"}
+
+	testCases := []struct {
+		name           string
+		input          dto.CreateCustomerRequest
+		setup          func()
+		expectedOutput dto.Customer
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			input: dto.CreateCustomerRequest{
+				Name:      "Jane",
+				Email:     "jane@example.com",
+				Addresses: []dto.Address{address},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.customerRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.customerRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerRepo.On("GetCustomerByEmail", mock.Anything, tx, "jane@example.com").Return(repository.Customer{}, nil)
+				suite.customerRepo.On("CreateCustomer", mock.Anything, tx, repository.Customer{
+					Name:      "Jane",
+					Email:     "jane@example.com",
+					Addresses: []repository.Address{repository.Address(address)},
+				}).Return(repository.Customer{
+					ID:        1,
+					Name:      "Jane",
+					Email:     "jane@example.com",
+					Addresses: []repository.Address{repository.Address(address)},
+				}, nil)
+			},
+			expectedOutput: dto.Customer{
+				ID:        1,
+				Name:      "Jane",
+				Email:     "jane@example.com",
+				Addresses: []dto.Address{address},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Email Already Registered",
+			input: dto.CreateCustomerRequest{
+				Name:  "Jane",
+				Email: "jane@example.com",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.customerRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.customerRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerRepo.On("GetCustomerByEmail", mock.Anything, tx, "jane@example.com").Return(repository.Customer{ID: 3}, nil)
+			},
+			expectedOutput: dto.Customer{},
+			expectedErr:    apperrors.CustomerEmailExists{Email: "jane@example.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			customer, err := suite.service.CreateCustomer(context.Background(), test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, customer)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *CustomerServiceTestSuite) TestUpdateCustomer() {
+	name := "Jane Doe"
+	noAddresses := []dto.Address{}
+
+	testCases := []struct {
+		name           string
+		input          dto.UpdateCustomerRequest
+		setup          func()
+		expectedOutput dto.Customer
+		expectedErr    error
+	}{
+		{
+			name:  "Success",
+			input: dto.UpdateCustomerRequest{Name: &name, Addresses: &noAddresses},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.customerRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.customerRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerRepo.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(repository.Customer{
+					ID:        1,
+					Name:      "Jane",
+					Email:     "jane@example.com",
+					Addresses: []repository.Address{{Line1: "1 Main Street"}},
+				}, nil)
+				suite.customerRepo.On("UpdateCustomer", mock.Anything, tx, repository.Customer{
+					ID:        1,
+					Name:      "Jane Doe",
+					Email:     "jane@example.com",
+					Addresses: []repository.Address{},
+				}).Return(repository.Customer{
+					ID:    1,
+					Name:  "Jane Doe",
+					Email: "jane@example.com",
+				}, nil)
+			},
+			expectedOutput: dto.Customer{
+				ID:        1,
+				Name:      "Jane Doe",
+				Email:     "jane@example.com",
+				Addresses: []dto.Address{},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Fail Because Customer Not Found",
+			input: dto.UpdateCustomerRequest{Name: &name},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.customerRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.customerRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerRepo.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(repository.Customer{}, nil)
+			},
+			expectedOutput: dto.Customer{},
+			expectedErr:    apperrors.CustomerNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			customer, err := suite.service.UpdateCustomer(context.Background(), 1, test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, customer)
+		})
+		suite.TearDownTest()
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	boltRepository "github.com/sagar23sj/go-ecommerce/internal/repository/boltdb"
+	sqlRepository "github.com/sagar23sj/go-ecommerce/internal/repository/sqldb"
+)
+
+// Repositories holds the storers of the selected storage backend.
+// All storers share one database, so a transaction started by any
+// of them can be passed to the others.
+type Repositories struct {
+	OrderRepo               repository.OrderStorer
+	OrderItemsRepo          repository.OrderItemStorer
+	ProductRepo             repository.ProductStorer
+	CartRepo                repository.CartStorer
+	CustomerRepo            repository.CustomerStorer
+	IdempotencyRepo         repository.IdempotencyStorer
+	OrderEventsRepo         repository.OrderStatusEventStorer
+	OutboxRepo              repository.OutboxStorer
+	Migrator                repository.Migrator
+	WebhookSubscriptionRepo repository.WebhookSubscriptionStorer
+	WebhookDeliveryRepo     repository.WebhookDeliveryStorer
+	ReservationRepo         repository.InventoryReservationStorer
+	StockMovementRepo       repository.StockMovementStorer
+	CouponRepo              repository.CouponStorer
+
+	close func() error
+}
+
+// NewRepositories opens the database for the given driver and builds the matching storers,
+// the database is migrated for a catalog priced in currency
+func NewRepositories(driver, dsn, currency string) (Repositories, error) {
+	switch driver {
+	case constants.DBDriverBolt:
+		db, err := boltRepository.InitializeDatabase(dsn)
+		if err != nil {
+			return Repositories{}, err
+		}
+
+		return Repositories{
+			OrderRepo:               boltRepository.NewOrderRepo(db),
+			OrderItemsRepo:          boltRepository.NewOrderItemRepo(db),
+			ProductRepo:             boltRepository.NewProductRepo(db),
+			CartRepo:                boltRepository.NewCartRepo(db),
+			CustomerRepo:            boltRepository.NewCustomerRepo(db),
+			IdempotencyRepo:         boltRepository.NewIdempotencyRepo(db),
+			OrderEventsRepo:         boltRepository.NewOrderStatusEventRepo(db),
+			OutboxRepo:              boltRepository.NewOutboxRepo(db),
+			WebhookSubscriptionRepo: boltRepository.NewWebhookSubscriptionRepo(db),
+			WebhookDeliveryRepo:     boltRepository.NewWebhookDeliveryRepo(db),
+			ReservationRepo:         boltRepository.NewInventoryReservationRepo(db),
+			StockMovementRepo:       boltRepository.NewStockMovementRepo(db),
+			CouponRepo:              boltRepository.NewCouponRepo(db),
+			Migrator:                boltRepository.NewMigrator(db, currency),
+			close:                   db.Close,
+		}, nil
+
+	case constants.DBDriverSQLite, constants.DBDriverPostgres:
+		db, err := sqlRepository.InitializeDatabase(driver, dsn)
+		if err != nil {
+			return Repositories{}, err
+		}
+
+		return Repositories{
+			OrderRepo:               sqlRepository.NewOrderRepo(db),
+			OrderItemsRepo:          sqlRepository.NewOrderItemRepo(db),
+			ProductRepo:             sqlRepository.NewProductRepo(db),
+			CartRepo:                sqlRepository.NewCartRepo(db),
+			CustomerRepo:            sqlRepository.NewCustomerRepo(db),
+			IdempotencyRepo:         sqlRepository.NewIdempotencyRepo(db),
+			OrderEventsRepo:         sqlRepository.NewOrderStatusEventRepo(db),
+			OutboxRepo:              sqlRepository.NewOutboxRepo(db),
+			WebhookSubscriptionRepo: sqlRepository.NewWebhookSubscriptionRepo(db),
+			WebhookDeliveryRepo:     sqlRepository.NewWebhookDeliveryRepo(db),
+			ReservationRepo:         sqlRepository.NewInventoryReservationRepo(db),
+			StockMovementRepo:       sqlRepository.NewStockMovementRepo(db),
+			CouponRepo:              sqlRepository.NewCouponRepo(db),
+			Migrator:                sqlRepository.NewMigrator(db, driver, currency),
+			close:                   db.Close,
+		}, nil
+	}
+
+	return Repositories{}, fmt.Errorf("unsupported database driver: %s", driver)
+}
+
+// Close releases the underlying database connection
+func (r Repositories) Close() error {
+	if r.close == nil {
+		return nil
+	}
+
+	return r.close()
+}
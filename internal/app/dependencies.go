@@ -1,29 +1,43 @@
 package app
 
 import (
-	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/app/cart"
+	"github.com/sagar23sj/go-ecommerce/internal/app/coupon"
+	"github.com/sagar23sj/go-ecommerce/internal/app/customer"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
 	"github.com/sagar23sj/go-ecommerce/internal/app/order"
 	"github.com/sagar23sj/go-ecommerce/internal/app/product"
-	repository "github.com/sagar23sj/go-ecommerce/internal/repository/boltdb"
+	"github.com/sagar23sj/go-ecommerce/internal/app/webhook"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 )
 
 type Dependencies struct {
-	OrderService   order.Service
-	ProductService product.Service
+	OrderService    order.Service
+	ProductService  product.Service
+	CartService     cart.Service
+	CustomerService customer.Service
+	WebhookService  webhook.Service
+	CouponService   coupon.Service
 }
 
-func NewServices(db *storm.DB) Dependencies {
-	//initialize repo dependencies
-	orderRepo := repository.NewOrderRepo(db)
-	orderItemsRepo := repository.NewOrderItemRepo(db)
-	productRepo := repository.NewProductRepo(db)
-
+func NewServices(repos Repositories, cfg config.Config) Dependencies {
 	//initialize service dependencies
-	productService := product.NewService(productRepo)
-	orderService := order.NewService(orderRepo, orderItemsRepo, productService)
+	eventService := event.NewService(repos.OutboxRepo)
+	productService := product.NewService(repos.ProductRepo, repos.ReservationRepo, repos.StockMovementRepo, eventService,
+		product.NewLowStockNotifiers(cfg.Inventory, eventService), cfg.Inventory, cfg.Order.Currency)
+	customerService := customer.NewService(repos.CustomerRepo)
+	couponService := coupon.NewService(repos.CouponRepo, cfg.Order.Currency)
+	orderService := order.NewService(repos.OrderRepo, repos.OrderItemsRepo, repos.IdempotencyRepo, repos.OrderEventsRepo,
+		productService, customerService, couponService, eventService, cfg.Order)
+	cartService := cart.NewService(repos.CartRepo, productService, orderService, cfg.Order)
+	webhookService := webhook.NewService(repos.WebhookSubscriptionRepo, repos.WebhookDeliveryRepo)
 
 	return Dependencies{
-		OrderService:   orderService,
-		ProductService: productService,
+		OrderService:    orderService,
+		ProductService:  productService,
+		CartService:     cartService,
+		CustomerService: customerService,
+		WebhookService:  webhookService,
+		CouponService:   couponService,
 	}
 }
@@ -0,0 +1,44 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+// domain event types, the payload of each is the dto struct of the same name
+const (
+	OrderPlaced        = "OrderPlaced"
+	OrderStatusChanged = "OrderStatusChanged"
+	StockAdjusted      = "StockAdjusted"
+	//LowStock carries a dto.LowStockProduct
+	LowStock = "LowStock"
+)
+
+// maxRetryBackoff caps the doubling wait between deliveries of a failing event
+const maxRetryBackoff = time.Hour
+
+func MapOutboxEventToDto(event repository.OutboxEvent) dto.Event {
+	return dto.Event{
+		ID:          int64(event.ID),
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.CreatedAt,
+		Payload:     event.Payload,
+	}
+}
+
+// RetryBackoff returns the wait before the next delivery of an event which failed attempts times
+func RetryBackoff(initial time.Duration, attempts int) time.Duration {
+	backoff := initial
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Relay delivers the pending outbox events to every sink, retrying failed deliveries with a doubling backoff.
+// Delivery is at least once: an event failing on one sink is sent to all the sinks again on the next attempt.
+type Relay struct {
+	outboxRepo repository.OutboxStorer
+	sinks      []Sink
+	cfg        config.OutboxConfig
+}
+
+func NewRelay(outboxRepo repository.OutboxStorer, sinks []Sink, cfg config.OutboxConfig) *Relay {
+	return &Relay{
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		cfg:        cfg,
+	}
+}
+
+// Run delivers the due events every poll interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		//keep going without waiting while full batches are due
+		for ctx.Err() == nil {
+			processed, err := r.DeliverPending(ctx)
+			if err != nil {
+				logger.Errorw(ctx, "error occured while relaying outbox events", zap.Error(err))
+				break
+			}
+
+			if processed < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending makes one delivery attempt for a batch of due events and returns the number of events attempted
+func (r *Relay) DeliverPending(ctx context.Context) (int, error) {
+	events, err := r.outboxRepo.ListPendingOutboxEvents(ctx, nil, now(), r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		//stop between events on shutdown, the rest stay pending for the next run
+		if ctx.Err() != nil {
+			return i, nil
+		}
+
+		event = r.recordAttempt(ctx, event, r.deliver(ctx, event))
+
+		err = r.outboxRepo.UpdateOutboxEvent(ctx, nil, event)
+		if err != nil {
+			return i, fmt.Errorf("error occured while updating outbox event %d: %w", event.ID, err)
+		}
+	}
+
+	return len(events), nil
+}
+
+func (r *Relay) deliver(ctx context.Context, event repository.OutboxEvent) error {
+	for _, sink := range r.sinks {
+		err := sink.Send(ctx, MapOutboxEventToDto(event))
+		if err != nil {
+			return fmt.Errorf("%s sink: %w", sink.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// recordAttempt updates the delivery state of the event after an attempt which failed with deliveryErr, if not nil
+func (r *Relay) recordAttempt(ctx context.Context, event repository.OutboxEvent, deliveryErr error) repository.OutboxEvent {
+	event.Attempts++
+
+	if deliveryErr == nil {
+		event.Status = repository.OutboxStatusDelivered
+		event.DeliveredAt = now()
+		event.LastError = ""
+		return event
+	}
+
+	event.LastError = deliveryErr.Error()
+
+	//out of attempts, the event stays in the outbox marked failed for inspection
+	if event.Attempts >= r.cfg.MaxAttempts {
+		event.Status = repository.OutboxStatusFailed
+		logger.Errorw(ctx, "giving up delivering outbox event",
+			zap.Uint("id", event.ID),
+			zap.String("type", event.Type),
+			zap.Int("attempts", event.Attempts),
+			zap.Error(deliveryErr),
+		)
+		return event
+	}
+
+	event.NextAttemptAt = now().Add(RetryBackoff(r.cfg.RetryBackoff, event.Attempts))
+	logger.Warnw(ctx, "error occured while delivering outbox event, retrying later",
+		zap.Uint("id", event.ID),
+		zap.String("type", event.Type),
+		zap.Int("attempts", event.Attempts),
+		zap.Time("next_attempt_at", event.NextAttemptAt),
+		zap.Error(deliveryErr),
+	)
+	return event
+}
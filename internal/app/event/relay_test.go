@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeSink struct {
+	err  error
+	sent []dto.Event
+}
+
+func (fs *fakeSink) Name() string {
+	return "fake"
+}
+
+func (fs *fakeSink) Send(ctx context.Context, event dto.Event) error {
+	fs.sent = append(fs.sent, event)
+	return fs.err
+}
+
+type RelayTestSuite struct {
+	suite.Suite
+	outboxRepo *mocks.OutboxStorer
+	sink       *fakeSink
+	relay      *Relay
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *RelayTestSuite) SetupTest() {
+	suite.outboxRepo = &mocks.OutboxStorer{}
+	suite.sink = &fakeSink{}
+
+	cfg := config.Default().Outbox
+	cfg.MaxAttempts = 3
+	suite.relay = NewRelay(suite.outboxRepo, []Sink{suite.sink}, cfg)
+}
+
+// this function executes after all tests executed
+func (suite *RelayTestSuite) TearDownTest() {
+	suite.outboxRepo.AssertExpectations(suite.T())
+}
+
+func (suite *RelayTestSuite) TestDeliverPending() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	pending := repository.OutboxEvent{
+		ID:          1,
+		Type:        OrderPlaced,
+		AggregateID: 7,
+		Payload:     []byte(`{"order_id":7}`),
+		Status:      repository.OutboxStatusPending,
+		CreatedAt:   timeNow.Add(-time.Minute),
+	}
+
+	testCases := []struct {
+		name              string
+		event             repository.OutboxEvent
+		sinkErr           error
+		expectedDelivered repository.OutboxEvent
+	}{
+		{
+			name:  "Success",
+			event: pending,
+			expectedDelivered: func() repository.OutboxEvent {
+				event := pending
+				event.Status = repository.OutboxStatusDelivered
+				event.Attempts = 1
+				event.DeliveredAt = timeNow
+				return event
+			}(),
+		},
+		{
+			name:    "Retry Later Because Sink Failed",
+			event:   pending,
+			sinkErr: errors.New("connection refused"),
+			expectedDelivered: func() repository.OutboxEvent {
+				event := pending
+				event.Attempts = 1
+				event.LastError = "fake sink: connection refused"
+				event.NextAttemptAt = timeNow.Add(time.Second)
+				return event
+			}(),
+		},
+		{
+			name: "Give Up Because Attempts Exhausted",
+			event: func() repository.OutboxEvent {
+				event := pending
+				event.Attempts = 2
+				return event
+			}(),
+			sinkErr: errors.New("connection refused"),
+			expectedDelivered: func() repository.OutboxEvent {
+				event := pending
+				event.Status = repository.OutboxStatusFailed
+				event.Attempts = 3
+				event.LastError = "fake sink: connection refused"
+				return event
+			}(),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			suite.sink.err = test.sinkErr
+			suite.outboxRepo.On("ListPendingOutboxEvents", mock.Anything, nil, timeNow, 100).Return([]repository.OutboxEvent{test.event}, nil).Once()
+			suite.outboxRepo.On("UpdateOutboxEvent", mock.Anything, nil, test.expectedDelivered).Return(nil).Once()
+
+			processed, err := suite.relay.DeliverPending(context.Background())
+			suite.NoError(err)
+			suite.Equal(1, processed)
+			suite.Equal([]dto.Event{{
+				ID:          1,
+				Type:        OrderPlaced,
+				AggregateID: 7,
+				OccurredAt:  pending.CreatedAt,
+				Payload:     []byte(`{"order_id":7}`),
+			}}, suite.sink.sent)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, RetryBackoff(time.Second, 1))
+	assert.Equal(t, 8*time.Second, RetryBackoff(time.Second, 4))
+	assert.Equal(t, maxRetryBackoff, RetryBackoff(time.Second, 40))
+}
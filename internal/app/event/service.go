@@ -0,0 +1,45 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+var now = time.Now
+
+type service struct {
+	outboxRepo repository.OutboxStorer
+}
+
+type Service interface {
+	//Publish stores the event in the outbox within tx, the relay delivers it once tx is committed
+	Publish(ctx context.Context, tx repository.Transaction, eventType string, aggregateID int64, payload interface{}) error
+}
+
+func NewService(outboxRepo repository.OutboxStorer) Service {
+	return &service{
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (es *service) Publish(ctx context.Context, tx repository.Transaction, eventType string, aggregateID int64, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error occured while encoding %s event: %w", eventType, err)
+	}
+
+	_, err = es.outboxRepo.CreateOutboxEvent(ctx, tx, repository.OutboxEvent{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     payloadJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("error occured while storing %s event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventServiceTestSuite struct {
+	suite.Suite
+	service    Service
+	outboxRepo *mocks.OutboxStorer
+}
+
+func TestEventServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EventServiceTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *EventServiceTestSuite) SetupTest() {
+	suite.outboxRepo = &mocks.OutboxStorer{}
+	suite.service = NewService(suite.outboxRepo)
+}
+
+// this function executes after all tests executed
+func (suite *EventServiceTestSuite) TearDownTest() {
+	suite.outboxRepo.AssertExpectations(suite.T())
+}
+
+func (suite *EventServiceTestSuite) TestPublish() {
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name        string
+		payload     interface{}
+		setup       func()
+		expectedErr bool
+	}{
+		{
+			name:    "Success",
+			payload: dto.OrderStatusChangedEvent{OrderID: 1, FromStatus: "Placed", ToStatus: "Cancelled"},
+			setup: func() {
+				suite.outboxRepo.On("CreateOutboxEvent", mock.Anything, tx, repository.OutboxEvent{
+					Type:        OrderStatusChanged,
+					AggregateID: 1,
+					Payload:     []byte(`{"order_id":1,"customer_id":0,"from_status":"Placed","to_status":"Cancelled","actor":"","actor_role":""}`),
+				}).Return(repository.OutboxEvent{ID: 1}, nil).Once()
+			},
+		},
+		{
+			name:        "Fail Because Payload Cannot Be Encoded",
+			payload:     func() {},
+			setup:       func() {},
+			expectedErr: true,
+		},
+		{
+			name:    "Fail Because Something Wrong With Storing Event",
+			payload: dto.OrderStatusChangedEvent{OrderID: 1},
+			setup: func() {
+				suite.outboxRepo.On("CreateOutboxEvent", mock.Anything, tx, mock.Anything).Return(repository.OutboxEvent{}, errors.New("bucket not found")).Once()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			err := suite.service.Publish(context.Background(), tx, OrderStatusChanged, 1, test.payload)
+			suite.Equal(test.expectedErr, err != nil)
+		})
+		suite.TearDownTest()
+	}
+}
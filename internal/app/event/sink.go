@@ -0,0 +1,143 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Sink is a destination of the domain events delivered by the relay
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event dto.Event) error
+}
+
+// NewSinks builds the sinks named in the outbox settings
+func NewSinks(cfg config.OutboxConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0)
+	for _, name := range cfg.SinkNames() {
+		switch name {
+		case constants.EventSinkLog:
+			sinks = append(sinks, NewLogSink())
+		case constants.EventSinkFile:
+			sinks = append(sinks, NewFileSink(cfg.FilePath))
+		case constants.EventSinkWebhook:
+			sinks = append(sinks, NewWebhookSink(cfg.WebhookURL, cfg.WebhookTimeout))
+		default:
+			return nil, fmt.Errorf("unsupported outbox sink: %s", name)
+		}
+	}
+
+	return sinks, nil
+}
+
+type logSink struct{}
+
+// NewLogSink returns a sink writing every event to the application log
+func NewLogSink() Sink {
+	return logSink{}
+}
+
+func (logSink) Name() string {
+	return constants.EventSinkLog
+}
+
+func (logSink) Send(ctx context.Context, event dto.Event) error {
+	logger.Infow(ctx, "domain event",
+		zap.Int64("id", event.ID),
+		zap.String("type", event.Type),
+		zap.Int64("aggregate_id", event.AggregateID),
+		zap.ByteString("payload", event.Payload),
+	)
+	return nil
+}
+
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink returns a sink appending every event to the file at path as a line of JSON
+func NewFileSink(path string) Sink {
+	return &fileSink{path: path}
+}
+
+func (fs *fileSink) Name() string {
+	return constants.EventSinkFile
+}
+
+func (fs *fileSink) Send(ctx context.Context, event dto.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	file, err := os.OpenFile(fs.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting every event as JSON to url, any response other than 2xx fails the delivery
+func NewWebhookSink(url string, timeout time.Duration) Sink {
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (ws *webhookSink) Name() string {
+	return constants.EventSinkWebhook
+}
+
+func (ws *webhookSink) Send(ctx context.Context, event dto.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ws.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
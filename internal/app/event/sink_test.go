@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = dto.Event{
+	ID:          1,
+	Type:        OrderPlaced,
+	AggregateID: 7,
+	Payload:     json.RawMessage(`{"order_id":7}`),
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+
+	require.NoError(t, sink.Send(context.Background(), testEvent))
+	require.NoError(t, sink.Send(context.Background(), testEvent))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var event dto.Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, testEvent.Type, event.Type)
+	assert.JSONEq(t, `{"order_id":7}`, string(event.Payload))
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received dto.Event
+	statusCode := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+
+	require.NoError(t, sink.Send(context.Background(), testEvent))
+	assert.Equal(t, testEvent.ID, received.ID)
+
+	statusCode = http.StatusInternalServerError
+	assert.Error(t, sink.Send(context.Background(), testEvent))
+}
+
+func TestNewSinks(t *testing.T) {
+	cfg := config.Default().Outbox
+	cfg.Sinks = "log, file,webhook"
+	cfg.FilePath = filepath.Join(t.TempDir(), "events.jsonl")
+	cfg.WebhookURL = "http://localhost:9000/events"
+
+	sinks, err := NewSinks(cfg)
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, sink := range sinks {
+		names = append(names, sink.Name())
+	}
+	assert.Equal(t, []string{"log", "file", "webhook"}, names)
+
+	cfg.Sinks = "kafka"
+	_, err = NewSinks(cfg)
+	assert.Error(t, err)
+}
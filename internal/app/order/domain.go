@@ -1,81 +1,137 @@
 package order
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 
+	"github.com/sagar23sj/go-ecommerce/internal/app/pricing"
+	"github.com/sagar23sj/go-ecommerce/internal/app/shipping"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
-const (
-	DefaultDiscountPercentage  = 10
-	MaxProductQuantity         = 10
-	PremiumProductsForDiscount = 3
-)
+// AllCustomers is passed as the customer id of admin lookups which are not scoped to a single customer
+const AllCustomers int64 = 0
 
-type OrderStatus int
+// orderSortColumns lists the sort_by values accepted by order listings
+var orderSortColumns = map[string]string{
+	"id":           repository.OrderSortByID,
+	"created_at":   repository.OrderSortByCreatedAt,
+	"final_amount": repository.OrderSortByFinalAmount,
+}
 
-const (
-	OrderCancelled OrderStatus = iota
-	OrderPlaced
-	OrderDispatched
-	OrderCompleted
-	OrderReturned
-)
+// mapListOrdersRequestToFilter turns the list request of a customer into a repository filter for the requested page,
+// statuses must belong to the lifecycle of the state machine
+func mapListOrdersRequestToFilter(sm *StateMachine, customerID int64, req dto.ListOrdersRequest) (repository.OrderFilter, error) {
+	filter := repository.OrderFilter{
+		CustomerID:     customerID,
+		Statuses:       req.Statuses,
+		Currency:       req.Currency,
+		MinFinalAmount: req.MinAmount,
+		MaxFinalAmount: req.MaxAmount,
+		SortDesc:       req.SortDesc,
+		Limit:          req.PageSize,
+		Offset:         (req.Page - 1) * req.PageSize,
+	}
+
+	for _, status := range req.Statuses {
+		if !sm.IsStatus(status) {
+			return repository.OrderFilter{}, apperrors.OrderListQueryInvalid{Param: "status", Value: status}
+		}
+	}
+
+	if req.SortBy != "" {
+		sortColumn, ok := orderSortColumns[req.SortBy]
+		if !ok {
+			return repository.OrderFilter{}, apperrors.OrderListQueryInvalid{Param: "sort_by", Value: req.SortBy}
+		}
+		filter.SortBy = sortColumn
+	}
 
-var MapOrderStatus = map[string]OrderStatus{
-	"Cancelled":  OrderCancelled,
-	"Placed":     OrderPlaced,
-	"Dispatched": OrderDispatched,
-	"Completed":  OrderCompleted,
-	"Returned":   OrderReturned,
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = *req.CreatedFrom
+	}
+
+	if req.CreatedTo != nil {
+		filter.CreatedTo = *req.CreatedTo
+	}
+
+	return filter, nil
 }
 
-// Note -- the order of this slice needs to match
-// the order of the iota enum values defined above
-var ListOrderStatus = []string{
-	"Cancelled",
-	"Placed",
-	"Dispatched",
-	"Completed",
-	"Returned",
+// orderFingerprint is the canonical form of the create order request fields an idempotency key is bound to
+type orderFingerprint struct {
+	Products        []dto.ProductInfo `json:"products"`
+	CouponCode      string            `json:"coupon_code"`
+	Currency        string            `json:"currency"`
+	ShippingMethod  string            `json:"shipping_method"`
+	ShippingAddress *dto.Address      `json:"shipping_address"`
 }
 
-func validateUpdateOrderStatusRequest(RequestOrderStatus, DBOrderStatus string) (isUpdateValid bool) {
-	requestedOrderState := MapOrderStatus[RequestOrderStatus]
-	currentOrderState := MapOrderStatus[DBOrderStatus]
+// fingerprintCreateOrderRequest hashes the ordered products, coupon code, currency, region and shipping,
+// requests sharing an idempotency key must have the same fingerprint
+func fingerprintCreateOrderRequest(orderDetails dto.CreateOrderRequest) string {
+	request, _ := json.Marshal(orderFingerprint{
+		Products:        orderDetails.Products,
+		CouponCode:      dto.NormalizeCouponCode(orderDetails.CouponCode),
+		Currency:        orderDetails.Currency,
+		ShippingMethod:  orderDetails.ShippingMethod,
+		ShippingAddress: orderDetails.ShippingAddress,
+	})
 
-	//donot update if order is already cancelled
-	if currentOrderState == OrderCancelled {
-		return false
+	hash := sha256.Sum256(request)
+	return hex.EncodeToString(hash[:])
+}
+
+// shippingAddress returns the address the order is delivered to, the first address of the customer
+// unless the request names one
+func shippingAddress(orderDetails dto.CreateOrderRequest, customerInfo dto.Customer) (dto.Address, bool) {
+	if orderDetails.ShippingAddress != nil {
+		return *orderDetails.ShippingAddress, true
 	}
 
-	//allow cancel only before order is completed
-	if requestedOrderState == OrderCancelled && currentOrderState < OrderCompleted {
-		return true
+	if len(customerInfo.Addresses) == 0 {
+		return dto.Address{}, false
 	}
 
-	//donot update if requested state is same or lower to current state
-	if currentOrderState >= requestedOrderState {
-		return false
+	return customerInfo.Addresses[0], true
+}
+
+// mapProductToParcel returns the parcel quantity units of the product are shipped in
+func mapProductToParcel(productInfo dto.Product, quantity int64) shipping.Parcel {
+	parcel := shipping.Parcel{
+		WeightGrams: productInfo.WeightGrams,
+		Quantity:    quantity,
 	}
 
-	//order status update can only go one step forward
-	if requestedOrderState != (currentOrderState + 1) {
-		return false
+	if productInfo.Dimensions != nil {
+		parcel.LengthMM = productInfo.Dimensions.LengthMM
+		parcel.WidthMM = productInfo.Dimensions.WidthMM
+		parcel.HeightMM = productInfo.Dimensions.HeightMM
 	}
 
-	return true
+	return parcel
 }
 
 func MapOrderRepoToOrderDto(order repository.Order, orderItems ...repository.OrderItem) dto.Order {
 
 	productInfo := make([]dto.ProductInfo, 0)
+	var items []dto.OrderItem
 	for _, orderItem := range orderItems {
 		productInfo = append(productInfo, dto.ProductInfo{
 			ProductID: orderItem.ProductID,
 			Quantity:  orderItem.Quantity,
 		})
+
+		//items stored before they were snapshotted have no price to break the order down with
+		if orderItem.ProductName != "" {
+			items = append(items, mapOrderItemToDto(orderItem, order.Currency, order.TaxInclusive))
+		}
 	}
 
 	var dispatchedAt *time.Time = &order.DispatchedAt
@@ -83,15 +139,155 @@ func MapOrderRepoToOrderDto(order repository.Order, orderItems ...repository.Ord
 		dispatchedAt = nil
 	}
 
+	//orders placed before they were shipped have no address
+	var shippingAddress *dto.Address
+	if order.ShippingAddress.Line1 != "" {
+		address := dto.Address(order.ShippingAddress)
+		shippingAddress = &address
+	}
+
 	return dto.Order{
 		ID:                 int64(order.ID),
+		CustomerID:         order.CustomerID,
 		Products:           productInfo,
-		Amount:             order.Amount,
+		Items:              items,
+		Amount:             money.New(order.Amount, order.Currency),
 		DiscountPercentage: order.DiscountPercentage,
-		FinalAmount:        order.FinalAmount,
+		Discounts:          mapOrderDiscountsToDto(order.Discounts, order.Currency),
+		TaxAmount:          money.New(order.TaxAmount, order.Currency),
+		TaxRegion:          order.TaxRegion,
+		TaxInclusive:       order.TaxInclusive,
+		ShippingMethod:     order.ShippingMethod,
+		ShippingAmount:     money.New(order.ShippingAmount, order.Currency),
+		ShippingAddress:    shippingAddress,
+		FinalAmount:        money.New(order.FinalAmount, order.Currency),
+		ExchangeRate:       money.Rate{From: order.BaseCurrency, To: order.Currency, Value: order.ExchangeRate},
 		Status:             order.Status,
 		DispatchedAt:       dispatchedAt,
 		CreatedAt:          order.CreatedAt,
 		UpdatedAt:          order.UpdatedAt,
 	}
 }
+
+// mapOrderItemToDto returns the line of an order priced in currency, the final amount of the line holds its tax
+// which is only added to it when the tax is not inclusive
+func mapOrderItemToDto(orderItem repository.OrderItem, currency string, taxInclusive bool) dto.OrderItem {
+	price := money.New(orderItem.UnitPrice, currency)
+	discount := money.New(orderItem.Discount, currency)
+	tax := money.New(orderItem.Tax, currency)
+
+	finalAmount := price.Mul(orderItem.Quantity).Sub(discount)
+	if !taxInclusive {
+		finalAmount = finalAmount.Add(tax)
+	}
+
+	return dto.OrderItem{
+		ProductID:   orderItem.ProductID,
+		Name:        orderItem.ProductName,
+		Category:    orderItem.Category,
+		Price:       price,
+		Quantity:    orderItem.Quantity,
+		LineTotal:   price.Mul(orderItem.Quantity),
+		Discount:    discount,
+		TaxRate:     orderItem.TaxRate,
+		Tax:         tax,
+		FinalAmount: finalAmount,
+	}
+}
+
+// mapAppliedDiscountsToRepo stores the discount breakdown priced by the promotion engine on the order
+func mapAppliedDiscountsToRepo(discounts []dto.AppliedDiscount) []repository.OrderDiscount {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	orderDiscounts := make([]repository.OrderDiscount, 0, len(discounts))
+	for _, discount := range discounts {
+		orderDiscounts = append(orderDiscounts, repository.OrderDiscount{
+			Promotion: discount.Promotion,
+			Type:      discount.Type,
+			Amount:    discount.Amount.MinorUnits,
+		})
+	}
+
+	return orderDiscounts
+}
+
+// mapOrderDiscountsToDto returns the discounts of an order priced in currency
+func mapOrderDiscountsToDto(orderDiscounts []repository.OrderDiscount, currency string) []dto.AppliedDiscount {
+	if len(orderDiscounts) == 0 {
+		return nil
+	}
+
+	discounts := make([]dto.AppliedDiscount, 0, len(orderDiscounts))
+	for _, discount := range orderDiscounts {
+		discounts = append(discounts, dto.AppliedDiscount{
+			Promotion: discount.Promotion,
+			Type:      discount.Type,
+			Amount:    money.New(discount.Amount, currency),
+		})
+	}
+
+	return discounts
+}
+
+func mapCouponToPricing(orderCoupon dto.Coupon) pricing.Coupon {
+	coupon := pricing.Coupon{
+		Code:         orderCoupon.Code,
+		DiscountType: orderCoupon.DiscountType,
+		Percent:      orderCoupon.Percent,
+		Categories:   orderCoupon.Categories,
+	}
+
+	if orderCoupon.Amount != nil {
+		coupon.Amount = *orderCoupon.Amount
+	}
+
+	return coupon
+}
+
+// couponDiscount returns the amount the coupon took off the order
+func couponDiscount(order repository.Order) money.Money {
+	for _, discount := range order.Discounts {
+		if discount.Type == constants.DiscountCoupon {
+			return money.New(discount.Amount, order.Currency)
+		}
+	}
+
+	return money.New(0, order.Currency)
+}
+
+// stockMovementReason names the stock ledger reason of a restock caused by an order moving to status
+func stockMovementReason(status OrderStatus) string {
+	if status == OrderReturned {
+		return repository.StockReasonReturn
+	}
+
+	return repository.StockReasonCancel
+}
+
+func isOwnedBy(order repository.Order, customerID int64) bool {
+	return customerID == AllCustomers || order.CustomerID == customerID
+}
+
+func MapOrderHistoryToDto(order repository.Order, events []repository.OrderStatusEvent) dto.OrderHistory {
+	history := dto.OrderHistory{
+		OrderID:   int64(order.ID),
+		Status:    order.Status,
+		CreatedAt: order.CreatedAt,
+		Events:    make([]dto.OrderStatusEvent, 0),
+	}
+
+	for _, event := range events {
+		history.Events = append(history.Events, dto.OrderStatusEvent{
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			Actor:      event.Actor,
+			ActorRole:  event.ActorRole,
+			Reason:     event.Reason,
+			CreatedAt:  event.CreatedAt,
+		})
+	}
+
+	return history
+}
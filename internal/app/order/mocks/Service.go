@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	auth "github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+
 	dto "github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,23 +41,71 @@ func (_m *Service) CreateOrder(ctx context.Context, orderDetails dto.CreateOrder
 	return r0, r1
 }
 
-// GetOrderDetailsByID provides a mock function with given fields: ctx, orderID
-func (_m *Service) GetOrderDetailsByID(ctx context.Context, orderID int64) (dto.Order, error) {
-	ret := _m.Called(ctx, orderID)
+// GetOrderDetailsByID provides a mock function with given fields: ctx, customerID, orderID
+func (_m *Service) GetOrderDetailsByID(ctx context.Context, customerID int64, orderID int64) (dto.Order, error) {
+	ret := _m.Called(ctx, customerID, orderID)
 
 	var r0 dto.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (dto.Order, error)); ok {
-		return rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (dto.Order, error)); ok {
+		return rf(ctx, customerID, orderID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) dto.Order); ok {
-		r0 = rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) dto.Order); ok {
+		r0 = rf(ctx, customerID, orderID)
 	} else {
 		r0 = ret.Get(0).(dto.Order)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, orderID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, customerID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderHistory provides a mock function with given fields: ctx, customerID, orderID
+func (_m *Service) GetOrderHistory(ctx context.Context, customerID int64, orderID int64) (dto.OrderHistory, error) {
+	ret := _m.Called(ctx, customerID, orderID)
+
+	var r0 dto.OrderHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (dto.OrderHistory, error)); ok {
+		return rf(ctx, customerID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) dto.OrderHistory); ok {
+		r0 = rf(ctx, customerID, orderID)
+	} else {
+		r0 = ret.Get(0).(dto.OrderHistory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, customerID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrders provides a mock function with given fields: ctx, customerID, req
+func (_m *Service) ListOrders(ctx context.Context, customerID int64, req dto.ListOrdersRequest) (dto.OrderList, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	var r0 dto.OrderList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.ListOrdersRequest) (dto.OrderList, error)); ok {
+		return rf(ctx, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.ListOrdersRequest) dto.OrderList); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		r0 = ret.Get(0).(dto.OrderList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, dto.ListOrdersRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,25 +113,25 @@ func (_m *Service) GetOrderDetailsByID(ctx context.Context, orderID int64) (dto.
 	return r0, r1
 }
 
-// ListOrders provides a mock function with given fields: ctx
-func (_m *Service) ListOrders(ctx context.Context) ([]dto.Order, error) {
-	ret := _m.Called(ctx)
+// ListShippingMethods provides a mock function with given fields: ctx, currency
+func (_m *Service) ListShippingMethods(ctx context.Context, currency string) ([]dto.ShippingMethod, error) {
+	ret := _m.Called(ctx, currency)
 
-	var r0 []dto.Order
+	var r0 []dto.ShippingMethod
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.Order, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]dto.ShippingMethod, error)); ok {
+		return rf(ctx, currency)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dto.Order); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dto.ShippingMethod); ok {
+		r0 = rf(ctx, currency)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dto.Order)
+			r0 = ret.Get(0).([]dto.ShippingMethod)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, currency)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -88,23 +139,23 @@ func (_m *Service) ListOrders(ctx context.Context) ([]dto.Order, error) {
 	return r0, r1
 }
 
-// UpdateOrderStatus provides a mock function with given fields: ctx, orderID, status
-func (_m *Service) UpdateOrderStatus(ctx context.Context, orderID int64, status string) (dto.Order, error) {
-	ret := _m.Called(ctx, orderID, status)
+// UpdateOrderStatus provides a mock function with given fields: ctx, actor, orderID, status, reason
+func (_m *Service) UpdateOrderStatus(ctx context.Context, actor auth.Principal, orderID int64, status string, reason string) (dto.Order, error) {
+	ret := _m.Called(ctx, actor, orderID, status, reason)
 
 	var r0 dto.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (dto.Order, error)); ok {
-		return rf(ctx, orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Principal, int64, string, string) (dto.Order, error)); ok {
+		return rf(ctx, actor, orderID, status, reason)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, string) dto.Order); ok {
-		r0 = rf(ctx, orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Principal, int64, string, string) dto.Order); ok {
+		r0 = rf(ctx, actor, orderID, status, reason)
 	} else {
 		r0 = ret.Get(0).(dto.Order)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
-		r1 = rf(ctx, orderID, status)
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Principal, int64, string, string) error); ok {
+		r1 = rf(ctx, actor, orderID, status, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
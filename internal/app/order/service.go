@@ -2,36 +2,75 @@ package order
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/sagar23sj/go-ecommerce/internal/app/coupon"
+	"github.com/sagar23sj/go-ecommerce/internal/app/customer"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/app/pricing"
 	"github.com/sagar23sj/go-ecommerce/internal/app/product"
+	"github.com/sagar23sj/go-ecommerce/internal/app/shipping"
+	"github.com/sagar23sj/go-ecommerce/internal/app/tax"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
 var now = time.Now
 
 type service struct {
-	orderRepo      repository.OrderStorer
-	orderItemsRepo repository.OrderItemStorer
-	productSvc     product.Service
+	orderRepo       repository.OrderStorer
+	orderItemsRepo  repository.OrderItemStorer
+	idempotencyRepo repository.IdempotencyStorer
+	orderEventsRepo repository.OrderStatusEventStorer
+	productSvc      product.Service
+	customerSvc     customer.Service
+	couponSvc       coupon.Service
+	eventSvc        event.Service
+	cfg             config.OrderConfig
+	stateMachine    *StateMachine
+	pricing         *pricing.Engine
+	tax             *tax.Calculator
+	shipping        *shipping.Calculator
 }
 
 type Service interface {
 	CreateOrder(ctx context.Context, orderDetails dto.CreateOrderRequest) (dto.Order, error)
-	GetOrderDetailsByID(ctx context.Context, orderID int64) (dto.Order, error)
-	ListOrders(ctx context.Context) ([]dto.Order, error)
-	UpdateOrderStatus(ctx context.Context, orderID int64, status string) (dto.Order, error)
+	//customerID scopes the lookup to orders of that customer, AllCustomers disables the scope
+	GetOrderDetailsByID(ctx context.Context, customerID, orderID int64) (dto.Order, error)
+	//ListOrders returns the requested page of the orders of the customer matching the request filters
+	ListOrders(ctx context.Context, customerID int64, req dto.ListOrdersRequest) (dto.OrderList, error)
+	//UpdateOrderStatus moves the order to status on behalf of actor, whose role decides the allowed statuses.
+	//The transition is recorded in the order history along with the reason.
+	UpdateOrderStatus(ctx context.Context, actor auth.Principal, orderID int64, status, reason string) (dto.Order, error)
+	//GetOrderHistory returns the status transitions of the order, scoped like GetOrderDetailsByID
+	GetOrderHistory(ctx context.Context, customerID, orderID int64) (dto.OrderHistory, error)
+	//ListShippingMethods returns the shipping catalog priced in currency, the catalog currency when empty
+	ListShippingMethods(ctx context.Context, currency string) ([]dto.ShippingMethod, error)
 }
 
-func NewService(orderRepo repository.OrderStorer, orderItemsRepo repository.OrderItemStorer,
-	productSvc product.Service) Service {
+func NewService(orderRepo repository.OrderStorer, orderItemsRepo repository.OrderItemStorer, idempotencyRepo repository.IdempotencyStorer,
+	orderEventsRepo repository.OrderStatusEventStorer, productSvc product.Service, customerSvc customer.Service, couponSvc coupon.Service,
+	eventSvc event.Service, cfg config.OrderConfig) Service {
 	return &service{
-		orderRepo:      orderRepo,
-		orderItemsRepo: orderItemsRepo,
-		productSvc:     productSvc,
+		orderRepo:       orderRepo,
+		orderItemsRepo:  orderItemsRepo,
+		idempotencyRepo: idempotencyRepo,
+		orderEventsRepo: orderEventsRepo,
+		productSvc:      productSvc,
+		customerSvc:     customerSvc,
+		couponSvc:       couponSvc,
+		eventSvc:        eventSvc,
+		cfg:             cfg,
+		stateMachine:    MustNewStateMachine(DefaultTransitions),
+		pricing:         pricing.MustNewEngine(cfg.Currency, cfg.ActivePromotions()),
+		tax:             tax.NewCalculator(cfg.Tax),
+		shipping:        shipping.NewCalculator(cfg.Shipping),
 	}
 }
 
@@ -50,13 +89,65 @@ func (os *service) CreateOrder(ctx context.Context, orderDetails dto.CreateOrder
 		}
 	}()
 
-	orderRepoObj, updatedProductInfo, err := os.calculateOrderValueFromProducts(ctx, tx, orderDetails.Products)
+	//customer not registered, return error CustomerNotFound
+	customerInfo, err := os.customerSvc.GetCustomerByID(ctx, tx, orderDetails.CustomerID)
 	if err != nil {
 		return dto.Order{}, err
 	}
 
-	//Set Order Status to Placed
-	orderRepoObj.Status = ListOrderStatus[OrderPlaced]
+	//retried request, replay the stored response instead of placing the order again
+	requestHash := fingerprintCreateOrderRequest(orderDetails)
+	if orderDetails.IdempotencyKey != "" {
+		storedOrder, found, err := os.getIdempotentResponse(ctx, tx, orderDetails, requestHash)
+		if err != nil || found {
+			return storedOrder, err
+		}
+	}
+
+	//no exchange rate into the requested currency, return error CurrencyUnavailable
+	currency := orderDetails.Currency
+	if currency == "" {
+		currency = os.cfg.Currency
+	}
+
+	rate, ok := os.cfg.Rate(currency)
+	if !ok {
+		return dto.Order{}, apperrors.CurrencyUnavailable{Currency: currency}
+	}
+
+	//coupon unknown, expired or used up, return error CouponNotRedeemable or CouponUsageExceeded
+	var orderCoupon dto.Coupon
+	if orderDetails.CouponCode != "" {
+		orderCoupon, err = os.couponSvc.GetRedeemableCoupon(ctx, tx, orderDetails.CustomerID, orderDetails.CouponCode)
+		if err != nil {
+			return dto.Order{}, err
+		}
+	}
+
+	//the order is taxed where it is delivered, in the default tax region when it has no address
+	var destination *dto.Address
+	address, hasAddress := shippingAddress(orderDetails, customerInfo)
+	if hasAddress {
+		destination = &address
+	}
+
+	orderRepoObj, orderItems, err := os.calculateOrderValueFromProducts(ctx, tx, orderDetails, orderCoupon, rate, destination)
+	if err != nil {
+		return dto.Order{}, err
+	}
+
+	//shipped order without an address to deliver it to, return error ShippingAddressRequired
+	if orderRepoObj.ShippingMethod != "" {
+		if !hasAddress {
+			return dto.Order{}, apperrors.ShippingAddressRequired{Method: orderRepoObj.ShippingMethod}
+		}
+		orderRepoObj.ShippingAddress = repository.Address(address)
+	}
+
+	orderRepoObj.CustomerID = orderDetails.CustomerID
+
+	//Set Order Status to the initial status of the lifecycle
+	orderRepoObj.Status = string(os.stateMachine.Initial())
 
 	//1. Inserting Order in Database
 	orderDB, err := os.orderRepo.CreateOrder(ctx, tx, orderRepoObj)
@@ -64,43 +155,114 @@ func (os *service) CreateOrder(ctx context.Context, orderDetails dto.CreateOrder
 		return dto.Order{}, err
 	}
 
-	orderItems := make([]repository.OrderItem, 0)
-	for _, p := range orderDetails.Products {
-		orderItems = append(orderItems, repository.OrderItem{
-			OrderID:   int64(orderDB.ID),
-			ProductID: p.ProductID,
-			Quantity:  p.Quantity,
-		})
+	for i := range orderItems {
+		orderItems[i].OrderID = int64(orderDB.ID)
 	}
 
-	//2. Inserting order items in database
+	//2. Inserting order items, snapshotting the products as they were sold, in database
 	err = os.orderItemsRepo.StoreOrderItems(ctx, tx, orderItems)
 	if err != nil {
 		return dto.Order{}, err
 	}
 
-	//3. Update Product quantity in database
-	productQuantityMap := make(map[int64]int64)
-	for _, p := range updatedProductInfo {
-		productQuantityMap[p.ProductID] = p.Quantity
+	//3. Redeeming the coupon within the same transaction, so concurrent orders cannot exceed its usage limits
+	if orderCoupon.ID != 0 {
+		err = os.couponSvc.RedeemCoupon(ctx, tx, orderCoupon, orderDetails.CustomerID, int64(orderDB.ID), couponDiscount(orderDB))
+		if err != nil {
+			return dto.Order{}, err
+		}
 	}
 
-	err = os.productSvc.UpdateProductQuantity(ctx, tx, productQuantityMap)
+	//4. Reserving the ordered stock until the order is dispatched or cancelled,
+	//stock not reserved by other orders insufficient, return error ProductQuantityInsufficient
+	err = os.productSvc.ReserveStock(ctx, tx, int64(orderDB.ID), orderDetails.Products)
 	if err != nil {
 		return dto.Order{}, err
 	}
 
 	order = MapOrderRepoToOrderDto(orderDB, orderItems...)
+
+	//5. Publishing the domain event of the order within the same transaction
+	err = os.publishOrderPlaced(ctx, tx, order)
+	if err != nil {
+		return dto.Order{}, err
+	}
+
+	//6. Storing the response for retries of the request within the same transaction
+	if orderDetails.IdempotencyKey != "" {
+		err = os.storeIdempotentResponse(ctx, tx, orderDetails, requestHash, order)
+		if err != nil {
+			return dto.Order{}, err
+		}
+	}
+
 	return order, nil
 }
 
-func (os *service) GetOrderDetailsByID(ctx context.Context, orderID int64) (order dto.Order, err error) {
+// getIdempotentResponse returns the order created by an earlier request with the same idempotency key,
+// found is false on the first use of the key
+func (os *service) getIdempotentResponse(ctx context.Context, tx repository.Transaction,
+	orderDetails dto.CreateOrderRequest, requestHash string) (order dto.Order, found bool, err error) {
+	idempotencyKeyDB, err := os.idempotencyRepo.GetIdempotencyKey(ctx, tx, orderDetails.CustomerID, orderDetails.IdempotencyKey)
+	if err != nil {
+		return dto.Order{}, false, err
+	}
+
+	if idempotencyKeyDB.ID == 0 {
+		return dto.Order{}, false, nil
+	}
+
+	//key reused with another request body, return error IdempotencyKeyReused
+	if idempotencyKeyDB.RequestHash != requestHash {
+		return dto.Order{}, false, apperrors.IdempotencyKeyReused{Key: orderDetails.IdempotencyKey}
+	}
+
+	err = json.Unmarshal(idempotencyKeyDB.Response, &order)
+	if err != nil {
+		return dto.Order{}, false, err
+	}
+
+	return order, true, nil
+}
+
+func (os *service) storeIdempotentResponse(ctx context.Context, tx repository.Transaction,
+	orderDetails dto.CreateOrderRequest, requestHash string, order dto.Order) error {
+	response, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.idempotencyRepo.CreateIdempotencyKey(ctx, tx, repository.IdempotencyKey{
+		CustomerID:  orderDetails.CustomerID,
+		Key:         orderDetails.IdempotencyKey,
+		RequestHash: requestHash,
+		Response:    response,
+	})
+	return err
+}
+
+func (os *service) ListShippingMethods(ctx context.Context, currency string) ([]dto.ShippingMethod, error) {
+	if currency == "" {
+		currency = os.cfg.Currency
+	}
+
+	//no exchange rate into the requested currency, return error CurrencyUnavailable
+	rate, ok := os.cfg.Rate(currency)
+	if !ok {
+		return nil, apperrors.CurrencyUnavailable{Currency: currency}
+	}
+
+	return os.shipping.Methods(rate), nil
+}
+
+func (os *service) GetOrderDetailsByID(ctx context.Context, customerID, orderID int64) (order dto.Order, err error) {
 	orderInfoDB, err := os.orderRepo.GetOrderByID(ctx, nil, orderID)
 	if err != nil {
 		return dto.Order{}, err
 	}
 
-	if orderInfoDB.ID == 0 {
+	//orders of other customers are reported as not found so their ids are not disclosed
+	if orderInfoDB.ID == 0 || !isOwnedBy(orderInfoDB, customerID) {
 		return dto.Order{}, apperrors.OrderNotFound{ID: orderID}
 	}
 
@@ -113,22 +275,57 @@ func (os *service) GetOrderDetailsByID(ctx context.Context, orderID int64) (orde
 	return order, nil
 }
 
-func (os *service) ListOrders(ctx context.Context) ([]dto.Order, error) {
-	orderList := make([]dto.Order, 0)
+func (os *service) GetOrderHistory(ctx context.Context, customerID, orderID int64) (dto.OrderHistory, error) {
+	orderInfoDB, err := os.orderRepo.GetOrderByID(ctx, nil, orderID)
+	if err != nil {
+		return dto.OrderHistory{}, err
+	}
+
+	//orders of other customers are reported as not found so their ids are not disclosed
+	if orderInfoDB.ID == 0 || !isOwnedBy(orderInfoDB, customerID) {
+		return dto.OrderHistory{}, apperrors.OrderNotFound{ID: orderID}
+	}
 
-	orderListDB, err := os.orderRepo.ListOrders(ctx, nil)
+	eventsDB, err := os.orderEventsRepo.ListOrderStatusEvents(ctx, nil, orderID)
+	if err != nil {
+		return dto.OrderHistory{}, err
+	}
+
+	return MapOrderHistoryToDto(orderInfoDB, eventsDB), nil
+}
+
+func (os *service) ListOrders(ctx context.Context, customerID int64, req dto.ListOrdersRequest) (dto.OrderList, error) {
+	orderList := dto.OrderList{
+		Orders:     make([]dto.Order, 0),
+		Pagination: dto.NewPagination(req.Page, req.PageSize, 0),
+	}
+
+	//status or sort_by unknown, return error OrderListQueryInvalid
+	filter, err := mapListOrdersRequestToFilter(os.stateMachine, customerID, req)
+	if err != nil {
+		return orderList, err
+	}
+
+	totalItems, err := os.orderRepo.CountOrders(ctx, nil, filter)
+	if err != nil {
+		return orderList, err
+	}
+
+	orderList.Pagination = dto.NewPagination(req.Page, req.PageSize, totalItems)
+
+	orderListDB, err := os.orderRepo.ListOrders(ctx, nil, filter)
 	if err != nil {
 		return orderList, err
 	}
 
 	for _, order := range orderListDB {
-		orderList = append(orderList, MapOrderRepoToOrderDto(order))
+		orderList.Orders = append(orderList.Orders, MapOrderRepoToOrderDto(order))
 	}
 
 	return orderList, nil
 }
 
-func (os *service) UpdateOrderStatus(ctx context.Context, orderID int64, status string) (order dto.Order, err error) {
+func (os *service) UpdateOrderStatus(ctx context.Context, actor auth.Principal, orderID int64, status, reason string) (order dto.Order, err error) {
 	//initializing database transaction
 	tx, err := os.orderRepo.BeginTx(ctx)
 	if err != nil {
@@ -144,7 +341,7 @@ func (os *service) UpdateOrderStatus(ctx context.Context, orderID int64, status
 	}()
 
 	//order status invalid, return error OrderStatusInvalid
-	if _, ok := MapOrderStatus[status]; !ok {
+	if !os.stateMachine.IsStatus(status) {
 		return dto.Order{}, apperrors.OrderStatusInvalid{ID: orderID}
 	}
 
@@ -153,14 +350,25 @@ func (os *service) UpdateOrderStatus(ctx context.Context, orderID int64, status
 		return dto.Order{}, err
 	}
 
-	//order not found invalid, return error OrderNotFound
-	if orderInfoDB.ID == 0 {
+	//order not found or placed by another customer, return error OrderNotFound
+	if orderInfoDB.ID == 0 || (actor.Role == auth.RoleCustomer && orderInfoDB.CustomerID != actor.CustomerID) {
 		return dto.Order{}, apperrors.OrderNotFound{ID: orderID}
 	}
 
-	//order status not allowed for update, return error OrderUpdationInvalid
-	isUpdationValid := validateUpdateOrderStatusRequest(status, orderInfoDB.Status)
-	if !isUpdationValid {
+	forbidden := apperrors.OrderUpdationForbidden{
+		ID:             orderID,
+		Role:           string(actor.Role),
+		RequestedState: status,
+	}
+
+	//role of the caller not allowed to request the status, return error OrderUpdationForbidden
+	if !os.stateMachine.CanRequest(actor, status) {
+		return dto.Order{}, forbidden
+	}
+
+	//no transition from the current status, return error OrderUpdationInvalid
+	transition, ok := os.stateMachine.Transition(orderInfoDB.Status, status)
+	if !ok {
 		return dto.Order{}, apperrors.OrderUpdationInvalid{
 			ID:             orderID,
 			CurrentState:   orderInfoDB.Status,
@@ -168,116 +376,270 @@ func (os *service) UpdateOrderStatus(ctx context.Context, orderID int64, status
 		}
 	}
 
+	if !actor.HasRole(transition.Roles...) {
+		return dto.Order{}, forbidden
+	}
+
+	for _, guard := range transition.Guards {
+		err = guard(orderInfoDB, actor)
+		if err != nil {
+			return dto.Order{}, err
+		}
+	}
+
 	//update order status in db
 	err = os.orderRepo.UpdateOrderStatus(ctx, tx, orderID, status)
 	if err != nil {
 		return dto.Order{}, fmt.Errorf("error occured while updating order status: %w", err)
 	}
 
-	//update product quantity if order cancelled or returned
-	if (MapOrderStatus[status] == OrderCancelled) || (MapOrderStatus[status] == OrderReturned) {
+	//record the transition in the order history
+	_, err = os.orderEventsRepo.CreateOrderStatusEvent(ctx, tx, repository.OrderStatusEvent{
+		OrderID:    orderID,
+		FromStatus: orderInfoDB.Status,
+		ToStatus:   status,
+		Actor:      actor.Subject,
+		ActorRole:  string(actor.Role),
+		Reason:     reason,
+	})
+	if err != nil {
+		return dto.Order{}, fmt.Errorf("error occured while recording order status event: %w", err)
+	}
+
+	err = os.eventSvc.Publish(ctx, tx, event.OrderStatusChanged, orderID, dto.OrderStatusChangedEvent{
+		OrderID:    orderID,
+		CustomerID: orderInfoDB.CustomerID,
+		FromStatus: orderInfoDB.Status,
+		ToStatus:   status,
+		Actor:      actor.Subject,
+		ActorRole:  string(actor.Role),
+		Reason:     reason,
+	})
+	if err != nil {
+		return dto.Order{}, err
+	}
 
-		orderItemsDB, err := os.orderItemsRepo.GetOrderItemsByOrderID(ctx, tx, orderID)
+	for _, effect := range transition.Effects {
+		err = os.applyEffect(ctx, tx, orderID, transition.To, effect)
 		if err != nil {
-			return dto.Order{}, fmt.Errorf("error occured while fetching order items: %w", err)
+			return dto.Order{}, err
 		}
+	}
+
+	orderInfoDB, err = os.orderRepo.GetOrderByID(ctx, tx, orderID)
+	if err != nil {
+		return dto.Order{}, err
+	}
 
-		productQuantityMap := make(map[int64]int64)
-		for _, item := range orderItemsDB {
+	order = MapOrderRepoToOrderDto(orderInfoDB)
+	return order, err
+}
 
-			product, err := os.productSvc.GetProductByID(ctx, tx, item.ProductID)
-			if err != nil {
-				return dto.Order{}, fmt.Errorf("error occured while fetching product with id %d,  %w", item.ProductID, err)
-			}
+// applyEffect runs a side effect of a status transition within the transaction of the update
+func (os *service) applyEffect(ctx context.Context, tx repository.Transaction, orderID int64, status OrderStatus, effect Effect) error {
+	switch effect {
+	case EffectRestock:
+		return os.restockOrderItems(ctx, tx, orderID, status)
 
-			productQuantityMap[item.ProductID] = product.Quantity + item.Quantity
+	case EffectCommitStock:
+		adjustments, err := os.productSvc.CommitReservations(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("error occured while committing order reservations: %w", err)
 		}
+		return os.publishStockAdjustments(ctx, tx, adjustments)
 
-		err = os.productSvc.UpdateProductQuantity(ctx, tx, productQuantityMap)
+	case EffectReleaseStock:
+		reserved, err := os.productSvc.ReleaseReservations(ctx, tx, orderID)
 		if err != nil {
-			return dto.Order{}, fmt.Errorf("error occured while updating product quantiry,  %w", err)
+			return fmt.Errorf("error occured while releasing order reservations: %w", err)
 		}
 
-	}
+		//orders placed before reservations took their stock right away
+		if !reserved {
+			return os.restockOrderItems(ctx, tx, orderID, status)
+		}
+		return nil
 
-	//update dispatch date only when order_status = Dispatched
-	if MapOrderStatus[status] == OrderDispatched {
-		orderDispatchedAt := now()
-		err = os.orderRepo.UpdateOrderDispatchDate(ctx, tx, orderID, orderDispatchedAt)
+	case EffectSetDispatchedAt:
+		err := os.orderRepo.UpdateOrderDispatchDate(ctx, tx, orderID, now())
 		if err != nil {
-			return dto.Order{}, fmt.Errorf("error occured while updating order dispatch date: %w", err)
+			return fmt.Errorf("error occured while updating order dispatch date: %w", err)
 		}
+		return nil
 	}
 
-	orderInfoDB, err = os.orderRepo.GetOrderByID(ctx, tx, orderID)
+	return fmt.Errorf("unknown order effect: %s", effect)
+}
+
+// restockOrderItems returns the quantities of the order items to the product stock as the order moves to status
+func (os *service) restockOrderItems(ctx context.Context, tx repository.Transaction, orderID int64, status OrderStatus) error {
+	orderItemsDB, err := os.orderItemsRepo.GetOrderItemsByOrderID(ctx, tx, orderID)
 	if err != nil {
-		return dto.Order{}, err
+		return fmt.Errorf("error occured while fetching order items: %w", err)
 	}
 
-	order = MapOrderRepoToOrderDto(orderInfoDB)
-	return order, err
+	adjustments := make([]dto.StockAdjustment, 0, len(orderItemsDB))
+	for _, item := range orderItemsDB {
+		adjustments = append(adjustments, dto.StockAdjustment{
+			ProductID: item.ProductID,
+			Delta:     item.Quantity,
+			Reason:    stockMovementReason(status),
+			OrderID:   orderID,
+		})
+	}
+
+	adjusted, err := os.productSvc.AdjustStock(ctx, tx, adjustments)
+	if err != nil {
+		return fmt.Errorf("error occured while restocking order items: %w", err)
+	}
+
+	return os.publishStockAdjustments(ctx, tx, adjusted)
 }
 
-func (os *service) calculateOrderValueFromProducts(ctx context.Context, tx repository.Transaction, requestedProducts []dto.ProductInfo) (
-	orderInfo repository.Order, productsUpdated []dto.ProductInfo, err error) {
+// publishOrderPlaced publishes the placed order, its stock is only reserved so no stock adjustment is published
+func (os *service) publishOrderPlaced(ctx context.Context, tx repository.Transaction, order dto.Order) error {
+	return os.eventSvc.Publish(ctx, tx, event.OrderPlaced, order.ID, dto.OrderPlacedEvent{
+		OrderID:            order.ID,
+		CustomerID:         order.CustomerID,
+		Products:           order.Products,
+		Amount:             order.Amount,
+		DiscountPercentage: order.DiscountPercentage,
+		Discounts:          order.Discounts,
+		TaxAmount:          order.TaxAmount,
+		ShippingMethod:     order.ShippingMethod,
+		ShippingAmount:     order.ShippingAmount,
+		FinalAmount:        order.FinalAmount,
+		ExchangeRate:       order.ExchangeRate,
+		Status:             order.Status,
+	})
+}
 
-	productsUpdated = make([]dto.ProductInfo, 0)
-	premiumProductCount := 0
+func (os *service) publishStockAdjustments(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustedEvent) error {
+	for _, adjustment := range adjustments {
+		err := os.eventSvc.Publish(ctx, tx, event.StockAdjusted, adjustment.ProductID, adjustment)
+		if err != nil {
+			return err
+		}
+	}
 
-	var orderAmount float64
-	var discountPercent float64
-	var finalOrderAmount float64
+	return nil
+}
+
+// calculateOrderValueFromProducts prices the requested products with the promotions and the coupon of the order, if any,
+// in the currency the rate converts the catalog prices into. Products priced in that currency keep their own price.
+// The discounted lines are then taxed in the region of the destination, the default tax region without one,
+// and the order is charged the untaxed shipping of its products with its shipping method.
+// The order items snapshot the products along with their share of the discounts and their tax.
+func (os *service) calculateOrderValueFromProducts(ctx context.Context, tx repository.Transaction, orderDetails dto.CreateOrderRequest,
+	orderCoupon dto.Coupon, rate money.Rate, destination *dto.Address) (orderInfo repository.Order, orderItems []repository.OrderItem, err error) {
+
+	requestedProducts := orderDetails.Products
+	lines := make([]pricing.Line, 0, len(requestedProducts))
+	parcels := make([]shipping.Parcel, 0, len(requestedProducts))
+	orderItems = make([]repository.OrderItem, 0, len(requestedProducts))
 
 	for _, p := range requestedProducts {
 		productInfo, err := os.productSvc.GetProductByID(ctx, tx, p.ProductID)
 		if err != nil {
-			return repository.Order{}, productsUpdated, err
+			return repository.Order{}, nil, err
+		}
+
+		//product retired from catalog, return error apperrors.ProductArchived
+		if productInfo.Archived {
+			return repository.Order{}, nil, apperrors.ProductArchived{ID: p.ProductID}
 		}
 
 		//product quantity exceeded limit, return error apperrors.ProductQuantityExceeded
-		if p.Quantity > MaxProductQuantity {
-			return repository.Order{}, productsUpdated, apperrors.ProductQuantityExceeded{
+		if p.Quantity > os.cfg.MaxProductQuantity {
+			return repository.Order{}, nil, apperrors.ProductQuantityExceeded{
 				ID:            p.ProductID,
 				QuantityAsked: p.Quantity,
-				QuantityLimit: MaxProductQuantity,
+				QuantityLimit: os.cfg.MaxProductQuantity,
 			}
 		}
 
-		//product quantity insufficient, return error apperrors.ProductQuantityInsufficient
-		if productInfo.Quantity < p.Quantity {
-			return repository.Order{}, productsUpdated, apperrors.ProductQuantityInsufficient{
-				ID:                p.ProductID,
-				QuantityAsked:     p.Quantity,
-				QuantityRemaining: productInfo.Quantity,
-			}
+		//product priced in another currency than the catalog, return error CurrencyMismatch
+		if productInfo.Price.Currency != rate.From {
+			return repository.Order{}, nil, apperrors.CurrencyMismatch{Currency: productInfo.Price.Currency, Expected: rate.From}
 		}
 
-		orderAmount = orderAmount + (float64(p.Quantity) * productInfo.Price)
+		price := productInfo.PriceIn(rate)
+		lines = append(lines, pricing.Line{
+			ProductID: p.ProductID,
+			Category:  productInfo.Category,
+			Price:     price,
+			Quantity:  p.Quantity,
+		})
+
+		parcels = append(parcels, mapProductToParcel(productInfo, p.Quantity))
+
+		orderItems = append(orderItems, repository.OrderItem{
+			ProductID:   p.ProductID,
+			ProductName: productInfo.Name,
+			Category:    productInfo.Category,
+			UnitPrice:   price.MinorUnits,
+			Quantity:    p.Quantity,
+		})
+	}
+
+	//applying the configured promotions the order qualifies for
+	quote, err := os.pricing.In(rate).Price(lines)
+	if err != nil {
+		return repository.Order{}, nil, err
+	}
 
-		//update premium product counter
-		if productInfo.Category == string(product.PremiumProduct) {
-			premiumProductCount = premiumProductCount + 1
+	//no ordered product in the categories of the coupon, return error CouponNotRedeemable
+	if orderCoupon.ID != 0 {
+		var applied bool
+		quote, applied = pricing.ApplyCoupon(quote, lines, mapCouponToPricing(orderCoupon).In(rate))
+		if !applied {
+			return repository.Order{}, nil, apperrors.CouponNotRedeemable{Code: orderCoupon.Code, Reason: "no ordered product is eligible"}
 		}
+	}
 
-		//adding product details with updated quantity to the list
-		productsUpdated = append(productsUpdated, dto.ProductInfo{
-			ProductID: p.ProductID,
-			Quantity:  (productInfo.Quantity - p.Quantity),
-		})
+	taxLines := make([]tax.Line, 0, len(lines))
+	for i, lineDiscount := range quote.LineDiscounts {
+		orderItems[i].Discount = lineDiscount.MinorUnits
+		taxLines = append(taxLines, tax.Line{Category: lines[i].Category, Amount: lines[i].Total().Sub(lineDiscount)})
+	}
+
+	//destination in no region with tax rates, return error TaxRegionUnknown
+	assessment, err := os.tax.Calculate(destination, quote.Amount.Currency, taxLines)
+	if err != nil {
+		return repository.Order{}, nil, err
+	}
+
+	for i, lineTax := range assessment.Lines {
+		orderItems[i].TaxRate = lineTax.Rate
+		orderItems[i].Tax = lineTax.Amount.MinorUnits
+	}
+
+	//shipping method outside the catalog, return error ShippingMethodUnknown
+	shippingQuote, err := os.shipping.Quote(orderDetails.ShippingMethod, rate, quote.FinalAmount, parcels)
+	if err != nil {
+		return repository.Order{}, nil, err
 	}
 
-	finalOrderAmount = orderAmount
-	//checking if premium products are equal or more than 3
-	if premiumProductCount >= PremiumProductsForDiscount {
-		discountPercent = DefaultDiscountPercentage
-		finalOrderAmount = orderAmount * (100 - discountPercent) / 100
+	//inclusive prices already hold the tax, it is only added on top of exclusive ones
+	finalAmount := quote.FinalAmount.Add(shippingQuote.Amount)
+	if !assessment.Inclusive {
+		finalAmount = finalAmount.Add(assessment.Amount)
 	}
 
 	orderInfo = repository.Order{
-		Amount:             orderAmount,
-		DiscountPercentage: discountPercent,
-		FinalAmount:        finalOrderAmount,
+		Currency:           quote.Amount.Currency,
+		BaseCurrency:       rate.From,
+		ExchangeRate:       rate.Value,
+		Amount:             quote.Amount.MinorUnits,
+		DiscountPercentage: quote.DiscountPercentage,
+		FinalAmount:        finalAmount.MinorUnits,
+		Discounts:          mapAppliedDiscountsToRepo(quote.Discounts),
+		TaxAmount:          assessment.Amount.MinorUnits,
+		TaxRegion:          assessment.Region,
+		TaxInclusive:       assessment.Inclusive,
+		ShippingMethod:     shippingQuote.Method,
+		ShippingAmount:     shippingQuote.Amount.MinorUnits,
 	}
 
-	return orderInfo, productsUpdated, nil
+	return orderInfo, orderItems, nil
 }
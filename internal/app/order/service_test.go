@@ -8,9 +8,16 @@ import (
 	"time"
 
 	"github.com/asdine/storm/v3"
+	couponMock "github.com/sagar23sj/go-ecommerce/internal/app/coupon/mocks"
+	customerMock "github.com/sagar23sj/go-ecommerce/internal/app/customer/mocks"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	eventMock "github.com/sagar23sj/go-ecommerce/internal/app/event/mocks"
 	productMock "github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
 	"github.com/stretchr/testify/mock"
@@ -19,10 +26,15 @@ import (
 
 type OrderServiceTestSuite struct {
 	suite.Suite
-	service        Service
-	orderRepo      *mocks.OrderStorer
-	orderItemRepo  *mocks.OrderItemStorer
-	productService *productMock.Service
+	service         Service
+	orderRepo       *mocks.OrderStorer
+	orderItemRepo   *mocks.OrderItemStorer
+	idempotencyRepo *mocks.IdempotencyStorer
+	orderEventsRepo *mocks.OrderStatusEventStorer
+	productService  *productMock.Service
+	customerService *customerMock.Service
+	couponService   *couponMock.Service
+	eventService    *eventMock.Service
 }
 
 func TestOrderServiceTestSuite(t *testing.T) {
@@ -33,16 +45,49 @@ func TestOrderServiceTestSuite(t *testing.T) {
 func (suite *OrderServiceTestSuite) SetupTest() {
 	suite.orderRepo = &mocks.OrderStorer{}
 	suite.orderItemRepo = &mocks.OrderItemStorer{}
+	suite.idempotencyRepo = &mocks.IdempotencyStorer{}
+	suite.orderEventsRepo = &mocks.OrderStatusEventStorer{}
 	suite.productService = &productMock.Service{}
+	suite.customerService = &customerMock.Service{}
+	suite.couponService = &couponMock.Service{}
+	suite.eventService = &eventMock.Service{}
 
-	suite.service = NewService(suite.orderRepo, suite.orderItemRepo, suite.productService)
+	suite.service = suite.newService(func(cfg *config.OrderConfig) {})
+}
+
+// shippingCatalog ships flat by default and by weight on request
+var shippingCatalog = config.ShippingConfig{
+	DefaultMethod: "standard",
+	Methods: []config.ShippingMethodConfig{
+		{Name: "standard", Type: "flat", Amount: 100, FreeAbove: 5000},
+		{Name: "express", Type: "weight", Amount: 300, PerKg: 100},
+	},
+}
+
+// newService returns the order service with the test settings, changed by update
+func (suite *OrderServiceTestSuite) newService(update func(cfg *config.OrderConfig)) Service {
+	cfg := config.Default().Order
+	cfg.Rates = map[string]float64{"USD": 0.012}
+	cfg.Tax = config.TaxConfig{
+		Classes: map[string]string{"Budget": "reduced"},
+		Regions: map[string]map[string]float64{"IN-MH": {"standard": 18, "reduced": 5}},
+	}
+	update(&cfg)
+
+	return NewService(suite.orderRepo, suite.orderItemRepo, suite.idempotencyRepo, suite.orderEventsRepo,
+		suite.productService, suite.customerService, suite.couponService, suite.eventService, cfg)
 }
 
 // this function executes after all tests executed
 func (suite *OrderServiceTestSuite) TearDownTest() {
 	suite.orderRepo.AssertExpectations(suite.T())
 	suite.orderItemRepo.AssertExpectations(suite.T())
+	suite.idempotencyRepo.AssertExpectations(suite.T())
+	suite.orderEventsRepo.AssertExpectations(suite.T())
 	suite.productService.AssertExpectations(suite.T())
+	suite.customerService.AssertExpectations(suite.T())
+	suite.couponService.AssertExpectations(suite.T())
+	suite.eventService.AssertExpectations(suite.T())
 }
 
 func (suite *OrderServiceTestSuite) TestCreateOrder() {
@@ -58,6 +103,7 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 		{
 			name: "Success",
 			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
 				Products: []dto.ProductInfo{
 					{
 						ProductID: int64(1),
@@ -69,38 +115,62 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 				tx := &storm.DB{}
 				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
-					ID:       int64(1),
-					Name:     "xyz",
-					Price:    10.0,
-					Category: "Premium",
-					Quantity: int64(10),
+					ID:              int64(1),
+					Name:            "xyz",
+					Price:           money.New(1000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
 				}, nil)
 				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
-					Amount:             20.0,
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil)
 				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, []repository.OrderItem{{
-					OrderID:   int64(1),
-					ProductID: int64(1),
-					Quantity:  int64(2),
+					OrderID:     int64(1),
+					ProductID:   int64(1),
+					ProductName: "xyz",
+					Category:    "Premium",
+					UnitPrice:   1000,
+					Quantity:    int64(2),
 				}}).Return(nil)
-				suite.productService.On("UpdateProductQuantity", mock.Anything, tx, map[int64]int64{1: 8}).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), []dto.ProductInfo{{ProductID: 1, Quantity: 2}}).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), dto.OrderPlacedEvent{
+					OrderID:        int64(1),
+					CustomerID:     int64(1),
+					Products:       []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:         money.New(2000, "INR"),
+					TaxAmount:      money.New(0, "INR"),
+					ShippingAmount: money.New(0, "INR"),
+					FinalAmount:    money.New(2000, "INR"),
+					ExchangeRate:   money.Identity("INR"),
+					Status:         "Placed",
+				}).Return(nil).Once()
 			},
 			expectedOutput: dto.Order{
 				ID:                 int64(1),
 				Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-				Amount:             20.0,
+				Amount:             money.New(2000, "INR"),
 				DiscountPercentage: 0.0,
-				FinalAmount:        20.0,
+				FinalAmount:        money.New(2000, "INR"),
 				Status:             "Placed",
 			},
 			expectedErr: nil,
@@ -108,6 +178,7 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 		{
 			name: "Success for 3 Premium Products",
 			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
 				Products: []dto.ProductInfo{
 					{
 						ProductID: int64(1),
@@ -127,125 +198,908 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 				tx := &storm.DB{}
 				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
-					ID:       int64(1),
-					Name:     "xyz",
-					Price:    10.0,
-					Category: "Premium",
-					Quantity: int64(10),
+					ID:              int64(1),
+					Name:            "xyz",
+					Price:           money.New(1000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
 				}, nil).Once()
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(2)).Return(dto.Product{
-					ID:       int64(2),
-					Name:     "xyz",
-					Price:    20.0,
-					Category: "Premium",
-					Quantity: int64(10),
+					ID:              int64(2),
+					Name:            "xyz",
+					Price:           money.New(2000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
 				}, nil).Once()
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(3)).Return(dto.Product{
-					ID:       int64(3),
-					Name:     "xyz",
-					Price:    30.0,
-					Category: "Premium",
-					Quantity: int64(10),
+					ID:              int64(3),
+					Name:            "xyz",
+					Price:           money.New(3000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
 				}, nil).Once()
 				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
-					Amount:             120.0,
-					DiscountPercentage: 10.0,
-					FinalAmount:        108.0,
-					Status:             "Placed",
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             12000,
+					DiscountPercentage: 10.0,
+					Discounts:          []repository.OrderDiscount{{Promotion: "premium_products", Type: "percentage", Amount: 1200}},
+					FinalAmount:        10800,
+					Status:             "Placed",
+				}).Return(repository.Order{
+					ID:                 uint(1),
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             12000,
+					DiscountPercentage: 10.0,
+					Discounts:          []repository.OrderDiscount{{Promotion: "premium_products", Type: "percentage", Amount: 1200}},
+					FinalAmount:        10800,
+					Status:             "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, []repository.OrderItem{
+					{OrderID: 1, ProductID: 1, ProductName: "xyz", Category: "Premium", UnitPrice: 1000, Quantity: 2, Discount: 200},
+					{OrderID: 1, ProductID: 2, ProductName: "xyz", Category: "Premium", UnitPrice: 2000, Quantity: 2, Discount: 400},
+					{OrderID: 1, ProductID: 3, ProductName: "xyz", Category: "Premium", UnitPrice: 3000, Quantity: 2, Discount: 600},
+				}).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:                 int64(1),
+				Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+				Amount:             money.New(12000, "INR"),
+				DiscountPercentage: 10.0,
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: money.New(1200, "INR")}},
+				FinalAmount:        money.New(10800, "INR"),
+				Status:             "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Publishing Order Placed Event Failed",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Price:           money.New(1000, "INR"),
+					Category:        "Regular",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, mock.Anything).Return(repository.Order{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Currency:    "INR",
+					Amount:      2000,
+					FinalAmount: 2000,
+					Status:      "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(errors.New("outbox unavailable")).Once()
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    errors.New("outbox unavailable"),
+		},
+		{
+			name: "Failed Because Product Limit Exceeded",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products: []dto.ProductInfo{
+					{
+						ProductID: int64(1),
+						Quantity:  int64(12),
+					},
+				},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Name:            "xyz",
+					Price:           money.New(1000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(20),
+					AvailableToSell: int64(20),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr: apperrors.ProductQuantityExceeded{
+				ID:            1,
+				QuantityLimit: 10,
+				QuantityAsked: 12,
+			},
+		},
+		{
+			name: "Fail Because Product Quantity Insufficient",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products: []dto.ProductInfo{
+					{
+						ProductID: int64(1),
+						Quantity:  int64(8),
+					},
+				},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Name:            "xyz",
+					Price:           money.New(1000, "INR"),
+					Category:        "Premium",
+					Quantity:        int64(10),
+					Reserved:        int64(4),
+					AvailableToSell: int64(6),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, mock.Anything).Return(repository.Order{ID: uint(1), FinalAmount: 8000, Status: "Placed"}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				//the stock is checked when it is reserved, under the product locks
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), []dto.ProductInfo{{ProductID: 1, Quantity: 8}}).
+					Return(apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 8, QuantityRemaining: 6})
+			},
+			expectedOutput: dto.Order{},
+			expectedErr: apperrors.ProductQuantityInsufficient{
+				ID:                1,
+				QuantityRemaining: 6,
+				QuantityAsked:     8,
+			},
+		},
+		{
+			name: "Fail Because Customer Not Found",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(5),
+				Products: []dto.ProductInfo{
+					{
+						ProductID: int64(1),
+						Quantity:  int64(2),
+					},
+				},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(5)).Return(dto.Customer{}, apperrors.CustomerNotFound{ID: 5})
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CustomerNotFound{ID: 5},
+		},
+		{
+			name: "Success In Another Currency At Its Exchange Rate",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				Currency:   "USD",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Price: money.New(100000, "INR"), Category: "Regular", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:   int64(1),
+					Currency:     "USD",
+					BaseCurrency: "INR",
+					ExchangeRate: 0.012,
+					Amount:       2400,
+					FinalAmount:  2400,
+					Status:       "Placed",
+				}).Return(repository.Order{
+					ID:           uint(1),
+					CustomerID:   int64(1),
+					Currency:     "USD",
+					BaseCurrency: "INR",
+					ExchangeRate: 0.012,
+					Amount:       2400,
+					FinalAmount:  2400,
+					Status:       "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), dto.OrderPlacedEvent{
+					OrderID:        int64(1),
+					CustomerID:     int64(1),
+					Products:       []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+					Amount:         money.New(2400, "USD"),
+					TaxAmount:      money.New(0, "USD"),
+					ShippingAmount: money.New(0, "USD"),
+					FinalAmount:    money.New(2400, "USD"),
+					ExchangeRate:   money.Rate{From: "INR", To: "USD", Value: 0.012},
+					Status:         "Placed",
+				}).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:           int64(1),
+				Amount:       money.New(2400, "USD"),
+				FinalAmount:  money.New(2400, "USD"),
+				ExchangeRate: money.Rate{From: "INR", To: "USD", Value: 0.012},
+				Status:       "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success In Currency The Product Has A Price In",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				Currency:   "USD",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Price:           money.New(100000, "INR"),
+					Prices:          []money.Money{money.New(1500, "USD")},
+					Category:        "Regular",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:   int64(1),
+					Currency:     "USD",
+					BaseCurrency: "INR",
+					ExchangeRate: 0.012,
+					Amount:       3000,
+					FinalAmount:  3000,
+					Status:       "Placed",
+				}).Return(repository.Order{
+					ID:           uint(1),
+					CustomerID:   int64(1),
+					Currency:     "USD",
+					BaseCurrency: "INR",
+					ExchangeRate: 0.012,
+					Amount:       3000,
+					FinalAmount:  3000,
+					Status:       "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:           int64(1),
+				Amount:       money.New(3000, "USD"),
+				FinalAmount:  money.New(3000, "USD"),
+				ExchangeRate: money.Rate{From: "INR", To: "USD", Value: 0.012},
+				Status:       "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Currency Has No Exchange Rate",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				Currency:   "EUR",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CurrencyUnavailable{Currency: "EUR"},
+		},
+		{
+			name: "Fail Because Product Priced In Another Currency",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				Currency:   "USD",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Name:            "xyz",
+					Price:           money.New(1000, "EUR"),
+					Category:        "Premium",
+					Quantity:        int64(20),
+					AvailableToSell: int64(20),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CurrencyMismatch{Currency: "EUR", Expected: "INR"},
+		},
+		{
+			name: "Success Storing Response For Idempotency Key",
+			input: dto.CreateOrderRequest{
+				CustomerID:     int64(1),
+				IdempotencyKey: "order-attempt-1",
+				Products:       []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.idempotencyRepo.On("GetIdempotencyKey", mock.Anything, tx, int64(1), "order-attempt-1").Return(repository.IdempotencyKey{}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID:              int64(1),
+					Price:           money.New(1000, "INR"),
+					Category:        "Regular",
+					Quantity:        int64(10),
+					AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, mock.Anything).Return(repository.Order{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Currency:    "INR",
+					Amount:      2000,
+					FinalAmount: 2000,
+					Status:      "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				suite.idempotencyRepo.On("CreateIdempotencyKey", mock.Anything, tx, mock.MatchedBy(func(key repository.IdempotencyKey) bool {
+					return key.CustomerID == 1 && key.Key == "order-attempt-1" && len(key.Response) > 0
+				})).Return(repository.IdempotencyKey{ID: 1}, nil)
+			},
+			expectedOutput: dto.Order{
+				ID:          int64(1),
+				FinalAmount: money.New(2000, "INR"),
+				Status:      "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success Replaying Response For Repeated Idempotency Key",
+			input: dto.CreateOrderRequest{
+				CustomerID:     int64(1),
+				IdempotencyKey: "order-attempt-1",
+				Products:       []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.idempotencyRepo.On("GetIdempotencyKey", mock.Anything, tx, int64(1), "order-attempt-1").Return(repository.IdempotencyKey{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Key:         "order-attempt-1",
+					RequestHash: fingerprintCreateOrderRequest(dto.CreateOrderRequest{Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}}}),
+					Response: []byte(`{"id":1,"amount":{"minor_units":2000,"currency":"INR"},"discount_percent":0,` +
+						`"final_amount":{"minor_units":2000,"currency":"INR"},"status":"Placed"}`),
+				}, nil)
+			},
+			expectedOutput: dto.Order{
+				ID:          int64(1),
+				FinalAmount: money.New(2000, "INR"),
+				Status:      "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Idempotency Key Reused With Different Products",
+			input: dto.CreateOrderRequest{
+				CustomerID:     int64(1),
+				IdempotencyKey: "order-attempt-1",
+				Products:       []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(3)}},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.idempotencyRepo.On("GetIdempotencyKey", mock.Anything, tx, int64(1), "order-attempt-1").Return(repository.IdempotencyKey{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Key:         "order-attempt-1",
+					RequestHash: fingerprintCreateOrderRequest(dto.CreateOrderRequest{Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}}}),
+					Response:    []byte(`{"id":1}`),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.IdempotencyKeyReused{Key: "order-attempt-1"},
+		},
+		{
+			name: "Fail Because Idempotency Key Reused With Different Shipping Address",
+			input: dto.CreateOrderRequest{
+				CustomerID:      int64(1),
+				IdempotencyKey:  "order-attempt-1",
+				Products:        []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", PostalCode: "411001", Country: "IN"},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.idempotencyRepo.On("GetIdempotencyKey", mock.Anything, tx, int64(1), "order-attempt-1").Return(repository.IdempotencyKey{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Key:        "order-attempt-1",
+					RequestHash: fingerprintCreateOrderRequest(dto.CreateOrderRequest{
+						Products:        []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
+						ShippingAddress: &dto.Address{Line1: "7 FC Road", City: "Pune", PostalCode: "411004", Country: "IN"},
+					}),
+					Response: []byte(`{"id":1}`),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.IdempotencyKeyReused{Key: "order-attempt-1"},
+		},
+		{
+			name: "Success Redeeming Coupon",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				CouponCode: "welcome5",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				fiveRupees := money.New(500, "INR")
+				welcome := dto.Coupon{ID: 7, Code: "WELCOME5", DiscountType: "fixed", Amount: &fiveRupees, Categories: []string{"Premium"}}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.couponService.On("GetRedeemableCoupon", mock.Anything, tx, int64(1), "welcome5").Return(welcome, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             2000,
+					DiscountPercentage: 25.0,
+					Discounts:          []repository.OrderDiscount{{Promotion: "WELCOME5", Type: "coupon", Amount: 500}},
+					FinalAmount:        1500,
+					Status:             "Placed",
+				}).Return(repository.Order{
+					ID:                 uint(1),
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					BaseCurrency:       "INR",
+					ExchangeRate:       1,
+					Amount:             2000,
+					DiscountPercentage: 25.0,
+					Discounts:          []repository.OrderDiscount{{Promotion: "WELCOME5", Type: "coupon", Amount: 500}},
+					FinalAmount:        1500,
+					Status:             "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.couponService.On("RedeemCoupon", mock.Anything, tx, welcome, int64(1), int64(1), money.New(500, "INR")).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:                 int64(1),
+				Amount:             money.New(2000, "INR"),
+				DiscountPercentage: 25.0,
+				FinalAmount:        money.New(1500, "INR"),
+				Status:             "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Coupon Expired",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				CouponCode: "SUMMER",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.couponService.On("GetRedeemableCoupon", mock.Anything, tx, int64(1), "SUMMER").
+					Return(dto.Coupon{}, apperrors.CouponNotRedeemable{Code: "SUMMER", Reason: "coupon has expired"})
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CouponNotRedeemable{Code: "SUMMER", Reason: "coupon has expired"},
+		},
+		{
+			name: "Fail Because No Product Eligible For Coupon",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				CouponCode: "BUDGET10",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.couponService.On("GetRedeemableCoupon", mock.Anything, tx, int64(1), "BUDGET10").Return(dto.Coupon{
+					ID: 8, Code: "BUDGET10", DiscountType: "percentage", Percent: 10.0, Categories: []string{"Budget"},
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CouponNotRedeemable{Code: "BUDGET10", Reason: "no ordered product is eligible"},
+		},
+		{
+			name: "Fail Because Concurrent Order Took Last Coupon Use",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				CouponCode: "FIRST100",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.couponService.On("GetRedeemableCoupon", mock.Anything, tx, int64(1), "FIRST100").Return(dto.Coupon{
+					ID: 9, Code: "FIRST100", DiscountType: "percentage", Percent: 10.0, UsageLimit: 100,
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, mock.Anything).Return(repository.Order{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Currency:    "INR",
+					Amount:      2000,
+					Discounts:   []repository.OrderDiscount{{Promotion: "FIRST100", Type: "coupon", Amount: 200}},
+					FinalAmount: 1800,
+					Status:      "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.couponService.On("RedeemCoupon", mock.Anything, tx, mock.Anything, int64(1), int64(1), money.New(200, "INR")).
+					Return(apperrors.CouponUsageExceeded{Code: "FIRST100"})
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.CouponUsageExceeded{Code: "FIRST100"},
+		},
+		{
+			name: "Success With Tax Added In Region Of Shipping Address",
+			input: dto.CreateOrderRequest{
+				CustomerID:      int64(1),
+				Products:        []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}, {ProductID: int64(2), Quantity: int64(2)}},
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Name: "xyz", Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil).Once()
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(2)).Return(dto.Product{
+					ID: int64(2), Name: "abc", Price: money.New(500, "INR"), Category: "Budget", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil).Once()
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:   int64(1),
+					Currency:     "INR",
+					BaseCurrency: "INR",
+					ExchangeRate: 1,
+					Amount:       3000,
+					TaxAmount:    410,
+					TaxRegion:    "IN-MH",
+					FinalAmount:  3410,
+					Status:       "Placed",
+				}).Return(repository.Order{
+					ID:           uint(1),
+					CustomerID:   int64(1),
+					Currency:     "INR",
+					BaseCurrency: "INR",
+					ExchangeRate: 1,
+					Amount:       3000,
+					TaxAmount:    410,
+					TaxRegion:    "IN-MH",
+					FinalAmount:  3410,
+					Status:       "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, []repository.OrderItem{
+					{OrderID: 1, ProductID: 1, ProductName: "xyz", Category: "Premium", UnitPrice: 1000, Quantity: 2, TaxRate: 18, Tax: 360},
+					{OrderID: 1, ProductID: 2, ProductName: "abc", Category: "Budget", UnitPrice: 500, Quantity: 2, TaxRate: 5, Tax: 50},
+				}).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:          int64(1),
+				Amount:      money.New(3000, "INR"),
+				TaxAmount:   money.New(410, "INR"),
+				TaxRegion:   "IN-MH",
+				FinalAmount: money.New(3410, "INR"),
+				Status:      "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success With Tax Included In Prices Of Default Region",
+			input: dto.CreateOrderRequest{
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(1)}},
+			},
+			setup: func() {
+				suite.service = suite.newService(func(cfg *config.OrderConfig) {
+					cfg.Tax.Inclusive = true
+					cfg.Tax.DefaultRegion = "IN-MH"
+				})
+
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Name: "xyz", Price: money.New(1180, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:   int64(1),
+					Currency:     "INR",
+					BaseCurrency: "INR",
+					ExchangeRate: 1,
+					Amount:       1180,
+					TaxAmount:    180,
+					TaxRegion:    "IN-MH",
+					TaxInclusive: true,
+					FinalAmount:  1180,
+					Status:       "Placed",
+				}).Return(repository.Order{
+					ID:           uint(1),
+					CustomerID:   int64(1),
+					Currency:     "INR",
+					BaseCurrency: "INR",
+					ExchangeRate: 1,
+					Amount:       1180,
+					TaxAmount:    180,
+					TaxRegion:    "IN-MH",
+					TaxInclusive: true,
+					FinalAmount:  1180,
+					Status:       "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, []repository.OrderItem{
+					{OrderID: 1, ProductID: 1, ProductName: "xyz", Category: "Premium", UnitPrice: 1180, Quantity: 1, TaxRate: 18, Tax: 180},
+				}).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:           int64(1),
+				Amount:       money.New(1180, "INR"),
+				TaxAmount:    money.New(180, "INR"),
+				TaxRegion:    "IN-MH",
+				TaxInclusive: true,
+				FinalAmount:  money.New(1180, "INR"),
+				Status:       "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Shipping Address In Unknown Tax Region",
+			input: dto.CreateOrderRequest{
+				CustomerID:      int64(1),
+				Products:        []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				ShippingAddress: &dto.Address{Line1: "1 Mount Road", City: "Chennai", State: "TN", PostalCode: "600002", Country: "IN"},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.TaxRegionUnknown{Region: "IN-TN"},
+		},
+		{
+			name: "Success Shipped By Weight To Customer Address Without Tax On Shipping",
+			input: dto.CreateOrderRequest{
+				CustomerID:     int64(1),
+				Products:       []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				ShippingMethod: "express",
+			},
+			setup: func() {
+				suite.service = suite.newService(func(cfg *config.OrderConfig) {
+					cfg.Shipping = shippingCatalog
+				})
+
+				address := dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"}
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).
+					Return(dto.Customer{ID: 1, Addresses: []dto.Address{address}}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Name: "xyz", Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+					WeightGrams: 800,
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:      int64(1),
+					Currency:        "INR",
+					BaseCurrency:    "INR",
+					ExchangeRate:    1,
+					Amount:          2000,
+					TaxAmount:       360,
+					TaxRegion:       "IN-MH",
+					ShippingMethod:  "express",
+					ShippingAmount:  500,
+					ShippingAddress: repository.Address(address),
+					FinalAmount:     2860,
+					Status:          "Placed",
+				}).Return(repository.Order{
+					ID:              uint(1),
+					CustomerID:      int64(1),
+					Currency:        "INR",
+					BaseCurrency:    "INR",
+					ExchangeRate:    1,
+					Amount:          2000,
+					TaxAmount:       360,
+					TaxRegion:       "IN-MH",
+					ShippingMethod:  "express",
+					ShippingAmount:  500,
+					ShippingAddress: repository.Address(address),
+					FinalAmount:     2860,
+					Status:          "Placed",
+				}, nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:              int64(1),
+				Amount:          money.New(2000, "INR"),
+				TaxAmount:       money.New(360, "INR"),
+				TaxRegion:       "IN-MH",
+				ShippingMethod:  "express",
+				ShippingAmount:  money.New(500, "INR"),
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+				FinalAmount:     money.New(2860, "INR"),
+				Status:          "Placed",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success Taxed In Region Of Shipping Address Instead Of Customer Address",
+			input: dto.CreateOrderRequest{
+				CustomerID:      int64(1),
+				Products:        []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				ShippingMethod:  "express",
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+			},
+			setup: func() {
+				suite.service = suite.newService(func(cfg *config.OrderConfig) {
+					cfg.Shipping = shippingCatalog
+				})
+
+				address := dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"}
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{
+					ID:        1,
+					Addresses: []dto.Address{{Line1: "1 Mount Road", City: "Chennai", State: "TN", PostalCode: "600002", Country: "IN"}},
+				}, nil)
+				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
+					ID: int64(1), Name: "xyz", Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
+					WeightGrams: 800,
+				}, nil)
+				suite.orderRepo.On("CreateOrder", mock.Anything, tx, repository.Order{
+					CustomerID:      int64(1),
+					Currency:        "INR",
+					BaseCurrency:    "INR",
+					ExchangeRate:    1,
+					Amount:          2000,
+					TaxAmount:       360,
+					TaxRegion:       "IN-MH",
+					ShippingMethod:  "express",
+					ShippingAmount:  500,
+					ShippingAddress: repository.Address(address),
+					FinalAmount:     2860,
+					Status:          "Placed",
 				}).Return(repository.Order{
-					ID:                 uint(1),
-					Amount:             120.0,
-					DiscountPercentage: 10.0,
-					FinalAmount:        108.0,
-					Status:             "Placed",
+					ID:              uint(1),
+					CustomerID:      int64(1),
+					Currency:        "INR",
+					BaseCurrency:    "INR",
+					ExchangeRate:    1,
+					Amount:          2000,
+					TaxAmount:       360,
+					TaxRegion:       "IN-MH",
+					ShippingMethod:  "express",
+					ShippingAmount:  500,
+					ShippingAddress: repository.Address(address),
+					FinalAmount:     2860,
+					Status:          "Placed",
 				}, nil)
-				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, []repository.OrderItem{
-					{
-						OrderID:   int64(1),
-						ProductID: int64(1),
-						Quantity:  int64(2),
-					},
-					{
-						OrderID:   int64(1),
-						ProductID: int64(2),
-						Quantity:  int64(2),
-					},
-					{
-						OrderID:   int64(1),
-						ProductID: int64(3),
-						Quantity:  int64(2),
-					},
-				}).Return(nil)
-				suite.productService.On("UpdateProductQuantity", mock.Anything, tx, map[int64]int64{1: 8, 2: 8, 3: 8}).Return(nil)
+				suite.orderItemRepo.On("StoreOrderItems", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productService.On("ReserveStock", mock.Anything, tx, int64(1), mock.Anything).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderPlaced, int64(1), mock.Anything).Return(nil).Once()
 			},
 			expectedOutput: dto.Order{
-				ID:                 int64(1),
-				Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-				Amount:             120.0,
-				DiscountPercentage: 10.0,
-				FinalAmount:        108.0,
-				Status:             "Placed",
+				ID:              int64(1),
+				Amount:          money.New(2000, "INR"),
+				TaxAmount:       money.New(360, "INR"),
+				TaxRegion:       "IN-MH",
+				ShippingMethod:  "express",
+				ShippingAmount:  money.New(500, "INR"),
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+				FinalAmount:     money.New(2860, "INR"),
+				Status:          "Placed",
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Failed Because Product Limit Exceeded",
+			name: "Fail Because Shipping Address Missing",
 			input: dto.CreateOrderRequest{
-				Products: []dto.ProductInfo{
-					{
-						ProductID: int64(1),
-						Quantity:  int64(12),
-					},
-				},
+				CustomerID: int64(1),
+				Products:   []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
 			},
 			setup: func() {
+				suite.service = suite.newService(func(cfg *config.OrderConfig) {
+					cfg.Shipping = shippingCatalog
+				})
+
 				tx := &storm.DB{}
 				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
-					ID:       int64(1),
-					Name:     "xyz",
-					Price:    10.0,
-					Category: "Premium",
-					Quantity: int64(20),
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
 				}, nil)
 			},
 			expectedOutput: dto.Order{},
-			expectedErr: apperrors.ProductQuantityExceeded{
-				ID:            1,
-				QuantityLimit: 10,
-				QuantityAsked: 12,
-			},
+			expectedErr:    apperrors.ShippingAddressRequired{Method: "standard"},
 		},
 		{
-			name: "Fail Because Product Quantity Insufficient",
+			name: "Fail Because Shipping Method Unknown",
 			input: dto.CreateOrderRequest{
-				Products: []dto.ProductInfo{
-					{
-						ProductID: int64(1),
-						Quantity:  int64(8),
-					},
-				},
+				CustomerID:      int64(1),
+				Products:        []dto.ProductInfo{{ProductID: int64(1), Quantity: int64(2)}},
+				ShippingMethod:  "drone",
+				ShippingAddress: &dto.Address{Line1: "12 MG Road", City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
 			},
 			setup: func() {
+				suite.service = suite.newService(func(cfg *config.OrderConfig) {
+					cfg.Shipping = shippingCatalog
+				})
+
 				tx := &storm.DB{}
 				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.customerService.On("GetCustomerByID", mock.Anything, tx, int64(1)).Return(dto.Customer{ID: 1}, nil)
 				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
-					ID:       int64(1),
-					Name:     "xyz",
-					Price:    10.0,
-					Category: "Premium",
-					Quantity: int64(6),
+					ID: int64(1), Price: money.New(1000, "INR"), Category: "Premium", Quantity: int64(10), AvailableToSell: int64(10),
 				}, nil)
 			},
 			expectedOutput: dto.Order{},
-			expectedErr: apperrors.ProductQuantityInsufficient{
-				ID:                1,
-				QuantityRemaining: 6,
-				QuantityAsked:     8,
-			},
+			expectedErr:    apperrors.ShippingMethodUnknown{Method: "drone"},
 		},
 	}
 
@@ -260,6 +1114,19 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 			suite.Equal(test.expectedOutput.FinalAmount, order.FinalAmount)
 			suite.Equal(test.expectedOutput.Status, order.Status)
 			suite.Equal(test.expectedOutput.DiscountPercentage, order.DiscountPercentage)
+			if test.expectedOutput.ExchangeRate.To != "" {
+				suite.Equal(test.expectedOutput.ExchangeRate, order.ExchangeRate)
+			}
+			if test.expectedOutput.TaxRegion != "" {
+				suite.Equal(test.expectedOutput.TaxAmount, order.TaxAmount)
+				suite.Equal(test.expectedOutput.TaxRegion, order.TaxRegion)
+				suite.Equal(test.expectedOutput.TaxInclusive, order.TaxInclusive)
+			}
+			if test.expectedOutput.ShippingMethod != "" {
+				suite.Equal(test.expectedOutput.ShippingMethod, order.ShippingMethod)
+				suite.Equal(test.expectedOutput.ShippingAmount, order.ShippingAmount)
+				suite.Equal(test.expectedOutput.ShippingAddress, order.ShippingAddress)
+			}
 		})
 		suite.TearDownTest()
 	}
@@ -268,6 +1135,7 @@ func (suite *OrderServiceTestSuite) TestCreateOrder() {
 func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 	type testCaseStruct struct {
 		name           string
+		actor          auth.Principal
 		input          dto.UpdateOrderStatusRequest
 		setup          func()
 		expectedOutput dto.Order
@@ -276,12 +1144,15 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 
 	now = func() time.Time { return time.Date(2023, 05, 18, 00, 00, 00, 00, time.UTC) }
 	timeNow := now()
+	opsActor := auth.Principal{Subject: "warehouse", Role: auth.RoleOps}
 	testCases := []testCaseStruct{
 		{
-			name: "Success",
+			name:  "Success",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: 1,
 				Status:  "Dispatched",
+				Reason:  "picked up by courier",
 			},
 			setup: func() {
 				tx := &storm.DB{}
@@ -289,18 +1160,46 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil).Once()
 				suite.orderRepo.On("UpdateOrderStatus", mock.Anything, mock.Anything, int64(1), "Dispatched").Return(nil)
+				suite.orderEventsRepo.On("CreateOrderStatusEvent", mock.Anything, mock.Anything, repository.OrderStatusEvent{
+					OrderID:    int64(1),
+					FromStatus: "Placed",
+					ToStatus:   "Dispatched",
+					Actor:      "warehouse",
+					ActorRole:  "ops",
+					Reason:     "picked up by courier",
+				}).Return(repository.OrderStatusEvent{ID: 1}, nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderStatusChanged, int64(1), dto.OrderStatusChangedEvent{
+					OrderID:    int64(1),
+					FromStatus: "Placed",
+					ToStatus:   "Dispatched",
+					Actor:      "warehouse",
+					ActorRole:  "ops",
+					Reason:     "picked up by courier",
+				}).Return(nil).Once()
+				suite.productService.On("CommitReservations", mock.Anything, tx, int64(1)).Return([]dto.StockAdjustedEvent{
+					{ProductID: int64(1), Delta: int64(-2), Quantity: int64(8), Reason: "sale", OrderID: int64(1)},
+				}, nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), dto.StockAdjustedEvent{
+					ProductID: int64(1),
+					Delta:     int64(-2),
+					Quantity:  int64(8),
+					Reason:    "sale",
+					OrderID:   int64(1),
+				}).Return(nil).Once()
 				suite.orderRepo.On("UpdateOrderDispatchDate", mock.Anything, mock.Anything, int64(1), timeNow).Return(nil)
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Dispatched",
 					DispatchedAt:       timeNow,
 				}, nil).NotBefore()
@@ -308,15 +1207,59 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			expectedOutput: dto.Order{
 				ID:                 int64(1),
 				Products:           []dto.ProductInfo{},
-				Amount:             20.0,
+				Amount:             money.New(2000, "INR"),
 				DiscountPercentage: 0.0,
-				FinalAmount:        20.0,
+				FinalAmount:        money.New(2000, "INR"),
 				Status:             "Dispatched",
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Success When Order Cancelled",
+			name:  "Success When Order Cancelled",
+			actor: opsActor,
+			input: dto.UpdateOrderStatusRequest{
+				OrderID: 1,
+				Status:  "Cancelled",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:                 uint(1),
+					Currency:           "INR",
+					Amount:             2000,
+					DiscountPercentage: 0.0,
+					FinalAmount:        2000,
+					Status:             "Placed",
+				}, nil).Once()
+				suite.orderRepo.On("UpdateOrderStatus", mock.Anything, mock.Anything, int64(1), "Cancelled").Return(nil)
+				suite.orderEventsRepo.On("CreateOrderStatusEvent", mock.Anything, mock.Anything, mock.Anything).Return(repository.OrderStatusEvent{ID: 1}, nil)
+				suite.productService.On("ReleaseReservations", mock.Anything, tx, int64(1)).Return(true, nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderStatusChanged, int64(1), mock.Anything).Return(nil).Once()
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:                 uint(1),
+					Currency:           "INR",
+					Amount:             2000,
+					DiscountPercentage: 0.0,
+					FinalAmount:        2000,
+					Status:             "Cancelled",
+					DispatchedAt:       timeNow,
+				}, nil).NotBefore()
+			},
+			expectedOutput: dto.Order{
+				ID:                 int64(1),
+				Products:           []dto.ProductInfo{},
+				Amount:             money.New(2000, "INR"),
+				DiscountPercentage: 0.0,
+				FinalAmount:        money.New(2000, "INR"),
+				Status:             "Cancelled",
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Success When Order Placed Before Reservations Cancelled",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: 1,
 				Status:  "Cancelled",
@@ -327,12 +1270,15 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil).Once()
 				suite.orderRepo.On("UpdateOrderStatus", mock.Anything, mock.Anything, int64(1), "Cancelled").Return(nil)
+				suite.orderEventsRepo.On("CreateOrderStatusEvent", mock.Anything, mock.Anything, mock.Anything).Return(repository.OrderStatusEvent{ID: 1}, nil)
+				suite.productService.On("ReleaseReservations", mock.Anything, tx, int64(1)).Return(false, nil)
 				suite.orderItemRepo.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderItem{
 					{
 						ID:        uint(1),
@@ -341,19 +1287,25 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 						Quantity:  2,
 					},
 				}, nil).Once()
-				suite.productService.On("GetProductByID", mock.Anything, tx, int64(1)).Return(dto.Product{
-					ID:       int64(1),
-					Name:     "xyz",
-					Price:    10.0,
-					Category: "Premium",
-					Quantity: int64(10),
+				suite.productService.On("AdjustStock", mock.Anything, tx, []dto.StockAdjustment{
+					{ProductID: int64(1), Delta: int64(2), Reason: "cancel", OrderID: int64(1)},
+				}).Return([]dto.StockAdjustedEvent{
+					{ProductID: int64(1), Delta: int64(2), Quantity: int64(12), Reason: "cancel", OrderID: int64(1)},
 				}, nil)
-				suite.productService.On("UpdateProductQuantity", mock.Anything, tx, map[int64]int64{1: 12}).Return(nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.OrderStatusChanged, int64(1), mock.Anything).Return(nil).Once()
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), dto.StockAdjustedEvent{
+					ProductID: int64(1),
+					Delta:     int64(2),
+					Quantity:  int64(12),
+					Reason:    "cancel",
+					OrderID:   int64(1),
+				}).Return(nil).Once()
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Cancelled",
 					DispatchedAt:       timeNow,
 				}, nil).NotBefore()
@@ -361,15 +1313,16 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			expectedOutput: dto.Order{
 				ID:                 int64(1),
 				Products:           []dto.ProductInfo{},
-				Amount:             20.0,
+				Amount:             money.New(2000, "INR"),
 				DiscountPercentage: 0.0,
-				FinalAmount:        20.0,
+				FinalAmount:        money.New(2000, "INR"),
 				Status:             "Cancelled",
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Failed Because Order Status Invalid ",
+			name:  "Failed Because Order Status Invalid ",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: int64(1),
 				Status:  "test",
@@ -383,7 +1336,8 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			expectedErr:    apperrors.OrderStatusInvalid{ID: int64(1)},
 		},
 		{
-			name: "Failed Because Order Updation Invalid ",
+			name:  "Failed Because Order Updation Invalid ",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: int64(1),
 				Status:  "Completed",
@@ -394,9 +1348,10 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil)
 			},
@@ -408,7 +1363,8 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			},
 		},
 		{
-			name: "Failed Because Order Not Found ",
+			name:  "Failed Because Order Not Found ",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: int64(1),
 				Status:  "Completed",
@@ -423,7 +1379,8 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			expectedErr:    apperrors.OrderNotFound{ID: int64(1)},
 		},
 		{
-			name: "Failed Because Order Updation Failed",
+			name:  "Failed Because Order Updation Failed",
+			actor: opsActor,
 			input: dto.UpdateOrderStatusRequest{
 				OrderID: 1,
 				Status:  "Dispatched",
@@ -434,9 +1391,10 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil).Once()
 				suite.orderRepo.On("UpdateOrderStatus", mock.Anything, mock.Anything, int64(1), "Dispatched").Return(errors.New("something went wrong"))
@@ -444,6 +1402,50 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			expectedOutput: dto.Order{},
 			expectedErr:    fmt.Errorf("error occured while updating order status: %w", errors.New("something went wrong")),
 		},
+		{
+			name:  "Failed Because Customer Cannot Dispatch Orders",
+			actor: auth.Principal{Role: auth.RoleCustomer, CustomerID: 1},
+			input: dto.UpdateOrderStatusRequest{
+				OrderID: 1,
+				Status:  "Dispatched",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr: apperrors.OrderUpdationForbidden{
+				ID:             int64(1),
+				Role:           "customer",
+				RequestedState: "Dispatched",
+			},
+		},
+		{
+			name:  "Failed Because Customer Cancels Order Of Another Customer",
+			actor: auth.Principal{Role: auth.RoleCustomer, CustomerID: 2},
+			input: dto.UpdateOrderStatusRequest{
+				OrderID: 1,
+				Status:  "Cancelled",
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.orderRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil)
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.OrderNotFound{ID: int64(1)},
+		},
 	}
 
 	for _, test := range testCases {
@@ -451,7 +1453,7 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			order, err := suite.service.UpdateOrderStatus(context.Background(), test.input.OrderID, test.input.Status)
+			order, err := suite.service.UpdateOrderStatus(context.Background(), test.actor, test.input.OrderID, test.input.Status, test.input.Reason)
 			suite.Equal(test.expectedErr, err)
 			suite.Equal(test.expectedOutput.Status, order.Status)
 		})
@@ -462,6 +1464,7 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 	type testCaseStruct struct {
 		name           string
+		customerID     int64
 		orderID        int64
 		setup          func()
 		expectedOutput dto.Order
@@ -470,14 +1473,17 @@ func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 
 	testCases := []testCaseStruct{
 		{
-			name:    "Success",
-			orderID: int64(1),
+			name:       "Success",
+			customerID: int64(1),
+			orderID:    int64(1),
 			setup: func() {
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil).Once()
 				suite.orderItemRepo.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderItem{
@@ -492,22 +1498,82 @@ func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 			expectedOutput: dto.Order{
 				ID:                 int64(1),
 				Products:           []dto.ProductInfo{{ProductID: 1, Quantity: 2}},
-				Amount:             20.0,
+				Amount:             money.New(2000, "INR"),
 				DiscountPercentage: 0.0,
-				FinalAmount:        20.0,
+				FinalAmount:        money.New(2000, "INR"),
 				Status:             "Placed",
 			},
 			expectedErr: nil,
 		},
 		{
-			name:    "Fail Because Something Wrong With Fetching OrderItems",
-			orderID: int64(1),
+			name:       "Success With Line Item Breakdown",
+			customerID: int64(1),
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:                 uint(1),
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					Amount:             12000,
+					DiscountPercentage: 10.0,
+					Discounts:          []repository.OrderDiscount{{Promotion: "premium_products", Type: "percentage", Amount: 1200}},
+					TaxAmount:          1944,
+					TaxRegion:          "IN-MH",
+					FinalAmount:        12744,
+					Status:             "Placed",
+				}, nil).Once()
+				suite.orderItemRepo.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderItem{
+					{ID: 1, OrderID: 1, ProductID: 1, ProductName: "Trail Shoe", Category: "Premium", UnitPrice: 1000, Quantity: 2, Discount: 200,
+						TaxRate: 18, Tax: 324},
+					{ID: 2, OrderID: 1, ProductID: 2, ProductName: "Rain Jacket", Category: "Premium", UnitPrice: 5000, Quantity: 2, Discount: 1000,
+						TaxRate: 18, Tax: 1620},
+				}, nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:       int64(1),
+				Products: []dto.ProductInfo{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 2}},
+				Items: []dto.OrderItem{
+					{
+						ProductID:   1,
+						Name:        "Trail Shoe",
+						Category:    "Premium",
+						Price:       money.New(1000, "INR"),
+						Quantity:    2,
+						LineTotal:   money.New(2000, "INR"),
+						Discount:    money.New(200, "INR"),
+						TaxRate:     18,
+						Tax:         money.New(324, "INR"),
+						FinalAmount: money.New(2124, "INR"),
+					},
+					{
+						ProductID:   2,
+						Name:        "Rain Jacket",
+						Category:    "Premium",
+						Price:       money.New(5000, "INR"),
+						Quantity:    2,
+						LineTotal:   money.New(10000, "INR"),
+						Discount:    money.New(1000, "INR"),
+						TaxRate:     18,
+						Tax:         money.New(1620, "INR"),
+						FinalAmount: money.New(10620, "INR"),
+					},
+				},
+				Amount: money.New(12000, "INR"),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "Fail Because Something Wrong With Fetching OrderItems",
+			customerID: int64(1),
+			orderID:    int64(1),
 			setup: func() {
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, nil).Once()
 				suite.orderItemRepo.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderItem{}, errors.New("error fetching data for OrderItems")).Once()
@@ -516,14 +1582,17 @@ func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 			expectedErr:    errors.New("error fetching data for OrderItems"),
 		},
 		{
-			name:    "Fail Because Something Wrong With Fetching Order",
-			orderID: int64(1),
+			name:       "Fail Because Something Wrong With Fetching Order",
+			customerID: int64(1),
+			orderID:    int64(1),
 			setup: func() {
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
 					ID:                 uint(1),
-					Amount:             20.0,
+					CustomerID:         int64(1),
+					Currency:           "INR",
+					Amount:             2000,
 					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
+					FinalAmount:        2000,
 					Status:             "Placed",
 				}, errors.New("error fetching data for Order")).Once()
 			},
@@ -531,14 +1600,51 @@ func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 			expectedErr:    errors.New("error fetching data for Order"),
 		},
 		{
-			name:    "Fail Because Order Not Found",
-			orderID: int64(1),
+			name:       "Fail Because Order Not Found",
+			customerID: int64(1),
+			orderID:    int64(1),
 			setup: func() {
 				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{}, nil).Once()
 			},
 			expectedOutput: dto.Order{},
 			expectedErr:    apperrors.OrderNotFound{ID: 1},
 		},
+		{
+			name:       "Fail Because Order Belongs To Another Customer",
+			customerID: int64(2),
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil).Once()
+			},
+			expectedOutput: dto.Order{},
+			expectedErr:    apperrors.OrderNotFound{ID: 1},
+		},
+		{
+			name:       "Success For Admin Lookup",
+			customerID: AllCustomers,
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:          uint(1),
+					CustomerID:  int64(1),
+					Currency:    "INR",
+					Amount:      2000,
+					FinalAmount: 2000,
+					Status:      "Placed",
+				}, nil).Once()
+				suite.orderItemRepo.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderItem{}, nil).Once()
+			},
+			expectedOutput: dto.Order{
+				ID:       int64(1),
+				Products: []dto.ProductInfo{},
+				Amount:   money.New(2000, "INR"),
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, test := range testCases {
@@ -546,57 +1652,276 @@ func (suite *OrderServiceTestSuite) TestGetOrdeDetails() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			order, err := suite.service.GetOrderDetailsByID(context.Background(), test.orderID)
+			order, err := suite.service.GetOrderDetailsByID(context.Background(), test.customerID, test.orderID)
 			suite.Equal(test.expectedErr, err)
 			suite.Equal(test.expectedOutput.ID, order.ID)
 			suite.Equal(test.expectedOutput.Products, order.Products)
+			suite.Equal(test.expectedOutput.Items, order.Items)
 			suite.Equal(test.expectedOutput.Amount, order.Amount)
 		})
 		suite.TearDownTest()
 	}
 }
 
+func (suite *OrderServiceTestSuite) TestGetOrderHistory() {
+	type testCaseStruct struct {
+		name           string
+		customerID     int64
+		orderID        int64
+		setup          func()
+		expectedOutput dto.OrderHistory
+		expectedErr    error
+	}
+
+	testCases := []testCaseStruct{
+		{
+			name:       "Success",
+			customerID: int64(1),
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Cancelled",
+				}, nil).Once()
+				suite.orderEventsRepo.On("ListOrderStatusEvents", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderStatusEvent{
+					{ID: uint(1), OrderID: 1, FromStatus: "Placed", ToStatus: "Cancelled", Actor: "1", ActorRole: "customer", Reason: "ordered twice"},
+				}, nil).Once()
+			},
+			expectedOutput: dto.OrderHistory{
+				OrderID: int64(1),
+				Status:  "Cancelled",
+				Events: []dto.OrderStatusEvent{
+					{FromStatus: "Placed", ToStatus: "Cancelled", Actor: "1", ActorRole: "customer", Reason: "ordered twice"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "Success Without Transitions",
+			customerID: AllCustomers,
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil).Once()
+				suite.orderEventsRepo.On("ListOrderStatusEvents", mock.Anything, mock.Anything, int64(1)).Return([]repository.OrderStatusEvent{}, nil).Once()
+			},
+			expectedOutput: dto.OrderHistory{
+				OrderID: int64(1),
+				Status:  "Placed",
+				Events:  []dto.OrderStatusEvent{},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "Fail Because Order Belongs To Another Customer",
+			customerID: int64(2),
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil).Once()
+			},
+			expectedOutput: dto.OrderHistory{},
+			expectedErr:    apperrors.OrderNotFound{ID: 1},
+		},
+		{
+			name:       "Fail Because Something Wrong With Fetching Events",
+			customerID: int64(1),
+			orderID:    int64(1),
+			setup: func() {
+				suite.orderRepo.On("GetOrderByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Order{
+					ID:         uint(1),
+					CustomerID: int64(1),
+					Status:     "Placed",
+				}, nil).Once()
+				suite.orderEventsRepo.On("ListOrderStatusEvents", mock.Anything, mock.Anything, int64(1)).Return(nil, errors.New("error fetching order status events")).Once()
+			},
+			expectedOutput: dto.OrderHistory{},
+			expectedErr:    errors.New("error fetching order status events"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			history, err := suite.service.GetOrderHistory(context.Background(), test.customerID, test.orderID)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, history)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *OrderServiceTestSuite) TestListShippingMethods() {
+	freeAbove, freeAboveUSD := money.New(5000, "INR"), money.New(60, "USD")
+	perKg, perKgUSD := money.New(100, "INR"), money.New(1, "USD")
+
+	testCases := []struct {
+		name           string
+		currency       string
+		expectedOutput []dto.ShippingMethod
+		expectedErr    error
+	}{
+		{
+			name: "Success In Catalog Currency",
+			expectedOutput: []dto.ShippingMethod{
+				{Name: "standard", Type: "flat", Amount: money.New(100, "INR"), FreeAbove: &freeAbove, Default: true},
+				{Name: "express", Type: "weight", Amount: money.New(300, "INR"), PerKg: &perKg},
+			},
+		},
+		{
+			name:     "Success Converted Into Currency",
+			currency: "USD",
+			expectedOutput: []dto.ShippingMethod{
+				{Name: "standard", Type: "flat", Amount: money.New(1, "USD"), FreeAbove: &freeAboveUSD, Default: true},
+				{Name: "express", Type: "weight", Amount: money.New(4, "USD"), PerKg: &perKgUSD},
+			},
+		},
+		{
+			name:        "Fail Because Currency Unavailable",
+			currency:    "EUR",
+			expectedErr: apperrors.CurrencyUnavailable{Currency: "EUR"},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			suite.service = suite.newService(func(cfg *config.OrderConfig) {
+				cfg.Shipping = shippingCatalog
+			})
+
+			methods, err := suite.service.ListShippingMethods(context.Background(), test.currency)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, methods)
+		})
+		suite.TearDownTest()
+	}
+}
+
 func (suite *OrderServiceTestSuite) TestListOrders() {
+	minAmount := int64(1000)
+	createdFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	type testCaseStruct struct {
 		name           string
+		input          dto.ListOrdersRequest
 		setup          func()
-		expectedOutput []dto.Order
+		expectedOutput dto.OrderList
 		expectedErr    error
 	}
 
 	testCases := []testCaseStruct{
 		{
 			name: "Success",
+			input: dto.ListOrdersRequest{
+				Statuses:    []string{"Placed", "Dispatched"},
+				CreatedFrom: &createdFrom,
+				Currency:    "INR",
+				MinAmount:   &minAmount,
+				SortBy:      "final_amount",
+				SortDesc:    true,
+				Page:        2,
+				PageSize:    2,
+			},
 			setup: func() {
-				suite.orderRepo.On("ListOrders", mock.Anything, mock.Anything).Return([]repository.Order{
+				filter := repository.OrderFilter{
+					CustomerID:     1,
+					Statuses:       []string{"Placed", "Dispatched"},
+					CreatedFrom:    createdFrom,
+					Currency:       "INR",
+					MinFinalAmount: &minAmount,
+					SortBy:         repository.OrderSortByFinalAmount,
+					SortDesc:       true,
+					Limit:          2,
+					Offset:         2,
+				}
+				suite.orderRepo.On("CountOrders", mock.Anything, mock.Anything, filter).Return(int64(3), nil).Once()
+				suite.orderRepo.On("ListOrders", mock.Anything, mock.Anything, filter).Return([]repository.Order{
 					{
 						ID:                 uint(1),
-						Amount:             20.0,
+						CustomerID:         1,
+						Currency:           "INR",
+						BaseCurrency:       "INR",
+						ExchangeRate:       1,
+						Amount:             2000,
 						DiscountPercentage: 0.0,
-						FinalAmount:        20.0,
+						FinalAmount:        2000,
 						Status:             "Placed",
 					},
 				}, nil).Once()
 			},
-			expectedOutput: []dto.Order{
-				{
-					ID:                 int64(1),
-					Products:           []dto.ProductInfo{},
-					Amount:             20.0,
-					DiscountPercentage: 0.0,
-					FinalAmount:        20.0,
-					Status:             "Cancelled",
+			expectedOutput: dto.OrderList{
+				Orders: []dto.Order{
+					{
+						ID:                 int64(1),
+						CustomerID:         1,
+						Products:           []dto.ProductInfo{},
+						Amount:             money.New(2000, "INR"),
+						DiscountPercentage: 0.0,
+						TaxAmount:          money.New(0, "INR"),
+						ShippingAmount:     money.New(0, "INR"),
+						FinalAmount:        money.New(2000, "INR"),
+						ExchangeRate:       money.Identity("INR"),
+						Status:             "Placed",
+					},
 				},
+				Pagination: dto.Pagination{Page: 2, PageSize: 2, TotalItems: 3, TotalPages: 2},
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Fail Because Something Wrong With Fetching Orders List",
+			name:  "Fail Because Status Filter Invalid",
+			input: dto.ListOrdersRequest{Statuses: []string{"Shipped"}, Page: 1, PageSize: 20},
+			setup: func() {},
+			expectedOutput: dto.OrderList{
+				Orders:     []dto.Order{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 20},
+			},
+			expectedErr: apperrors.OrderListQueryInvalid{Param: "status", Value: "Shipped"},
+		},
+		{
+			name:  "Fail Because Sort Column Invalid",
+			input: dto.ListOrdersRequest{SortBy: "status", Page: 1, PageSize: 20},
+			setup: func() {},
+			expectedOutput: dto.OrderList{
+				Orders:     []dto.Order{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 20},
+			},
+			expectedErr: apperrors.OrderListQueryInvalid{Param: "sort_by", Value: "status"},
+		},
+		{
+			name:  "Fail Because Something Wrong With Counting Orders",
+			input: dto.ListOrdersRequest{Page: 1, PageSize: 20},
+			setup: func() {
+				suite.orderRepo.On("CountOrders", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("error counting Orders")).Once()
+			},
+			expectedOutput: dto.OrderList{
+				Orders:     []dto.Order{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 20},
+			},
+			expectedErr: errors.New("error counting Orders"),
+		},
+		{
+			name:  "Fail Because Something Wrong With Fetching Orders List",
+			input: dto.ListOrdersRequest{Page: 1, PageSize: 20},
 			setup: func() {
-				suite.orderRepo.On("ListOrders", mock.Anything, mock.Anything).Return([]repository.Order{}, errors.New("error fetching data for Orders")).Once()
+				suite.orderRepo.On("CountOrders", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+				suite.orderRepo.On("ListOrders", mock.Anything, mock.Anything, mock.Anything).Return([]repository.Order{}, errors.New("error fetching data for Orders")).Once()
 			},
-			expectedOutput: []dto.Order{},
-			expectedErr:    errors.New("error fetching data for Orders"),
+			expectedOutput: dto.OrderList{
+				Orders:     []dto.Order{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 20, TotalItems: 1, TotalPages: 1},
+			},
+			expectedErr: errors.New("error fetching data for Orders"),
 		},
 	}
 
@@ -605,10 +1930,28 @@ func (suite *OrderServiceTestSuite) TestListOrders() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			orderList, err := suite.service.ListOrders(context.Background())
+			orderList, err := suite.service.ListOrders(context.Background(), int64(1), test.input)
 			suite.Equal(test.expectedErr, err)
-			suite.Equal(len(test.expectedOutput), len(orderList))
+			suite.Equal(test.expectedOutput, orderList)
 		})
 		suite.TearDownTest()
 	}
 }
+
+func (suite *OrderServiceTestSuite) TestUpdateOrderStatusRunsGuards() {
+	errNotPaid := errors.New("order not paid")
+	suite.service.(*service).stateMachine = MustNewStateMachine([]Transition{
+		{From: OrderPlaced, To: "Packed", Roles: staffRoles, Guards: []Guard{
+			func(order repository.Order, actor auth.Principal) error { return errNotPaid },
+		}},
+	})
+
+	tx := &storm.DB{}
+	suite.orderRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+	suite.orderRepo.On("HandleTransaction", mock.Anything, tx, errNotPaid).Return(nil)
+	suite.orderRepo.On("GetOrderByID", mock.Anything, tx, int64(1)).Return(repository.Order{ID: uint(1), Status: "Placed"}, nil)
+
+	_, err := suite.service.UpdateOrderStatus(context.Background(), auth.Principal{Role: auth.RoleOps}, 1, "Packed", "")
+
+	suite.Equal(errNotPaid, err)
+}
@@ -0,0 +1,167 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type OrderStatus string
+
+const (
+	OrderPlaced     OrderStatus = "Placed"
+	OrderDispatched OrderStatus = "Dispatched"
+	OrderCompleted  OrderStatus = "Completed"
+	OrderCancelled  OrderStatus = "Cancelled"
+	OrderReturned   OrderStatus = "Returned"
+)
+
+// Effect names a side effect the order service runs in the transaction of a status update
+type Effect string
+
+const (
+	//EffectRestock returns the ordered quantities to the product stock
+	EffectRestock Effect = "restock"
+	//EffectCommitStock takes the quantities reserved for the order from the product stock
+	EffectCommitStock Effect = "commit_stock"
+	//EffectReleaseStock gives back the quantities reserved for the order
+	EffectReleaseStock Effect = "release_stock"
+	//EffectSetDispatchedAt records when the order left the warehouse
+	EffectSetDispatchedAt Effect = "set_dispatched_at"
+)
+
+var knownEffects = map[Effect]bool{
+	EffectRestock:         true,
+	EffectCommitStock:     true,
+	EffectReleaseStock:    true,
+	EffectSetDispatchedAt: true,
+}
+
+// Guard rejects the transition of a single order by returning an error
+type Guard func(order repository.Order, actor auth.Principal) error
+
+// Transition is an allowed move of an order from one status to another
+type Transition struct {
+	From OrderStatus
+	To   OrderStatus
+	//Roles allowed to request the transition, customers only for their own orders
+	Roles []auth.Role
+	//Guards run before the status is updated, Effects run in order after it is updated
+	Guards  []Guard
+	Effects []Effect
+}
+
+var (
+	staffRoles = []auth.Role{auth.RoleOps, auth.RoleAdmin}
+	allRoles   = []auth.Role{auth.RoleCustomer, auth.RoleOps, auth.RoleAdmin}
+)
+
+// DefaultTransitions is the lifecycle orders follow. New statuses such as Packed are added
+// by listing the transitions into and out of them, the first From is the initial status.
+// Placed orders only reserve their stock, it is taken from the product quantity on dispatch.
+var DefaultTransitions = []Transition{
+	{From: OrderPlaced, To: OrderDispatched, Roles: staffRoles, Effects: []Effect{EffectCommitStock, EffectSetDispatchedAt}},
+	{From: OrderPlaced, To: OrderCancelled, Roles: allRoles, Effects: []Effect{EffectReleaseStock}},
+	{From: OrderDispatched, To: OrderCompleted, Roles: staffRoles},
+	{From: OrderDispatched, To: OrderCancelled, Roles: allRoles, Effects: []Effect{EffectRestock}},
+	{From: OrderCompleted, To: OrderReturned, Roles: staffRoles, Effects: []Effect{EffectRestock}},
+}
+
+// StateMachine validates order status updates against a transition table
+type StateMachine struct {
+	initial     OrderStatus
+	statuses    []OrderStatus
+	transitions map[OrderStatus]map[OrderStatus]Transition
+}
+
+// NewStateMachine builds a state machine from the transitions, orders start in the From status of the first one
+func NewStateMachine(transitions []Transition) (*StateMachine, error) {
+	if len(transitions) == 0 {
+		return nil, fmt.Errorf("order state machine needs at least one transition")
+	}
+
+	sm := &StateMachine{
+		initial:     transitions[0].From,
+		transitions: make(map[OrderStatus]map[OrderStatus]Transition),
+	}
+
+	seen := make(map[OrderStatus]bool)
+	for _, transition := range transitions {
+		if transition.From == "" || transition.To == "" || transition.From == transition.To {
+			return nil, fmt.Errorf("invalid order transition from %q to %q", transition.From, transition.To)
+		}
+
+		if _, ok := sm.transitions[transition.From][transition.To]; ok {
+			return nil, fmt.Errorf("duplicate order transition from %s to %s", transition.From, transition.To)
+		}
+
+		for _, effect := range transition.Effects {
+			if !knownEffects[effect] {
+				return nil, fmt.Errorf("unknown effect %q on order transition from %s to %s", effect, transition.From, transition.To)
+			}
+		}
+
+		for _, status := range []OrderStatus{transition.From, transition.To} {
+			if !seen[status] {
+				seen[status] = true
+				sm.statuses = append(sm.statuses, status)
+			}
+		}
+
+		if sm.transitions[transition.From] == nil {
+			sm.transitions[transition.From] = make(map[OrderStatus]Transition)
+		}
+		sm.transitions[transition.From][transition.To] = transition
+	}
+
+	return sm, nil
+}
+
+// MustNewStateMachine is NewStateMachine for transition tables known to be valid
+func MustNewStateMachine(transitions []Transition) *StateMachine {
+	sm, err := NewStateMachine(transitions)
+	if err != nil {
+		panic(err)
+	}
+
+	return sm
+}
+
+// Initial returns the status of newly placed orders
+func (sm *StateMachine) Initial() OrderStatus {
+	return sm.initial
+}
+
+// Statuses returns every status of the lifecycle in the order they first appear in the table
+func (sm *StateMachine) Statuses() []OrderStatus {
+	return sm.statuses
+}
+
+func (sm *StateMachine) IsStatus(status string) bool {
+	for _, s := range sm.statuses {
+		if string(s) == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition returns the transition from the current to the requested status, ok is false when it is not allowed
+func (sm *StateMachine) Transition(from, to string) (transition Transition, ok bool) {
+	transition, ok = sm.transitions[OrderStatus(from)][OrderStatus(to)]
+	return transition, ok
+}
+
+// CanRequest reports whether the actor may move orders into the status from at least one other status
+func (sm *StateMachine) CanRequest(actor auth.Principal, status string) bool {
+	for _, transitions := range sm.transitions {
+		transition, ok := transitions[OrderStatus(status)]
+		if ok && actor.HasRole(transition.Roles...) {
+			return true
+		}
+	}
+
+	return false
+}
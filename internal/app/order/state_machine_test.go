@@ -0,0 +1,163 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateMachineTransition(t *testing.T) {
+	sm := MustNewStateMachine(DefaultTransitions)
+
+	testCases := []struct {
+		name            string
+		requestedStatus string
+		currentStatus   string
+		expectedOutput  bool
+	}{
+		{
+			name:            "Valid Order Status Request, Dispatch Placed Order",
+			requestedStatus: "Dispatched",
+			currentStatus:   "Placed",
+			expectedOutput:  true,
+		},
+		{
+			name:            "Valid Order Status Request, Cancel Placed Order",
+			requestedStatus: "Cancelled",
+			currentStatus:   "Placed",
+			expectedOutput:  true,
+		},
+		{
+			name:            "Valid Order Status Request, Cancel Dispatched Order",
+			requestedStatus: "Cancelled",
+			currentStatus:   "Dispatched",
+			expectedOutput:  true,
+		},
+		{
+			name:            "Valid Order Status Request, Complete Dispatched Order",
+			requestedStatus: "Completed",
+			currentStatus:   "Dispatched",
+			expectedOutput:  true,
+		},
+		{
+			name:            "Valid Order Status Request, Retun Completed Order",
+			requestedStatus: "Returned",
+			currentStatus:   "Completed",
+			expectedOutput:  true,
+		},
+		{
+			name:            "Incorrect Order Status Request, Cannot jump from Placed to Complete",
+			requestedStatus: "Completed",
+			currentStatus:   "Placed",
+			expectedOutput:  false,
+		},
+		{
+			name:            "Incorrect Order Status Request, Cannot Cancel Completed Order",
+			requestedStatus: "Cancelled",
+			currentStatus:   "Completed",
+			expectedOutput:  false,
+		},
+		{
+			name:            "Incorrect Order Status Request, Cannot Cancel Returned Order",
+			requestedStatus: "Cancelled",
+			currentStatus:   "Returned",
+			expectedOutput:  false,
+		},
+		{
+			name:            "Incorrect Order Status Request, Cannot Place Cancelled Order",
+			requestedStatus: "Placed",
+			currentStatus:   "Cancelled",
+			expectedOutput:  false,
+		},
+		{
+			name:            "Incorrect Order Status Request, Placed order Can't Be Placed Again",
+			requestedStatus: "Placed",
+			currentStatus:   "Placed",
+			expectedOutput:  false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, ok := sm.Transition(test.currentStatus, test.requestedStatus)
+			assert.Equal(t, test.expectedOutput, ok)
+		})
+	}
+}
+
+func TestNewStateMachine(t *testing.T) {
+	testCases := []struct {
+		name        string
+		transitions []Transition
+		expectedErr bool
+	}{
+		{
+			name: "Valid Table With Extra Status",
+			transitions: append([]Transition{
+				{From: OrderPlaced, To: "Packed", Roles: staffRoles},
+				{From: "Packed", To: OrderDispatched, Roles: staffRoles, Effects: []Effect{EffectSetDispatchedAt}},
+			}, DefaultTransitions...),
+		},
+		{
+			name:        "Fail Because Table Empty",
+			expectedErr: true,
+		},
+		{
+			name: "Fail Because Transition Duplicated",
+			transitions: []Transition{
+				{From: OrderPlaced, To: OrderCancelled},
+				{From: OrderPlaced, To: OrderCancelled},
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "Fail Because Transition Loops",
+			transitions: []Transition{{From: OrderPlaced, To: OrderPlaced}},
+			expectedErr: true,
+		},
+		{
+			name:        "Fail Because Effect Unknown",
+			transitions: []Transition{{From: OrderPlaced, To: OrderCancelled, Effects: []Effect{"refund"}}},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewStateMachine(test.transitions)
+			assert.Equal(t, test.expectedErr, err != nil)
+		})
+	}
+}
+
+func TestStateMachineWithPackedStatus(t *testing.T) {
+	errNotPaid := errors.New("order not paid")
+	sm, err := NewStateMachine([]Transition{
+		{From: OrderPlaced, To: "Packed", Roles: staffRoles, Guards: []Guard{
+			func(order repository.Order, actor auth.Principal) error { return errNotPaid },
+		}},
+		{From: "Packed", To: OrderDispatched, Roles: staffRoles, Effects: []Effect{EffectSetDispatchedAt}},
+		{From: OrderPlaced, To: OrderCancelled, Roles: allRoles, Effects: []Effect{EffectRestock}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, OrderPlaced, sm.Initial())
+	assert.Equal(t, []OrderStatus{OrderPlaced, "Packed", OrderDispatched, OrderCancelled}, sm.Statuses())
+	assert.True(t, sm.IsStatus("Packed"))
+	assert.False(t, sm.IsStatus("Returned"))
+
+	_, ok := sm.Transition("Placed", "Dispatched")
+	assert.False(t, ok)
+
+	transition, ok := sm.Transition("Placed", "Packed")
+	require.True(t, ok)
+	assert.Equal(t, errNotPaid, transition.Guards[0](repository.Order{}, auth.Principal{}))
+
+	customer := auth.Principal{Role: auth.RoleCustomer, CustomerID: 1}
+	assert.True(t, sm.CanRequest(customer, "Cancelled"))
+	assert.False(t, sm.CanRequest(customer, "Packed"))
+}
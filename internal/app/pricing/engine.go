@@ -0,0 +1,389 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// Line is an ordered product priced by the engine
+type Line struct {
+	ProductID int64
+	Category  string
+	Price     money.Money
+	Quantity  int64
+}
+
+func (l Line) Total() money.Money {
+	return l.Price.Mul(l.Quantity)
+}
+
+// Quote is the priced order, Discounts break DiscountAmount down by promotion in the order they were applied
+// and LineDiscounts break it down by line, in the order of the priced lines
+type Quote struct {
+	Amount             money.Money
+	Discounts          []dto.AppliedDiscount
+	LineDiscounts      []money.Money
+	DiscountAmount     money.Money
+	DiscountPercentage float64
+	FinalAmount        money.Money
+}
+
+// Coupon is a discount redeemed with its code, taking Percent or Amount off the lines of its Categories,
+// of every line when it has none
+type Coupon struct {
+	Code         string
+	DiscountType string
+	Percent      float64
+	Amount       money.Money
+	Categories   []string
+}
+
+// In returns the coupon taking its amount, converted at the rate, off orders in the currency the rate converts into
+func (c Coupon) In(rate money.Rate) Coupon {
+	if c.DiscountType == constants.PromotionFixed && c.Amount.Currency == rate.From {
+		c.Amount = rate.Convert(c.Amount)
+	}
+
+	return c
+}
+
+// Rule prices the discount a promotion gives on the lines it applies to,
+// the engine caps it at what is left to pay on the order
+type Rule func(promotion config.PromotionConfig, lines []Line) money.Money
+
+// rules prices every promotion type, a new type registers its rule here
+var rules = map[string]Rule{
+	constants.PromotionPercentage: percentageOff,
+	constants.PromotionFixed:      fixedOff,
+	constants.PromotionBuyXGetY:   buyXGetY,
+	constants.PromotionTiered:     tieredPercentageOff,
+}
+
+// Engine applies the promotions to orders by descending priority, promotions of equal priority keep their listed order.
+// Orders are priced in the currency of the engine, which the amounts of the promotions are in.
+type Engine struct {
+	currency   string
+	promotions []config.PromotionConfig
+}
+
+func NewEngine(currency string, promotions []config.PromotionConfig) (*Engine, error) {
+	if !money.IsSupported(currency) {
+		return nil, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	sorted := make([]config.PromotionConfig, len(promotions))
+	copy(sorted, promotions)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+
+	for _, promotion := range sorted {
+		if _, ok := rules[promotion.Type]; !ok {
+			return nil, fmt.Errorf("promotion %s has unsupported type %q", promotion.Name, promotion.Type)
+		}
+	}
+
+	return &Engine{currency: currency, promotions: sorted}, nil
+}
+
+// MustNewEngine is NewEngine for settings already validated with the configuration, it panics on invalid ones
+func MustNewEngine(currency string, promotions []config.PromotionConfig) *Engine {
+	engine, err := NewEngine(currency, promotions)
+	if err != nil {
+		panic(err)
+	}
+
+	return engine
+}
+
+// In returns the engine pricing orders in the currency the rate converts the engine currency into,
+// the amounts of the promotions are converted at the rate
+func (e *Engine) In(rate money.Rate) *Engine {
+	if rate.To == e.currency {
+		return e
+	}
+
+	promotions := make([]config.PromotionConfig, 0, len(e.promotions))
+	for _, promotion := range e.promotions {
+		promotion.MinOrderValue = convertMinorUnits(rate, promotion.MinOrderValue)
+		promotion.Amount = convertMinorUnits(rate, promotion.Amount)
+
+		tiers := make([]config.PromotionTier, 0, len(promotion.Tiers))
+		for _, tier := range promotion.Tiers {
+			tier.MinAmount = convertMinorUnits(rate, tier.MinAmount)
+			tiers = append(tiers, tier)
+		}
+		promotion.Tiers = tiers
+
+		promotions = append(promotions, promotion)
+	}
+
+	return &Engine{currency: rate.To, promotions: promotions}
+}
+
+// Price totals the lines and applies every promotion they qualify for. A promotion which is not stackable
+// is only applied alone, it is skipped once another one applied and no other is applied after it.
+// Lines priced in another currency than the engine fail with CurrencyMismatch.
+func (e *Engine) Price(lines []Line) (Quote, error) {
+	quote := Quote{
+		Amount:         money.New(0, e.currency),
+		Discounts:      make([]dto.AppliedDiscount, 0),
+		LineDiscounts:  make([]money.Money, 0, len(lines)),
+		DiscountAmount: money.New(0, e.currency),
+	}
+
+	for _, line := range lines {
+		if line.Price.Currency != e.currency {
+			return Quote{}, apperrors.CurrencyMismatch{Currency: line.Price.Currency, Expected: e.currency}
+		}
+
+		quote.Amount = quote.Amount.Add(line.Total())
+		quote.LineDiscounts = append(quote.LineDiscounts, money.New(0, e.currency))
+	}
+
+	for _, promotion := range e.promotions {
+		if len(quote.Discounts) > 0 && !promotion.Stackable {
+			continue
+		}
+
+		eligible := eligibleLines(promotion, lines)
+		if !qualifies(promotion, quote.Amount, eligible) {
+			continue
+		}
+
+		//the qualifying lines are discounted, or every line of the order with the order scope
+		discounted := eligible
+		if promotion.DiscountScope == constants.DiscountScopeOrder {
+			discounted = lines
+		}
+		appliesTo := func(line Line) bool {
+			return len(discounted) == len(lines) || line.Category == promotion.Category
+		}
+
+		//discounts never take the order below zero
+		amount := money.Min(rules[promotion.Type](promotion, discounted), quote.Amount.Sub(quote.DiscountAmount))
+		if !amount.IsPositive() {
+			continue
+		}
+
+		quote.addDiscount(dto.AppliedDiscount{
+			Promotion: promotion.Name,
+			Type:      promotion.Type,
+			Amount:    amount,
+		})
+		quote.spreadDiscount(amount, lines, appliesTo)
+
+		if !promotion.Stackable {
+			break
+		}
+	}
+
+	quote.total()
+	return quote, nil
+}
+
+// ApplyCoupon takes the coupon off the lines of its categories after the promotions of the quote,
+// applied is false when no line is eligible for the coupon or a fixed coupon is in another currency than the quote
+func ApplyCoupon(quote Quote, lines []Line, coupon Coupon) (Quote, bool) {
+	if coupon.DiscountType == constants.PromotionFixed && coupon.Amount.Currency != quote.Amount.Currency {
+		return quote, false
+	}
+
+	appliesTo := func(line Line) bool {
+		return len(coupon.Categories) == 0 || containsCategory(coupon.Categories, line.Category)
+	}
+
+	eligible := make([]Line, 0, len(lines))
+	for _, line := range lines {
+		if appliesTo(line) {
+			eligible = append(eligible, line)
+		}
+	}
+
+	if len(eligible) == 0 {
+		return quote, false
+	}
+
+	amount := money.New(0, quote.Amount.Currency)
+	switch coupon.DiscountType {
+	case constants.PromotionPercentage:
+		amount = linesTotal(eligible).Percent(coupon.Percent)
+	case constants.PromotionFixed:
+		amount = money.Min(coupon.Amount, linesTotal(eligible))
+	}
+
+	//discounts never take the order below zero
+	amount = money.Min(amount, quote.Amount.Sub(quote.DiscountAmount))
+	if amount.IsPositive() {
+		//copying the breakdown leaves the quote passed in unchanged
+		quote.Discounts = append(make([]dto.AppliedDiscount, 0, len(quote.Discounts)+1), quote.Discounts...)
+		quote.addDiscount(dto.AppliedDiscount{
+			Promotion: coupon.Code,
+			Type:      constants.DiscountCoupon,
+			Amount:    amount,
+		})
+		quote.spreadDiscount(amount, lines, appliesTo)
+		quote.total()
+	}
+
+	return quote, true
+}
+
+func (q *Quote) addDiscount(discount dto.AppliedDiscount) {
+	q.Discounts = append(q.Discounts, discount)
+	q.DiscountAmount = q.DiscountAmount.Add(discount.Amount)
+}
+
+// spreadDiscount adds the discount to the lines it applies to in proportion to what is left to pay on them.
+// Stacked promotions can take more off lines than is left on them, the rest is spread over the other lines.
+func (q *Quote) spreadDiscount(discount money.Money, lines []Line, appliesTo func(line Line) bool) {
+	eligible := make([]int64, len(lines))
+	others := make([]int64, len(lines))
+	var eligibleLeft int64
+	for i, line := range lines {
+		left := line.Total().MinorUnits - q.LineDiscounts[i].MinorUnits
+		if appliesTo(line) {
+			eligible[i] = left
+			eligibleLeft += left
+		} else {
+			others[i] = left
+		}
+	}
+
+	shares := spreadMinorUnits(discount.MinorUnits, eligible)
+	if discount.MinorUnits > eligibleLeft {
+		shares = eligible
+		for i, share := range spreadMinorUnits(discount.MinorUnits-eligibleLeft, others) {
+			shares[i] += share
+		}
+	}
+
+	//copying the breakdown leaves quotes sharing it unchanged
+	lineDiscounts := make([]money.Money, len(q.LineDiscounts))
+	for i, lineDiscount := range q.LineDiscounts {
+		lineDiscounts[i] = lineDiscount.Add(money.New(shares[i], discount.Currency))
+	}
+
+	q.LineDiscounts = lineDiscounts
+}
+
+// total prices what is left to pay once the discounts are taken off
+func (q *Quote) total() {
+	q.FinalAmount = q.Amount.Sub(q.DiscountAmount)
+	q.DiscountPercentage = q.DiscountAmount.Ratio(q.Amount)
+}
+
+// convertMinorUnits converts minor units of the currency the rate converts from
+func convertMinorUnits(rate money.Rate, minorUnits int64) int64 {
+	return rate.Convert(money.New(minorUnits, rate.From)).MinorUnits
+}
+
+func containsCategory(categories []string, category string) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eligibleLines returns the lines of the promotion category qualifying the order, every line when the promotion has none
+func eligibleLines(promotion config.PromotionConfig, lines []Line) []Line {
+	if promotion.Category == "" {
+		return lines
+	}
+
+	eligible := make([]Line, 0, len(lines))
+	for _, line := range lines {
+		if line.Category == promotion.Category {
+			eligible = append(eligible, line)
+		}
+	}
+
+	return eligible
+}
+
+// qualifies reports whether the order reaches the minimum value and holds enough eligible lines for the promotion
+func qualifies(promotion config.PromotionConfig, orderAmount money.Money, eligible []Line) bool {
+	return len(eligible) > 0 && len(eligible) >= promotion.MinItems && orderAmount.MinorUnits >= promotion.MinOrderValue
+}
+
+// spreadMinorUnits splits minorUnits in proportion to the weights, rounding down every cumulative share
+// so the shares add up to minorUnits. Nothing is spread over weights adding up to zero.
+func spreadMinorUnits(minorUnits int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+
+	var totalWeight int64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return shares
+	}
+
+	var cumulativeWeight, spread int64
+	for i, weight := range weights {
+		cumulativeWeight += weight
+		shares[i] = minorUnits*cumulativeWeight/totalWeight - spread
+		spread += shares[i]
+	}
+
+	return shares
+}
+
+// linesTotal totals lines of one currency, there is at least one
+func linesTotal(lines []Line) money.Money {
+	total := money.New(0, lines[0].Price.Currency)
+	for _, line := range lines {
+		total = total.Add(line.Total())
+	}
+
+	return total
+}
+
+func percentageOff(promotion config.PromotionConfig, lines []Line) money.Money {
+	return linesTotal(lines).Percent(promotion.Percent)
+}
+
+func fixedOff(promotion config.PromotionConfig, lines []Line) money.Money {
+	total := linesTotal(lines)
+	return money.Min(money.New(promotion.Amount, total.Currency), total)
+}
+
+// buyXGetY gives GetQuantity units of a line free for every BuyQuantity units paid, lines are not combined
+func buyXGetY(promotion config.PromotionConfig, lines []Line) money.Money {
+	discount := money.New(0, lines[0].Price.Currency)
+	for _, line := range lines {
+		freeUnits := line.Quantity / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+		discount = discount.Add(line.Price.Mul(freeUnits))
+	}
+
+	return discount
+}
+
+// tieredPercentageOff takes the percent of the highest tier whose minimum the lines reach
+func tieredPercentageOff(promotion config.PromotionConfig, lines []Line) money.Money {
+	total := linesTotal(lines)
+
+	reached := -1
+	for i, tier := range promotion.Tiers {
+		if total.MinorUnits >= tier.MinAmount && (reached < 0 || tier.MinAmount > promotion.Tiers[reached].MinAmount) {
+			reached = i
+		}
+	}
+
+	if reached < 0 {
+		return money.New(0, total.Currency)
+	}
+
+	return total.Percent(promotion.Tiers[reached].Percent)
+}
@@ -0,0 +1,414 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inr(minorUnits int64) money.Money {
+	return money.New(minorUnits, "INR")
+}
+
+func TestEnginePrice(t *testing.T) {
+	premiumLines := []Line{
+		{ProductID: 1, Category: "Premium", Price: inr(5000), Quantity: 1},
+		{ProductID: 2, Category: "Premium", Price: inr(3000), Quantity: 1},
+		{ProductID: 3, Category: "Premium", Price: inr(2000), Quantity: 1},
+	}
+	mixedLines := []Line{
+		{ProductID: 1, Category: "Premium", Price: inr(500000), Quantity: 1},
+		{ProductID: 9, Category: "Budget", Price: inr(80000), Quantity: 5},
+	}
+
+	testCases := []struct {
+		name           string
+		promotions     []config.PromotionConfig
+		lines          []Line
+		expectedOutput Quote
+	}{
+		{
+			name:       "No Discount For Less Than 3 Premium Products",
+			promotions: config.Default().Order.ActivePromotions(),
+			lines:      premiumLines[:2],
+			expectedOutput: Quote{
+				Amount:         inr(8000),
+				Discounts:      []dto.AppliedDiscount{},
+				LineDiscounts:  []money.Money{inr(0), inr(0)},
+				DiscountAmount: inr(0),
+				FinalAmount:    inr(8000),
+			},
+		},
+		{
+			name:       "Discount For 3 Premium Products",
+			promotions: config.Default().Order.ActivePromotions(),
+			lines:      premiumLines,
+			expectedOutput: Quote{
+				Amount:             inr(10000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: inr(1000)}},
+				LineDiscounts:      []money.Money{inr(500), inr(300), inr(200)},
+				DiscountAmount:     inr(1000),
+				DiscountPercentage: 10.0,
+				FinalAmount:        inr(9000),
+			},
+		},
+		{
+			name:       "Discount For 3 Premium Products Off The Whole Order",
+			promotions: config.Default().Order.ActivePromotions(),
+			lines:      append(premiumLines[:3:3], Line{ProductID: 9, Category: "Budget", Price: inr(80000), Quantity: 5}),
+			expectedOutput: Quote{
+				Amount:             inr(410000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: inr(41000)}},
+				LineDiscounts:      []money.Money{inr(500), inr(300), inr(200), inr(40000)},
+				DiscountAmount:     inr(41000),
+				DiscountPercentage: 10.0,
+				FinalAmount:        inr(369000),
+			},
+		},
+		{
+			name: "Percentage Discount Off The Lines Of Its Category",
+			promotions: []config.PromotionConfig{
+				{Name: "premium_lines", Type: "percentage", Category: "Premium", DiscountScope: "lines", Percent: 10},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_lines", Type: "percentage", Amount: inr(50000)}},
+				LineDiscounts:      []money.Money{inr(50000), inr(0)},
+				DiscountAmount:     inr(50000),
+				DiscountPercentage: 5.56,
+				FinalAmount:        inr(850000),
+			},
+		},
+		{
+			name: "Fixed Discount Off A Category Capped At Its Lines",
+			promotions: []config.PromotionConfig{
+				{Name: "budget_flat", Type: "fixed", Category: "Budget", Amount: 500000},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "budget_flat", Type: "fixed", Amount: inr(400000)}},
+				LineDiscounts:      []money.Money{inr(0), inr(400000)},
+				DiscountAmount:     inr(400000),
+				DiscountPercentage: 44.44,
+				FinalAmount:        inr(500000),
+			},
+		},
+		{
+			name: "Buy 2 Get 1 Free",
+			promotions: []config.PromotionConfig{
+				{Name: "budget_3_for_2", Type: "buy_x_get_y", Category: "Budget", BuyQuantity: 2, GetQuantity: 1},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "budget_3_for_2", Type: "buy_x_get_y", Amount: inr(80000)}},
+				LineDiscounts:      []money.Money{inr(0), inr(80000)},
+				DiscountAmount:     inr(80000),
+				DiscountPercentage: 8.89,
+				FinalAmount:        inr(820000),
+			},
+		},
+		{
+			name: "Highest Tier Reached",
+			promotions: []config.PromotionConfig{
+				{Name: "spend_more", Type: "tiered", Tiers: []config.PromotionTier{
+					{MinAmount: 1000000, Percent: 10},
+					{MinAmount: 500000, Percent: 5},
+					{MinAmount: 800000, Percent: 8},
+				}},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "spend_more", Type: "tiered", Amount: inr(72000)}},
+				LineDiscounts:      []money.Money{inr(40000), inr(32000)},
+				DiscountAmount:     inr(72000),
+				DiscountPercentage: 8.0,
+				FinalAmount:        inr(828000),
+			},
+		},
+		{
+			name: "No Discount Below Minimum Order Value",
+			promotions: []config.PromotionConfig{
+				{Name: "big_orders", Type: "percentage", Percent: 5, MinOrderValue: 1000000},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:         inr(900000),
+				Discounts:      []dto.AppliedDiscount{},
+				LineDiscounts:  []money.Money{inr(0), inr(0)},
+				DiscountAmount: inr(0),
+				FinalAmount:    inr(900000),
+			},
+		},
+		{
+			name: "Stackable Promotions Combine By Priority",
+			promotions: []config.PromotionConfig{
+				{Name: "site_wide", Type: "percentage", Percent: 10, Stackable: true},
+				{Name: "budget_3_for_2", Type: "buy_x_get_y", Category: "Budget", BuyQuantity: 2, GetQuantity: 1, Priority: 10, Stackable: true},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount: inr(900000),
+				Discounts: []dto.AppliedDiscount{
+					{Promotion: "budget_3_for_2", Type: "buy_x_get_y", Amount: inr(80000)},
+					{Promotion: "site_wide", Type: "percentage", Amount: inr(90000)},
+				},
+				LineDiscounts:      []money.Money{inr(54878), inr(115122)},
+				DiscountAmount:     inr(170000),
+				DiscountPercentage: 18.89,
+				FinalAmount:        inr(730000),
+			},
+		},
+		{
+			name: "Exclusive Promotion Applies Alone",
+			promotions: []config.PromotionConfig{
+				{Name: "clearance", Type: "percentage", Percent: 20, Priority: 5},
+				{Name: "site_wide", Type: "percentage", Percent: 10, Stackable: true},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "clearance", Type: "percentage", Amount: inr(180000)}},
+				LineDiscounts:      []money.Money{inr(100000), inr(80000)},
+				DiscountAmount:     inr(180000),
+				DiscountPercentage: 20.0,
+				FinalAmount:        inr(720000),
+			},
+		},
+		{
+			name: "Exclusive Promotion Skipped Once Another Applied",
+			promotions: []config.PromotionConfig{
+				{Name: "site_wide", Type: "percentage", Percent: 10, Stackable: true, Priority: 5},
+				{Name: "clearance", Type: "percentage", Percent: 20},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount:             inr(900000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "site_wide", Type: "percentage", Amount: inr(90000)}},
+				LineDiscounts:      []money.Money{inr(50000), inr(40000)},
+				DiscountAmount:     inr(90000),
+				DiscountPercentage: 10.0,
+				FinalAmount:        inr(810000),
+			},
+		},
+		{
+			name: "Percentage Rounded Half Away From Zero To The Paisa",
+			promotions: []config.PromotionConfig{
+				{Name: "odd_percent", Type: "percentage", Percent: 12.5},
+			},
+			lines: []Line{{ProductID: 9, Category: "Budget", Price: inr(1999), Quantity: 1}},
+			expectedOutput: Quote{
+				Amount:             inr(1999),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "odd_percent", Type: "percentage", Amount: inr(250)}},
+				LineDiscounts:      []money.Money{inr(250)},
+				DiscountAmount:     inr(250),
+				DiscountPercentage: 12.51,
+				FinalAmount:        inr(1749),
+			},
+		},
+		{
+			name: "Discounts Never Exceed Order Amount",
+			promotions: []config.PromotionConfig{
+				{Name: "voucher", Type: "fixed", Amount: 850000, Stackable: true, Priority: 1},
+				{Name: "site_wide", Type: "percentage", Percent: 10, Stackable: true},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount: inr(900000),
+				Discounts: []dto.AppliedDiscount{
+					{Promotion: "voucher", Type: "fixed", Amount: inr(850000)},
+					{Promotion: "site_wide", Type: "percentage", Amount: inr(50000)},
+				},
+				LineDiscounts:      []money.Money{inr(500000), inr(400000)},
+				DiscountAmount:     inr(900000),
+				DiscountPercentage: 100.0,
+				FinalAmount:        inr(0),
+			},
+		},
+		{
+			name: "Stacked Discounts Above What Is Left On Their Lines Spread Over The Others",
+			promotions: []config.PromotionConfig{
+				{Name: "budget_flat", Type: "fixed", Category: "Budget", Amount: 400000, Stackable: true, Priority: 1},
+				{Name: "budget_3_for_2", Type: "buy_x_get_y", Category: "Budget", BuyQuantity: 2, GetQuantity: 1, Stackable: true},
+			},
+			lines: mixedLines,
+			expectedOutput: Quote{
+				Amount: inr(900000),
+				Discounts: []dto.AppliedDiscount{
+					{Promotion: "budget_flat", Type: "fixed", Amount: inr(400000)},
+					{Promotion: "budget_3_for_2", Type: "buy_x_get_y", Amount: inr(80000)},
+				},
+				LineDiscounts:      []money.Money{inr(80000), inr(400000)},
+				DiscountAmount:     inr(480000),
+				DiscountPercentage: 53.33,
+				FinalAmount:        inr(420000),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			engine, err := NewEngine("INR", test.promotions)
+			require.NoError(t, err)
+
+			quote, err := engine.Price(test.lines)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, quote)
+		})
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	_, err := NewEngine("INR", []config.PromotionConfig{{Name: "lottery", Type: "lottery"}})
+	assert.EqualError(t, err, `promotion lottery has unsupported type "lottery"`)
+
+	_, err = NewEngine("RUPEE", nil)
+	assert.EqualError(t, err, `unsupported currency "RUPEE"`)
+
+	assert.Panics(t, func() { MustNewEngine("INR", []config.PromotionConfig{{Name: "lottery", Type: "lottery"}}) })
+}
+
+func TestEnginePriceCurrencyMismatch(t *testing.T) {
+	_, err := MustNewEngine("INR", nil).Price([]Line{{ProductID: 1, Price: money.New(1000, "USD"), Quantity: 1}})
+	assert.Equal(t, apperrors.CurrencyMismatch{Currency: "USD", Expected: "INR"}, err)
+}
+
+func TestApplyCoupon(t *testing.T) {
+	lines := []Line{
+		{ProductID: 1, Category: "Premium", Price: inr(5000), Quantity: 1},
+		{ProductID: 2, Category: "Premium", Price: inr(3000), Quantity: 1},
+		{ProductID: 3, Category: "Premium", Price: inr(2000), Quantity: 1},
+		{ProductID: 9, Category: "Budget", Price: inr(1000), Quantity: 2},
+	}
+	engine := MustNewEngine("INR", config.Default().Order.ActivePromotions())
+
+	testCases := []struct {
+		name            string
+		coupon          Coupon
+		lines           []Line
+		expectedApplied bool
+		expectedOutput  Quote
+	}{
+		{
+			name:            "Percentage Coupon Off Its Categories After Promotions",
+			coupon:          Coupon{Code: "BUDGET50", DiscountType: "percentage", Percent: 50.0, Categories: []string{"Budget"}},
+			lines:           lines,
+			expectedApplied: true,
+			expectedOutput: Quote{
+				Amount: inr(12000),
+				Discounts: []dto.AppliedDiscount{
+					{Promotion: "premium_products", Type: "percentage", Amount: inr(1200)},
+					{Promotion: "BUDGET50", Type: "coupon", Amount: inr(1000)},
+				},
+				LineDiscounts:      []money.Money{inr(500), inr(300), inr(200), inr(1200)},
+				DiscountAmount:     inr(2200),
+				DiscountPercentage: 18.33,
+				FinalAmount:        inr(9800),
+			},
+		},
+		{
+			name:            "Fixed Coupon Capped At What Is Left To Pay",
+			coupon:          Coupon{Code: "BIGGIFT", DiscountType: "fixed", Amount: inr(50000)},
+			lines:           lines[:3],
+			expectedApplied: true,
+			expectedOutput: Quote{
+				Amount: inr(10000),
+				Discounts: []dto.AppliedDiscount{
+					{Promotion: "premium_products", Type: "percentage", Amount: inr(1000)},
+					{Promotion: "BIGGIFT", Type: "coupon", Amount: inr(9000)},
+				},
+				LineDiscounts:      []money.Money{inr(5000), inr(3000), inr(2000)},
+				DiscountAmount:     inr(10000),
+				DiscountPercentage: 100.0,
+				FinalAmount:        inr(0),
+			},
+		},
+		{
+			name:            "Fixed Coupon In Another Currency Not Applied",
+			coupon:          Coupon{Code: "DOLLARS", DiscountType: "fixed", Amount: money.New(500, "USD")},
+			lines:           lines[:3],
+			expectedApplied: false,
+			expectedOutput: Quote{
+				Amount:             inr(10000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: inr(1000)}},
+				LineDiscounts:      []money.Money{inr(500), inr(300), inr(200)},
+				DiscountAmount:     inr(1000),
+				DiscountPercentage: 10.0,
+				FinalAmount:        inr(9000),
+			},
+		},
+		{
+			name:            "Not Applied Without Eligible Products",
+			coupon:          Coupon{Code: "BUDGET50", DiscountType: "percentage", Percent: 50.0, Categories: []string{"Budget"}},
+			lines:           lines[:3],
+			expectedApplied: false,
+			expectedOutput: Quote{
+				Amount:             inr(10000),
+				Discounts:          []dto.AppliedDiscount{{Promotion: "premium_products", Type: "percentage", Amount: inr(1000)}},
+				LineDiscounts:      []money.Money{inr(500), inr(300), inr(200)},
+				DiscountAmount:     inr(1000),
+				DiscountPercentage: 10.0,
+				FinalAmount:        inr(9000),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			quote, err := engine.Price(test.lines)
+			require.NoError(t, err)
+
+			quote, applied := ApplyCoupon(quote, test.lines, test.coupon)
+			assert.Equal(t, test.expectedApplied, applied)
+			assert.Equal(t, test.expectedOutput, quote)
+		})
+	}
+}
+
+func TestEngineIn(t *testing.T) {
+	engine := MustNewEngine("INR", []config.PromotionConfig{
+		{Name: "budget_flat", Type: "fixed", Category: "Budget", MinOrderValue: 100000, Amount: 50000},
+	})
+	assert.Same(t, engine, engine.In(money.Identity("INR")))
+
+	usd := func(minorUnits int64) money.Money { return money.New(minorUnits, "USD") }
+	dollars := engine.In(money.Rate{From: "INR", To: "USD", Value: 0.012})
+
+	//the minimum order value converts to 12 dollars and the amount off to 6 dollars
+	quote, err := dollars.Price([]Line{{ProductID: 9, Category: "Budget", Price: usd(1000), Quantity: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, Quote{
+		Amount:             usd(2000),
+		Discounts:          []dto.AppliedDiscount{{Promotion: "budget_flat", Type: "fixed", Amount: usd(600)}},
+		LineDiscounts:      []money.Money{usd(600)},
+		DiscountAmount:     usd(600),
+		DiscountPercentage: 30.0,
+		FinalAmount:        usd(1400),
+	}, quote)
+
+	quote, err = dollars.Price([]Line{{ProductID: 9, Category: "Budget", Price: usd(1000), Quantity: 1}})
+	require.NoError(t, err)
+	assert.Empty(t, quote.Discounts)
+
+	_, err = dollars.Price([]Line{{ProductID: 9, Category: "Budget", Price: inr(1000), Quantity: 1}})
+	assert.Equal(t, apperrors.CurrencyMismatch{Currency: "INR", Expected: "USD"}, err)
+}
+
+func TestCouponIn(t *testing.T) {
+	rate := money.Rate{From: "INR", To: "USD", Value: 0.012}
+
+	fixed := Coupon{Code: "GIFT500", DiscountType: "fixed", Amount: inr(50000)}
+	assert.Equal(t, money.New(600, "USD"), fixed.In(rate).Amount)
+	assert.Equal(t, inr(50000), fixed.Amount)
+
+	percentage := Coupon{Code: "WELCOME10", DiscountType: "percentage", Percent: 10.0}
+	assert.Equal(t, percentage, percentage.In(rate))
+}
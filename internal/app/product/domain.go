@@ -1,7 +1,11 @@
 package product
 
 import (
+	"sort"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
@@ -13,23 +17,253 @@ const (
 	BudgetProduct  ProductType = "Budget"
 )
 
-func MapRepoObjectToDto(repoObj repository.Product) dto.Product {
+func (pt ProductType) IsValid() bool {
+	switch pt {
+	case PremiumProduct, RegularProduct, BudgetProduct:
+		return true
+	}
+
+	return false
+}
+
+// productSortColumns lists the sort_by values accepted by catalog searches
+var productSortColumns = map[string]string{
+	"id":         repository.ProductSortByID,
+	"name":       repository.ProductSortByName,
+	"price":      repository.ProductSortByPrice,
+	"created_at": repository.ProductSortByCreatedAt,
+}
+
+// mapListProductsRequestToFilter turns a catalog search into a repository filter for the requested page
+func mapListProductsRequestToFilter(req dto.ListProductsRequest) (repository.ProductFilter, error) {
+	filter := repository.ProductFilter{
+		Category:     req.Category,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		NameContains: req.Query,
+		NamePrefix:   req.NamePrefix,
+		SortDesc:     req.SortDesc,
+		Limit:        req.PageSize,
+		Offset:       (req.Page - 1) * req.PageSize,
+	}
+
+	if req.Category != "" && !ProductType(req.Category).IsValid() {
+		return repository.ProductFilter{}, apperrors.ProductListQueryInvalid{Param: "category", Value: req.Category}
+	}
+
+	//stock held by placed orders is not available to sell
+	if req.InStock {
+		filter.InStockAt = now()
+	}
+
+	if req.SortBy != "" {
+		sortColumn, ok := productSortColumns[req.SortBy]
+		if !ok {
+			return repository.ProductFilter{}, apperrors.ProductListQueryInvalid{Param: "sort_by", Value: req.SortBy}
+		}
+		filter.SortBy = sortColumn
+	}
+
+	return filter, nil
+}
+
+// isManualAdjustment reports whether staff may adjust the stock with the line, restocks only add stock
+// and the other ledger reasons are recorded by orders
+func isManualAdjustment(line dto.InventoryAdjustmentLine) bool {
+	switch line.Reason {
+	case repository.StockReasonRestock:
+		return line.Delta > 0
+	case repository.StockReasonCorrection:
+		return true
+	}
+
+	return false
+}
+
+// MapRepoObjectToDto maps the product along with the quantity held by active reservations
+func MapRepoObjectToDto(repoObj repository.Product, reserved int64) dto.Product {
 	return dto.Product{
-		ID:        int64(repoObj.ID),
-		Name:      repoObj.Name,
-		Price:     repoObj.Price,
-		Category:  repoObj.Category,
-		Quantity:  repoObj.Quantity,
-		CreatedAt: repoObj.CreatedAt,
-		UpdatedAt: repoObj.CreatedAt,
+		ID:               int64(repoObj.ID),
+		Name:             repoObj.Name,
+		Price:            money.New(repoObj.Price, repoObj.Currency),
+		Prices:           mapPricesToDto(repoObj.Prices),
+		Category:         repoObj.Category,
+		Quantity:         repoObj.Quantity,
+		Reserved:         reserved,
+		AvailableToSell:  availableToSell(repoObj.Quantity, reserved),
+		ReorderThreshold: repoObj.ReorderThreshold,
+		ReorderQuantity:  repoObj.ReorderQuantity,
+		WeightGrams:      repoObj.WeightGrams,
+		Dimensions:       mapDimensionsToDto(repoObj),
+		Archived:         repoObj.Archived,
+		CreatedAt:        repoObj.CreatedAt,
+		UpdatedAt:        repoObj.UpdatedAt,
+	}
+}
+
+// mapDimensionsToDto returns the dimensions of the product, nil when unknown
+func mapDimensionsToDto(repoObj repository.Product) *dto.Dimensions {
+	dimensions := dto.Dimensions{LengthMM: repoObj.LengthMM, WidthMM: repoObj.WidthMM, HeightMM: repoObj.HeightMM}
+	if dimensions == (dto.Dimensions{}) {
+		return nil
+	}
+
+	return &dimensions
+}
+
+// applyDimensions sets the dimensions of the product, all zeros removing them
+func applyDimensions(productDB repository.Product, dimensions dto.Dimensions) repository.Product {
+	productDB.LengthMM = dimensions.LengthMM
+	productDB.WidthMM = dimensions.WidthMM
+	productDB.HeightMM = dimensions.HeightMM
+	return productDB
+}
+
+// mapPricesToDto returns the prices in other currencies ordered by currency code
+func mapPricesToDto(prices map[string]int64) []money.Money {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	mapped := make([]money.Money, 0, len(prices))
+	for currency, minorUnits := range prices {
+		mapped = append(mapped, money.New(minorUnits, currency))
+	}
+
+	sort.Slice(mapped, func(i, j int) bool {
+		return mapped[i].Currency < mapped[j].Currency
+	})
+
+	return mapped
+}
+
+// mapPricesToRepo keys the prices in other currencies by currency code
+func mapPricesToRepo(prices []money.Money) map[string]int64 {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	mapped := make(map[string]int64, len(prices))
+	for _, price := range prices {
+		mapped[price.Currency] = price.MinorUnits
+	}
+
+	return mapped
+}
+
+// availableToSell is the quantity on hand not held by reservations, never negative
+// even when the quantity was lowered below the reserved units
+func availableToSell(quantity, reserved int64) int64 {
+	if quantity < reserved {
+		return 0
+	}
+
+	return quantity - reserved
+}
+
+// isLowStock reports whether the stock available to sell is at or below the reorder threshold of the product
+func isLowStock(productDB repository.Product, available int64) bool {
+	return productDB.ReorderThreshold > 0 && available <= productDB.ReorderThreshold
+}
+
+// crossedReorderThreshold reports whether the stock available to sell fell to the reorder threshold,
+// a product already low is not reported again until it is restocked above its threshold
+func crossedReorderThreshold(productDB repository.Product, availableBefore, availableAfter int64) bool {
+	return !isLowStock(productDB, availableBefore) && isLowStock(productDB, availableAfter)
+}
+
+func mapLowStockProduct(productDB repository.Product, reserved int64) dto.LowStockProduct {
+	return dto.LowStockProduct{
+		ProductID:        int64(productDB.ID),
+		Name:             productDB.Name,
+		Quantity:         productDB.Quantity,
+		Reserved:         reserved,
+		AvailableToSell:  availableToSell(productDB.Quantity, reserved),
+		ReorderThreshold: productDB.ReorderThreshold,
+		ReorderQuantity:  productDB.ReorderQuantity,
 	}
 }
 
 func MapDtoObjectToRepo(product dto.Product) repository.Product {
 	return repository.Product{
 		Name:     product.Name,
-		Price:    product.Price,
+		Price:    product.Price.MinorUnits,
+		Currency: product.Price.Currency,
 		Category: product.Category,
 		Quantity: product.Quantity,
 	}
 }
+
+func MapCreateRequestToRepo(req dto.CreateProductRequest) repository.Product {
+	productDB := repository.Product{
+		Name:             req.Name,
+		Price:            req.Price.MinorUnits,
+		Currency:         req.Price.Currency,
+		Prices:           mapPricesToRepo(req.Prices),
+		Category:         req.Category,
+		Quantity:         req.Quantity,
+		ReorderThreshold: req.ReorderThreshold,
+		ReorderQuantity:  req.ReorderQuantity,
+		WeightGrams:      req.WeightGrams,
+	}
+
+	if req.Dimensions != nil {
+		productDB = applyDimensions(productDB, *req.Dimensions)
+	}
+
+	return productDB
+}
+
+// applyProductUpdates copies every non-nil field of the request on top of the stored product
+func applyProductUpdates(productDB repository.Product, req dto.UpdateProductRequest) repository.Product {
+	if req.Name != nil {
+		productDB.Name = *req.Name
+	}
+
+	if req.Price != nil {
+		productDB.Price = req.Price.MinorUnits
+		productDB.Currency = req.Price.Currency
+	}
+
+	if req.Prices != nil {
+		productDB.Prices = mapPricesToRepo(*req.Prices)
+	}
+
+	if req.Category != nil {
+		productDB.Category = *req.Category
+	}
+
+	if req.Quantity != nil {
+		productDB.Quantity = *req.Quantity
+	}
+
+	if req.ReorderThreshold != nil {
+		productDB.ReorderThreshold = *req.ReorderThreshold
+	}
+
+	if req.ReorderQuantity != nil {
+		productDB.ReorderQuantity = *req.ReorderQuantity
+	}
+
+	if req.WeightGrams != nil {
+		productDB.WeightGrams = *req.WeightGrams
+	}
+
+	if req.Dimensions != nil {
+		productDB = applyDimensions(productDB, *req.Dimensions)
+	}
+
+	return productDB
+}
+
+func MapStockMovementToDto(movement repository.StockMovement) dto.StockMovement {
+	return dto.StockMovement{
+		ID:            int64(movement.ID),
+		ProductID:     movement.ProductID,
+		Delta:         movement.Delta,
+		QuantityAfter: movement.QuantityAfter,
+		Reason:        movement.Reason,
+		OrderID:       movement.OrderID,
+		CreatedAt:     movement.CreatedAt,
+	}
+}
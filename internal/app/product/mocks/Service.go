@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	dto "github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+
 	mock "github.com/stretchr/testify/mock"
 
 	repository "github.com/sagar23sj/go-ecommerce/internal/repository"
@@ -16,6 +17,120 @@ type Service struct {
 	mock.Mock
 }
 
+// AdjustInventory provides a mock function with given fields: ctx, req
+func (_m *Service) AdjustInventory(ctx context.Context, req dto.InventoryAdjustmentRequest) (dto.InventoryAdjustmentList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.InventoryAdjustmentList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.InventoryAdjustmentRequest) (dto.InventoryAdjustmentList, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.InventoryAdjustmentRequest) dto.InventoryAdjustmentList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.InventoryAdjustmentList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.InventoryAdjustmentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdjustStock provides a mock function with given fields: ctx, tx, adjustments
+func (_m *Service) AdjustStock(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustment) ([]dto.StockAdjustedEvent, error) {
+	ret := _m.Called(ctx, tx, adjustments)
+
+	var r0 []dto.StockAdjustedEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, []dto.StockAdjustment) ([]dto.StockAdjustedEvent, error)); ok {
+		return rf(ctx, tx, adjustments)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, []dto.StockAdjustment) []dto.StockAdjustedEvent); ok {
+		r0 = rf(ctx, tx, adjustments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.StockAdjustedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, []dto.StockAdjustment) error); ok {
+		r1 = rf(ctx, tx, adjustments)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchiveProduct provides a mock function with given fields: ctx, productID
+func (_m *Service) ArchiveProduct(ctx context.Context, productID int64) error {
+	ret := _m.Called(ctx, productID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommitReservations provides a mock function with given fields: ctx, tx, orderID
+func (_m *Service) CommitReservations(ctx context.Context, tx repository.Transaction, orderID int64) ([]dto.StockAdjustedEvent, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 []dto.StockAdjustedEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) ([]dto.StockAdjustedEvent, error)); ok {
+		return rf(ctx, tx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) []dto.StockAdjustedEvent); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.StockAdjustedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProduct provides a mock function with given fields: ctx, productDetails
+func (_m *Service) CreateProduct(ctx context.Context, productDetails dto.CreateProductRequest) (dto.Product, error) {
+	ret := _m.Called(ctx, productDetails)
+
+	var r0 dto.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CreateProductRequest) (dto.Product, error)); ok {
+		return rf(ctx, productDetails)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CreateProductRequest) dto.Product); ok {
+		r0 = rf(ctx, productDetails)
+	} else {
+		r0 = ret.Get(0).(dto.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.CreateProductRequest) error); ok {
+		r1 = rf(ctx, productDetails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductByID provides a mock function with given fields: ctx, tx, productID
 func (_m *Service) GetProductByID(ctx context.Context, tx repository.Transaction, productID int64) (dto.Product, error) {
 	ret := _m.Called(ctx, tx, productID)
@@ -40,21 +155,67 @@ func (_m *Service) GetProductByID(ctx context.Context, tx repository.Transaction
 	return r0, r1
 }
 
-// ListProducts provides a mock function with given fields: ctx
-func (_m *Service) ListProducts(ctx context.Context) ([]dto.Product, error) {
+// ListProducts provides a mock function with given fields: ctx, req
+func (_m *Service) ListProducts(ctx context.Context, req dto.ListProductsRequest) (dto.ProductList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.ProductList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListProductsRequest) (dto.ProductList, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListProductsRequest) dto.ProductList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.ProductList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ListProductsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStockMovements provides a mock function with given fields: ctx, req
+func (_m *Service) ListStockMovements(ctx context.Context, req dto.ListStockMovementsRequest) (dto.StockMovementList, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.StockMovementList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListStockMovementsRequest) (dto.StockMovementList, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListStockMovementsRequest) dto.StockMovementList); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.StockMovementList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ListStockMovementsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LowStockReport provides a mock function with given fields: ctx
+func (_m *Service) LowStockReport(ctx context.Context) (dto.LowStockReport, error) {
 	ret := _m.Called(ctx)
 
-	var r0 []dto.Product
+	var r0 dto.LowStockReport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.Product, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (dto.LowStockReport, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dto.Product); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) dto.LowStockReport); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dto.Product)
-		}
+		r0 = ret.Get(0).(dto.LowStockReport)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -66,13 +227,85 @@ func (_m *Service) ListProducts(ctx context.Context) ([]dto.Product, error) {
 	return r0, r1
 }
 
-// UpdateProductQuantity provides a mock function with given fields: ctx, tx, productsQuantityMap
-func (_m *Service) UpdateProductQuantity(ctx context.Context, tx repository.Transaction, productsQuantityMap map[int64]int64) error {
-	ret := _m.Called(ctx, tx, productsQuantityMap)
+// ReconcileStock provides a mock function with given fields: ctx
+func (_m *Service) ReconcileStock(ctx context.Context) (dto.StockReconciliation, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.StockReconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (dto.StockReconciliation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) dto.StockReconciliation); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.StockReconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: ctx, limit
+func (_m *Service) ReleaseExpiredReservations(ctx context.Context, limit int) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseReservations provides a mock function with given fields: ctx, tx, orderID
+func (_m *Service) ReleaseReservations(ctx context.Context, tx repository.Transaction, orderID int64) (bool, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) (bool, error)); ok {
+		return rf(ctx, tx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) bool); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveStock provides a mock function with given fields: ctx, tx, orderID, products
+func (_m *Service) ReserveStock(ctx context.Context, tx repository.Transaction, orderID int64, products []dto.ProductInfo) error {
+	ret := _m.Called(ctx, tx, orderID, products)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, map[int64]int64) error); ok {
-		r0 = rf(ctx, tx, productsQuantityMap)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, []dto.ProductInfo) error); ok {
+		r0 = rf(ctx, tx, orderID, products)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,6 +313,54 @@ func (_m *Service) UpdateProductQuantity(ctx context.Context, tx repository.Tran
 	return r0
 }
 
+// RestockProduct provides a mock function with given fields: ctx, productID, req
+func (_m *Service) RestockProduct(ctx context.Context, productID int64, req dto.RestockProductRequest) (dto.Product, error) {
+	ret := _m.Called(ctx, productID, req)
+
+	var r0 dto.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.RestockProductRequest) (dto.Product, error)); ok {
+		return rf(ctx, productID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.RestockProductRequest) dto.Product); ok {
+		r0 = rf(ctx, productID, req)
+	} else {
+		r0 = ret.Get(0).(dto.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, dto.RestockProductRequest) error); ok {
+		r1 = rf(ctx, productID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: ctx, productID, productDetails
+func (_m *Service) UpdateProduct(ctx context.Context, productID int64, productDetails dto.UpdateProductRequest) (dto.Product, error) {
+	ret := _m.Called(ctx, productID, productDetails)
+
+	var r0 dto.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.UpdateProductRequest) (dto.Product, error)); ok {
+		return rf(ctx, productID, productDetails)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.UpdateProductRequest) dto.Product); ok {
+		r0 = rf(ctx, productID, productDetails)
+	} else {
+		r0 = ret.Get(0).(dto.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, dto.UpdateProductRequest) error); ok {
+		r1 = rf(ctx, productID, productDetails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,74 @@
+package product
+
+import (
+	"context"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"go.uber.org/zap"
+)
+
+// LowStockNotifier is told when the stock available to sell of a product falls to its reorder threshold.
+// It runs within tx, a notifier failing rejects the stock change along with it.
+type LowStockNotifier interface {
+	Name() string
+	NotifyLowStock(ctx context.Context, tx repository.Transaction, product dto.LowStockProduct) error
+}
+
+// NewLowStockNotifiers builds the notifiers named in the inventory settings, which were validated when loaded
+func NewLowStockNotifiers(cfg config.InventoryConfig, eventSvc event.Service) []LowStockNotifier {
+	notifiers := make([]LowStockNotifier, 0)
+	for _, name := range cfg.NotifierNames() {
+		switch name {
+		case constants.LowStockNotifierLog:
+			notifiers = append(notifiers, NewLogNotifier())
+		case constants.LowStockNotifierEvent:
+			notifiers = append(notifiers, NewEventNotifier(eventSvc))
+		}
+	}
+
+	return notifiers
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a notifier writing a warning to the application log
+func NewLogNotifier() LowStockNotifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Name() string {
+	return constants.LowStockNotifierLog
+}
+
+func (logNotifier) NotifyLowStock(ctx context.Context, tx repository.Transaction, product dto.LowStockProduct) error {
+	logger.Warnw(ctx, "product stock is low",
+		zap.Int64("product_id", product.ProductID),
+		zap.String("name", product.Name),
+		zap.Int64("available_to_sell", product.AvailableToSell),
+		zap.Int64("reorder_threshold", product.ReorderThreshold),
+		zap.Int64("reorder_quantity", product.ReorderQuantity),
+	)
+	return nil
+}
+
+type eventNotifier struct {
+	eventSvc event.Service
+}
+
+// NewEventNotifier returns a notifier publishing a LowStock domain event, delivered once the stock change commits
+func NewEventNotifier(eventSvc event.Service) LowStockNotifier {
+	return &eventNotifier{eventSvc: eventSvc}
+}
+
+func (en *eventNotifier) Name() string {
+	return constants.LowStockNotifierEvent
+}
+
+func (en *eventNotifier) NotifyLowStock(ctx context.Context, tx repository.Transaction, product dto.LowStockProduct) error {
+	return en.eventSvc.Publish(ctx, tx, event.LowStock, product.ProductID, product)
+}
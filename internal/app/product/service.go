@@ -2,58 +2,893 @@ package product
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
+var now = time.Now
+
 type service struct {
-	productRepo repository.ProductStorer
+	productRepo     repository.ProductStorer
+	reservationRepo repository.InventoryReservationStorer
+	movementRepo    repository.StockMovementStorer
+	eventSvc        event.Service
+	notifiers       []LowStockNotifier
+	cfg             config.InventoryConfig
+	//currency the catalog is priced in
+	currency string
 }
 
 type Service interface {
 	GetProductByID(ctx context.Context, tx repository.Transaction, productID int64) (dto.Product, error)
-	ListProducts(ctx context.Context) ([]dto.Product, error)
-	UpdateProductQuantity(ctx context.Context, tx repository.Transaction, productsQuantityMap map[int64]int64) error
+	//ListProducts returns the requested page of the catalog products matching the request filters
+	ListProducts(ctx context.Context, req dto.ListProductsRequest) (dto.ProductList, error)
+	CreateProduct(ctx context.Context, productDetails dto.CreateProductRequest) (dto.Product, error)
+	UpdateProduct(ctx context.Context, productID int64, productDetails dto.UpdateProductRequest) (dto.Product, error)
+	ArchiveProduct(ctx context.Context, productID int64) error
+	//AdjustStock adds the deltas to the stock on hand and records every change in the stock ledger,
+	//an adjustment taking more than the stock on hand fails with ProductQuantityInsufficient
+	AdjustStock(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustment) ([]dto.StockAdjustedEvent, error)
+	//RestockProduct adds newly arrived units to the stock on hand of the product
+	RestockProduct(ctx context.Context, productID int64, req dto.RestockProductRequest) (dto.Product, error)
+	//AdjustInventory applies every line of the request in one transaction, nothing is applied when a line fails
+	AdjustInventory(ctx context.Context, req dto.InventoryAdjustmentRequest) (dto.InventoryAdjustmentList, error)
+	//ListStockMovements returns the requested page of the stock ledger of the product, newest first
+	ListStockMovements(ctx context.Context, req dto.ListStockMovementsRequest) (dto.StockMovementList, error)
+	//ReconcileStock compares the quantity on hand of every product with the sum of its stock ledger
+	ReconcileStock(ctx context.Context) (dto.StockReconciliation, error)
+	//LowStockReport lists the products whose stock available to sell is at or below their reorder threshold
+	LowStockReport(ctx context.Context) (dto.LowStockReport, error)
+	//ReserveStock holds the ordered quantities for the order until the reservation TTL runs out and notifies
+	//the products it leaves low on stock. Quantities beyond the stock available to sell fail with ProductQuantityInsufficient,
+	//the products are locked until the transaction ends so concurrent orders check and reserve their stock one after the other.
+	ReserveStock(ctx context.Context, tx repository.Transaction, orderID int64, products []dto.ProductInfo) error
+	//CommitReservations takes the quantities held for the order from the stock on hand as a sale and returns the adjustments made.
+	//Holds which expired meanwhile are taken only when the stock is still available to sell.
+	CommitReservations(ctx context.Context, tx repository.Transaction, orderID int64) ([]dto.StockAdjustedEvent, error)
+	//ReleaseReservations gives back the active holds of the order, reserved is false for orders
+	//placed before reservations existed which took their stock when placed
+	ReleaseReservations(ctx context.Context, tx repository.Transaction, orderID int64) (reserved bool, err error)
+	//ReleaseExpiredReservations releases at most limit expired holds and returns the number released
+	ReleaseExpiredReservations(ctx context.Context, limit int) (int, error)
 }
 
-func NewService(productRepo repository.ProductStorer) Service {
+func NewService(productRepo repository.ProductStorer, reservationRepo repository.InventoryReservationStorer,
+	movementRepo repository.StockMovementStorer, eventSvc event.Service, notifiers []LowStockNotifier, cfg config.InventoryConfig,
+	currency string) Service {
 	return &service{
-		productRepo: productRepo,
+		productRepo:     productRepo,
+		reservationRepo: reservationRepo,
+		movementRepo:    movementRepo,
+		eventSvc:        eventSvc,
+		notifiers:       notifiers,
+		cfg:             cfg,
+		currency:        currency,
 	}
 }
 
 func (ps *service) GetProductByID(ctx context.Context, tx repository.Transaction, productID int64) (dto.Product, error) {
 	productInfoDB, err := ps.productRepo.GetProductByID(ctx, tx, productID)
 	if err != nil {
-		return dto.Product{}, nil
+		return dto.Product{}, err
 	}
 
 	if productInfoDB.ID == 0 {
 		return dto.Product{}, apperrors.ProductNotFound{ID: productID}
 	}
 
-	productInfo := MapRepoObjectToDto(productInfoDB)
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, []int64{productID}, now())
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	productInfo := MapRepoObjectToDto(productInfoDB, reserved[productID])
 	return productInfo, nil
 }
 
-func (ps *service) ListProducts(ctx context.Context) ([]dto.Product, error) {
-	products := make([]dto.Product, 0)
+func (ps *service) ListProducts(ctx context.Context, req dto.ListProductsRequest) (dto.ProductList, error) {
+	productList := dto.ProductList{
+		Products:   make([]dto.Product, 0),
+		Pagination: dto.NewPagination(req.Page, req.PageSize, 0),
+	}
+
+	//category or sort_by unknown, return error ProductListQueryInvalid
+	filter, err := mapListProductsRequestToFilter(req)
+	if err != nil {
+		return productList, err
+	}
+
+	totalItems, err := ps.productRepo.CountProducts(ctx, nil, filter)
+	if err != nil {
+		return productList, err
+	}
+
+	productList.Pagination = dto.NewPagination(req.Page, req.PageSize, totalItems)
+
+	productsListDB, err := ps.productRepo.SearchProducts(ctx, nil, filter)
+	if err != nil {
+		return productList, err
+	}
+
+	productIDs := make([]int64, 0, len(productsListDB))
+	for _, productInfo := range productsListDB {
+		productIDs = append(productIDs, int64(productInfo.ID))
+	}
 
-	productsListDB, err := ps.productRepo.ListProducts(ctx, nil)
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, nil, productIDs, now())
 	if err != nil {
-		return products, err
+		return productList, err
 	}
 
 	for _, productInfo := range productsListDB {
-		products = append(products, MapRepoObjectToDto(productInfo))
+		productList.Products = append(productList.Products, MapRepoObjectToDto(productInfo, reserved[int64(productInfo.ID)]))
+	}
+
+	return productList, nil
+}
+
+func (ps *service) CreateProduct(ctx context.Context, productDetails dto.CreateProductRequest) (product dto.Product, err error) {
+	//product category invalid, return error ProductCategoryInvalid
+	if !ProductType(productDetails.Category).IsValid() {
+		return dto.Product{}, apperrors.ProductCategoryInvalid{Category: productDetails.Category}
+	}
+
+	//product priced in another currency than the catalog, return error CurrencyMismatch
+	if productDetails.Price.Currency != ps.currency {
+		return dto.Product{}, apperrors.CurrencyMismatch{Currency: productDetails.Price.Currency, Expected: ps.currency}
+	}
+
+	//price in other currencies given in the catalog currency, return error PriceCurrencyRepeated
+	err = ps.checkOtherPrices(productDetails.Prices)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	//initializing database transaction
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	//the product starts empty, its initial quantity is recorded as the first stock movement
+	productRequest := MapCreateRequestToRepo(productDetails)
+	productRequest.Quantity = 0
+
+	productDB, err := ps.productRepo.CreateProduct(ctx, tx, productRequest)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	if productDetails.Quantity > 0 {
+		_, err = ps.recordStockMovement(ctx, tx, productDB, dto.StockAdjustment{
+			ProductID: int64(productDB.ID),
+			Delta:     productDetails.Quantity,
+			Reason:    repository.StockReasonInitial,
+		})
+		if err != nil {
+			return dto.Product{}, err
+		}
+		productDB.Quantity = productDetails.Quantity
+	}
+
+	//new products have no reservations
+	return MapRepoObjectToDto(productDB, 0), nil
+}
+
+func (ps *service) UpdateProduct(ctx context.Context, productID int64, productDetails dto.UpdateProductRequest) (product dto.Product, err error) {
+	//product category invalid, return error ProductCategoryInvalid
+	if productDetails.Category != nil && !ProductType(*productDetails.Category).IsValid() {
+		return dto.Product{}, apperrors.ProductCategoryInvalid{Category: *productDetails.Category}
+	}
+
+	//product priced in another currency than the catalog, return error CurrencyMismatch
+	if productDetails.Price != nil && productDetails.Price.Currency != ps.currency {
+		return dto.Product{}, apperrors.CurrencyMismatch{Currency: productDetails.Price.Currency, Expected: ps.currency}
+	}
+
+	//price in other currencies given in the catalog currency, return error PriceCurrencyRepeated
+	if productDetails.Prices != nil {
+		err = ps.checkOtherPrices(*productDetails.Prices)
+		if err != nil {
+			return dto.Product{}, err
+		}
+	}
+
+	//initializing database transaction
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	//locking the product first, so orders cannot reserve the stock a new quantity takes
+	if productDetails.Quantity != nil {
+		err = ps.productRepo.LockProducts(ctx, tx, []int64{productID})
+		if err != nil {
+			return dto.Product{}, err
+		}
+	}
+
+	productDB, err := ps.productRepo.GetProductByID(ctx, tx, productID)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	//product not found, return error ProductNotFound
+	if productDB.ID == 0 {
+		return dto.Product{}, apperrors.ProductNotFound{ID: productID}
+	}
+
+	//archived products are read only
+	if productDB.Archived {
+		return dto.Product{}, apperrors.ProductArchived{ID: productID}
+	}
+
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, []int64{productID}, now())
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	//a new quantity is a manual correction of the stock on hand, recorded in the stock ledger
+	updatedDB := applyProductUpdates(productDB, productDetails)
+	delta := updatedDB.Quantity - productDB.Quantity
+	updatedDB.Quantity = productDB.Quantity
+
+	//new quantity below the stock held by active reservations, return error ProductQuantityInsufficient
+	available := availableToSell(productDB.Quantity, reserved[productID])
+	if delta < 0 && available+delta < 0 {
+		return dto.Product{}, apperrors.ProductQuantityInsufficient{
+			ID:                productID,
+			QuantityAsked:     -delta,
+			QuantityRemaining: available,
+		}
+	}
+
+	updatedDB, err = ps.productRepo.UpdateProduct(ctx, tx, updatedDB)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	if delta != 0 {
+		adjustment, err := ps.recordStockMovement(ctx, tx, productDB, dto.StockAdjustment{
+			ProductID: productID,
+			Delta:     delta,
+			Reason:    repository.StockReasonCorrection,
+		})
+		if err != nil {
+			return dto.Product{}, err
+		}
+
+		err = ps.publishStockAdjustments(ctx, tx, []dto.StockAdjustedEvent{adjustment})
+		if err != nil {
+			return dto.Product{}, err
+		}
+		updatedDB.Quantity = adjustment.Quantity
+	}
+
+	//a lower quantity or a higher reorder threshold may both leave the product low on stock
+	if !isLowStock(productDB, availableToSell(productDB.Quantity, reserved[productID])) &&
+		isLowStock(updatedDB, availableToSell(updatedDB.Quantity, reserved[productID])) {
+		err = ps.notifyLowStock(ctx, tx, updatedDB, reserved[productID])
+		if err != nil {
+			return dto.Product{}, err
+		}
+	}
+
+	return MapRepoObjectToDto(updatedDB, reserved[productID]), nil
+}
+
+func (ps *service) ArchiveProduct(ctx context.Context, productID int64) (err error) {
+	//initializing database transaction
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return err
 	}
 
-	return products, nil
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	productDB, err := ps.productRepo.GetProductByID(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	//product not found, return error ProductNotFound
+	if productDB.ID == 0 {
+		return apperrors.ProductNotFound{ID: productID}
+	}
+
+	//archiving is idempotent, keep the original archival time
+	if productDB.Archived {
+		return nil
+	}
+
+	return ps.productRepo.ArchiveProduct(ctx, tx, productID, now())
 }
 
-func (ps *service) UpdateProductQuantity(ctx context.Context, tx repository.Transaction, productsQuantityMap map[int64]int64) error {
-	err := ps.productRepo.UpdateProductQuantity(ctx, tx, productsQuantityMap)
-	return err
+func (ps *service) AdjustStock(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustment) ([]dto.StockAdjustedEvent, error) {
+	return ps.adjustStock(ctx, tx, adjustments, false)
+}
+
+func (ps *service) RestockProduct(ctx context.Context, productID int64, req dto.RestockProductRequest) (product dto.Product, err error) {
+	//initializing database transaction
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	adjusted, err := ps.adjustStock(ctx, tx, []dto.StockAdjustment{{
+		ProductID: productID,
+		Delta:     req.Quantity,
+		Reason:    repository.StockReasonRestock,
+	}}, true)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	err = ps.publishStockAdjustments(ctx, tx, adjusted)
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	return ps.GetProductByID(ctx, tx, productID)
+}
+
+func (ps *service) AdjustInventory(ctx context.Context, req dto.InventoryAdjustmentRequest) (adjustmentList dto.InventoryAdjustmentList, err error) {
+	adjustmentList = dto.InventoryAdjustmentList{
+		Adjustments: make([]dto.StockAdjustedEvent, 0),
+	}
+
+	adjustments := make([]dto.StockAdjustment, 0, len(req.Adjustments))
+	for _, line := range req.Adjustments {
+		//reason recorded by orders or restock taking stock, return error StockAdjustmentInvalid
+		if !isManualAdjustment(line) {
+			return adjustmentList, apperrors.StockAdjustmentInvalid{ProductID: line.ProductID, Delta: line.Delta, Reason: line.Reason}
+		}
+
+		adjustments = append(adjustments, dto.StockAdjustment{
+			ProductID: line.ProductID,
+			Delta:     line.Delta,
+			Reason:    line.Reason,
+		})
+	}
+
+	//initializing database transaction
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return adjustmentList, err
+	}
+
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	adjusted, err := ps.adjustStock(ctx, tx, adjustments, true)
+	if err != nil {
+		return adjustmentList, err
+	}
+
+	err = ps.publishStockAdjustments(ctx, tx, adjusted)
+	if err != nil {
+		return adjustmentList, err
+	}
+
+	adjustmentList.Adjustments = adjusted
+	return adjustmentList, nil
+}
+
+// adjustStock applies the adjustments one after the other, so several lines of one product add up.
+// Manual adjustments by staff are refused for archived products and cannot take the stock held by active reservations,
+// stock coming back from orders is always taken.
+func (ps *service) adjustStock(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustment, manual bool) ([]dto.StockAdjustedEvent, error) {
+	adjusted := make([]dto.StockAdjustedEvent, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		//locking the product first, so orders cannot reserve the stock a correction takes
+		if manual && adjustment.Delta < 0 {
+			err := ps.productRepo.LockProducts(ctx, tx, []int64{adjustment.ProductID})
+			if err != nil {
+				return adjusted, err
+			}
+		}
+
+		productDB, err := ps.productRepo.GetProductByID(ctx, tx, adjustment.ProductID)
+		if err != nil {
+			return adjusted, err
+		}
+
+		//product not found, return error ProductNotFound
+		if productDB.ID == 0 {
+			return adjusted, apperrors.ProductNotFound{ID: adjustment.ProductID}
+		}
+
+		//archived products are read only
+		if manual && productDB.Archived {
+			return adjusted, apperrors.ProductArchived{ID: adjustment.ProductID}
+		}
+
+		//taking more than the stock on hand, return error ProductQuantityInsufficient
+		if productDB.Quantity+adjustment.Delta < 0 {
+			return adjusted, apperrors.ProductQuantityInsufficient{
+				ID:                adjustment.ProductID,
+				QuantityAsked:     -adjustment.Delta,
+				QuantityRemaining: productDB.Quantity,
+			}
+		}
+
+		//taking stock held by active reservations, return error ProductQuantityInsufficient
+		if manual && adjustment.Delta < 0 {
+			reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, []int64{adjustment.ProductID}, now())
+			if err != nil {
+				return adjusted, err
+			}
+
+			available := availableToSell(productDB.Quantity, reserved[adjustment.ProductID])
+			if available+adjustment.Delta < 0 {
+				return adjusted, apperrors.ProductQuantityInsufficient{
+					ID:                adjustment.ProductID,
+					QuantityAsked:     -adjustment.Delta,
+					QuantityRemaining: available,
+				}
+			}
+		}
+
+		stockAdjusted, err := ps.recordStockMovement(ctx, tx, productDB, adjustment)
+		if err != nil {
+			return adjusted, err
+		}
+
+		if adjustment.Delta < 0 {
+			err = ps.checkLowStock(ctx, tx, productDB, stockAdjusted.Quantity)
+			if err != nil {
+				return adjusted, err
+			}
+		}
+
+		adjusted = append(adjusted, stockAdjusted)
+	}
+
+	return adjusted, nil
+}
+
+func (ps *service) publishStockAdjustments(ctx context.Context, tx repository.Transaction, adjustments []dto.StockAdjustedEvent) error {
+	for _, adjustment := range adjustments {
+		err := ps.eventSvc.Publish(ctx, tx, event.StockAdjusted, adjustment.ProductID, adjustment)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLowStock notifies the product when lowering its quantity on hand to quantityAfter
+// takes its stock available to sell to the reorder threshold
+func (ps *service) checkLowStock(ctx context.Context, tx repository.Transaction, productDB repository.Product, quantityAfter int64) error {
+	if productDB.ReorderThreshold == 0 {
+		return nil
+	}
+
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, []int64{int64(productDB.ID)}, now())
+	if err != nil {
+		return err
+	}
+
+	productReserved := reserved[int64(productDB.ID)]
+	if !crossedReorderThreshold(productDB, availableToSell(productDB.Quantity, productReserved), availableToSell(quantityAfter, productReserved)) {
+		return nil
+	}
+
+	productDB.Quantity = quantityAfter
+	return ps.notifyLowStock(ctx, tx, productDB, productReserved)
+}
+
+func (ps *service) notifyLowStock(ctx context.Context, tx repository.Transaction, productDB repository.Product, reserved int64) error {
+	lowStock := mapLowStockProduct(productDB, reserved)
+	for _, notifier := range ps.notifiers {
+		err := notifier.NotifyLowStock(ctx, tx, lowStock)
+		if err != nil {
+			return fmt.Errorf("error occured while notifying low stock through %s: %w", notifier.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// recordStockMovement adds the delta to the stock on hand of the product and appends the change to the stock ledger
+func (ps *service) recordStockMovement(ctx context.Context, tx repository.Transaction, productDB repository.Product, adjustment dto.StockAdjustment) (dto.StockAdjustedEvent, error) {
+	adjustedDB, err := ps.productRepo.AdjustProductQuantity(ctx, tx, adjustment.ProductID, adjustment.Delta)
+	if err != nil {
+		return dto.StockAdjustedEvent{}, err
+	}
+
+	//stock taken by a concurrent adjustment, return error ProductQuantityInsufficient
+	if adjustedDB.ID == 0 {
+		return dto.StockAdjustedEvent{}, apperrors.ProductQuantityInsufficient{
+			ID:                adjustment.ProductID,
+			QuantityAsked:     -adjustment.Delta,
+			QuantityRemaining: productDB.Quantity,
+		}
+	}
+
+	_, err = ps.movementRepo.CreateStockMovement(ctx, tx, repository.StockMovement{
+		ProductID:     adjustment.ProductID,
+		Delta:         adjustment.Delta,
+		QuantityAfter: adjustedDB.Quantity,
+		Reason:        adjustment.Reason,
+		OrderID:       adjustment.OrderID,
+	})
+	if err != nil {
+		return dto.StockAdjustedEvent{}, err
+	}
+
+	return dto.StockAdjustedEvent{
+		ProductID: adjustment.ProductID,
+		Delta:     adjustment.Delta,
+		Quantity:  adjustedDB.Quantity,
+		Reason:    adjustment.Reason,
+		OrderID:   adjustment.OrderID,
+	}, nil
+}
+
+func (ps *service) ListStockMovements(ctx context.Context, req dto.ListStockMovementsRequest) (dto.StockMovementList, error) {
+	movementList := dto.StockMovementList{
+		Movements:  make([]dto.StockMovement, 0),
+		Pagination: dto.NewPagination(req.Page, req.PageSize, 0),
+	}
+
+	productDB, err := ps.productRepo.GetProductByID(ctx, nil, req.ProductID)
+	if err != nil {
+		return movementList, err
+	}
+
+	//product not found, return error ProductNotFound
+	if productDB.ID == 0 {
+		return movementList, apperrors.ProductNotFound{ID: req.ProductID}
+	}
+
+	totalItems, err := ps.movementRepo.CountStockMovements(ctx, nil, req.ProductID)
+	if err != nil {
+		return movementList, err
+	}
+
+	movementList.Pagination = dto.NewPagination(req.Page, req.PageSize, totalItems)
+
+	movementsDB, err := ps.movementRepo.ListStockMovements(ctx, nil, req.ProductID, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		return movementList, err
+	}
+
+	for _, movement := range movementsDB {
+		movementList.Movements = append(movementList.Movements, MapStockMovementToDto(movement))
+	}
+
+	return movementList, nil
+}
+
+func (ps *service) ReconcileStock(ctx context.Context) (reconciliation dto.StockReconciliation, err error) {
+	reconciliation = dto.StockReconciliation{
+		Mismatches: make([]dto.StockMismatch, 0),
+	}
+
+	//reading both sides in one transaction compares them at the same point in time
+	tx, err := ps.productRepo.BeginTx(ctx)
+	if err != nil {
+		return reconciliation, err
+	}
+
+	defer func() {
+		txErr := ps.productRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	quantities, err := ps.productRepo.ListProductQuantities(ctx, tx)
+	if err != nil {
+		return reconciliation, err
+	}
+
+	ledgerQuantities, err := ps.movementRepo.SumStockMovements(ctx, tx)
+	if err != nil {
+		return reconciliation, err
+	}
+
+	reconciliation.CheckedProducts = len(quantities)
+	for productID, quantity := range quantities {
+		if quantity != ledgerQuantities[productID] {
+			reconciliation.Mismatches = append(reconciliation.Mismatches, dto.StockMismatch{
+				ProductID:      productID,
+				Quantity:       quantity,
+				LedgerQuantity: ledgerQuantities[productID],
+			})
+		}
+	}
+
+	sort.Slice(reconciliation.Mismatches, func(i, j int) bool {
+		return reconciliation.Mismatches[i].ProductID < reconciliation.Mismatches[j].ProductID
+	})
+
+	return reconciliation, nil
+}
+
+func (ps *service) LowStockReport(ctx context.Context) (dto.LowStockReport, error) {
+	report := dto.LowStockReport{
+		Products: make([]dto.LowStockProduct, 0),
+	}
+
+	productsDB, err := ps.productRepo.ListReorderProducts(ctx, nil)
+	if err != nil {
+		return report, err
+	}
+
+	productIDs := make([]int64, 0, len(productsDB))
+	for _, productDB := range productsDB {
+		productIDs = append(productIDs, int64(productDB.ID))
+	}
+
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, nil, productIDs, now())
+	if err != nil {
+		return report, err
+	}
+
+	for _, productDB := range productsDB {
+		productReserved := reserved[int64(productDB.ID)]
+		if isLowStock(productDB, availableToSell(productDB.Quantity, productReserved)) {
+			report.Products = append(report.Products, mapLowStockProduct(productDB, productReserved))
+		}
+	}
+
+	return report, nil
+}
+
+func (ps *service) ReserveStock(ctx context.Context, tx repository.Transaction, orderID int64, products []dto.ProductInfo) error {
+	expiresAt := now().Add(ps.cfg.ReservationTTL)
+
+	productIDs := make([]int64, 0, len(products))
+	ordered := make(map[int64]int64)
+	reservations := make([]repository.InventoryReservation, 0, len(products))
+	for _, p := range products {
+		if _, ok := ordered[p.ProductID]; !ok {
+			productIDs = append(productIDs, p.ProductID)
+		}
+		ordered[p.ProductID] += p.Quantity
+
+		reservations = append(reservations, repository.InventoryReservation{
+			OrderID:   orderID,
+			ProductID: p.ProductID,
+			Quantity:  p.Quantity,
+			Status:    repository.ReservationActive,
+			ExpiresAt: expiresAt,
+		})
+	}
+
+	//the stock is only checked under the product locks, the holds of concurrent orders are visible by now
+	err := ps.productRepo.LockProducts(ctx, tx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, productIDs, now())
+	if err != nil {
+		return err
+	}
+
+	productsDB := make([]repository.Product, 0, len(productIDs))
+	for _, productID := range productIDs {
+		productDB, err := ps.productRepo.GetProductByID(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+
+		//stock not held by other orders insufficient, return error ProductQuantityInsufficient
+		available := availableToSell(productDB.Quantity, reserved[productID])
+		if available < ordered[productID] {
+			return apperrors.ProductQuantityInsufficient{
+				ID:                productID,
+				QuantityAsked:     ordered[productID],
+				QuantityRemaining: available,
+			}
+		}
+
+		productsDB = append(productsDB, productDB)
+	}
+
+	err = ps.reservationRepo.CreateReservations(ctx, tx, reservations)
+	if err != nil {
+		return err
+	}
+
+	//the order takes stock available to sell, which may leave its products low
+	for _, productDB := range productsDB {
+		productID := int64(productDB.ID)
+		availableBefore := availableToSell(productDB.Quantity, reserved[productID])
+		availableAfter := availableToSell(productDB.Quantity, reserved[productID]+ordered[productID])
+		if !crossedReorderThreshold(productDB, availableBefore, availableAfter) {
+			continue
+		}
+
+		err = ps.notifyLowStock(ctx, tx, productDB, reserved[productID]+ordered[productID])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ps *service) CommitReservations(ctx context.Context, tx repository.Transaction, orderID int64) ([]dto.StockAdjustedEvent, error) {
+	adjustments := make([]dto.StockAdjustedEvent, 0)
+
+	reservations, err := ps.reservationRepo.ListReservationsByOrderID(ctx, tx, orderID)
+	if err != nil {
+		return adjustments, err
+	}
+
+	//holds the sweeper released once they expired are committed too, if the stock is still available
+	reservationIDs := make([]int64, 0)
+	toCommit := make([]repository.InventoryReservation, 0)
+	for _, reservation := range reservations {
+		if reservation.Status == repository.ReservationCommitted {
+			continue
+		}
+
+		reservationIDs = append(reservationIDs, int64(reservation.ID))
+		toCommit = append(toCommit, reservation)
+	}
+
+	if len(toCommit) == 0 {
+		return adjustments, nil
+	}
+
+	//committed holds no longer count as reserved, the stock left must cover the rest of the active holds
+	err = ps.reservationRepo.UpdateReservationStatus(ctx, tx, reservationIDs, repository.ReservationCommitted)
+	if err != nil {
+		return adjustments, err
+	}
+
+	for _, reservation := range toCommit {
+		productDB, err := ps.productRepo.GetProductByID(ctx, tx, reservation.ProductID)
+		if err != nil {
+			return adjustments, err
+		}
+
+		if productDB.ID == 0 {
+			return adjustments, apperrors.ProductNotFound{ID: reservation.ProductID}
+		}
+
+		reserved, err := ps.reservationRepo.GetReservedQuantities(ctx, tx, []int64{reservation.ProductID}, now())
+		if err != nil {
+			return adjustments, err
+		}
+
+		//stock sold to other orders after the hold expired, return error ProductQuantityInsufficient
+		available := availableToSell(productDB.Quantity, reserved[reservation.ProductID])
+		if available < reservation.Quantity {
+			return adjustments, apperrors.ProductQuantityInsufficient{
+				ID:                reservation.ProductID,
+				QuantityAsked:     reservation.Quantity,
+				QuantityRemaining: available,
+			}
+		}
+
+		adjustment, err := ps.recordStockMovement(ctx, tx, productDB, dto.StockAdjustment{
+			ProductID: reservation.ProductID,
+			Delta:     -reservation.Quantity,
+			Reason:    repository.StockReasonSale,
+			OrderID:   orderID,
+		})
+		if err != nil {
+			return adjustments, err
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	return adjustments, nil
+}
+
+func (ps *service) ReleaseReservations(ctx context.Context, tx repository.Transaction, orderID int64) (reserved bool, err error) {
+	reservations, err := ps.reservationRepo.ListReservationsByOrderID(ctx, tx, orderID)
+	if err != nil {
+		return false, err
+	}
+
+	reservationIDs := make([]int64, 0)
+	for _, reservation := range reservations {
+		if reservation.Status == repository.ReservationActive {
+			reservationIDs = append(reservationIDs, int64(reservation.ID))
+		}
+	}
+
+	err = ps.reservationRepo.UpdateReservationStatus(ctx, tx, reservationIDs, repository.ReservationReleased)
+	if err != nil {
+		return false, err
+	}
+
+	return len(reservations) > 0, nil
+}
+
+func (ps *service) ReleaseExpiredReservations(ctx context.Context, limit int) (released int, err error) {
+	//initializing database transaction
+	tx, err := ps.reservationRepo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		txErr := ps.reservationRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	reservations, err := ps.reservationRepo.ListExpiredReservations(ctx, tx, now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	reservationIDs := make([]int64, 0, len(reservations))
+	for _, reservation := range reservations {
+		reservationIDs = append(reservationIDs, int64(reservation.ID))
+	}
+
+	err = ps.reservationRepo.UpdateReservationStatus(ctx, tx, reservationIDs, repository.ReservationReleased)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(reservations), nil
+}
+
+// checkOtherPrices rejects prices in other currencies which are in the catalog currency
+func (ps *service) checkOtherPrices(prices []money.Money) error {
+	for _, price := range prices {
+		if price.Currency == ps.currency {
+			return apperrors.PriceCurrencyRepeated{Currency: ps.currency}
+		}
+	}
+
+	return nil
 }
@@ -4,9 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	eventMock "github.com/sagar23sj/go-ecommerce/internal/app/event/mocks"
+	productMock "github.com/sagar23sj/go-ecommerce/internal/app/product/mocks"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
 	"github.com/stretchr/testify/mock"
@@ -15,8 +22,12 @@ import (
 
 type ProductServiceTestSuite struct {
 	suite.Suite
-	service     Service
-	productRepo *mocks.ProductStorer
+	service         Service
+	productRepo     *mocks.ProductStorer
+	reservationRepo *mocks.InventoryReservationStorer
+	movementRepo    *mocks.StockMovementStorer
+	eventService    *eventMock.Service
+	notifier        *productMock.LowStockNotifier
 }
 
 func TestProductServiceTestSuite(t *testing.T) {
@@ -26,13 +37,22 @@ func TestProductServiceTestSuite(t *testing.T) {
 // this function executes before the test suite begins execution
 func (suite *ProductServiceTestSuite) SetupTest() {
 	suite.productRepo = &mocks.ProductStorer{}
+	suite.reservationRepo = &mocks.InventoryReservationStorer{}
+	suite.movementRepo = &mocks.StockMovementStorer{}
+	suite.eventService = &eventMock.Service{}
+	suite.notifier = &productMock.LowStockNotifier{}
 
-	suite.service = NewService(suite.productRepo)
+	suite.service = NewService(suite.productRepo, suite.reservationRepo, suite.movementRepo, suite.eventService,
+		[]LowStockNotifier{suite.notifier}, config.Default().Inventory, "INR")
 }
 
 // this function executes after all tests executed
 func (suite *ProductServiceTestSuite) TearDownTest() {
 	suite.productRepo.AssertExpectations(suite.T())
+	suite.reservationRepo.AssertExpectations(suite.T())
+	suite.movementRepo.AssertExpectations(suite.T())
+	suite.eventService.AssertExpectations(suite.T())
+	suite.notifier.AssertExpectations(suite.T())
 }
 
 func (suite *ProductServiceTestSuite) TestGetProductByID() {
@@ -52,16 +72,20 @@ func (suite *ProductServiceTestSuite) TestGetProductByID() {
 					ID:       1,
 					Name:     "XYZ",
 					Category: "Premium",
-					Price:    100.0,
+					Price:    10000,
+					Currency: "INR",
 					Quantity: 10,
 				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, mock.Anything, []int64{1}, mock.Anything).Return(map[int64]int64{1: 4}, nil)
 			},
 			expectedOutput: dto.Product{
-				ID:       1,
-				Name:     "XYZ",
-				Category: "Premium",
-				Price:    100.0,
-				Quantity: 10,
+				ID:              1,
+				Name:            "XYZ",
+				Category:        "Premium",
+				Price:           money.New(10000, "INR"),
+				Quantity:        10,
+				Reserved:        4,
+				AvailableToSell: 6,
 			},
 			expectedErr: nil,
 		},
@@ -74,6 +98,15 @@ func (suite *ProductServiceTestSuite) TestGetProductByID() {
 			expectedOutput: dto.Product{},
 			expectedErr:    apperrors.ProductNotFound{ID: 1},
 		},
+		{
+			name:  "Fail Because Product Could Not Be Read",
+			input: 1,
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Product{}, errors.New("database is locked"))
+			},
+			expectedOutput: dto.Product{},
+			expectedErr:    errors.New("database is locked"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -88,46 +121,343 @@ func (suite *ProductServiceTestSuite) TestGetProductByID() {
 			suite.Equal(test.expectedOutput.Category, product.Category)
 			suite.Equal(test.expectedOutput.Price, product.Price)
 			suite.Equal(test.expectedOutput.Quantity, product.Quantity)
+			suite.Equal(test.expectedOutput.Reserved, product.Reserved)
+			suite.Equal(test.expectedOutput.AvailableToSell, product.AvailableToSell)
 		})
 		suite.TearDownTest()
 	}
 }
 
 func (suite *ProductServiceTestSuite) TestListProducts() {
+	now = func() time.Time { return time.Date(2023, 05, 18, 00, 00, 00, 00, time.UTC) }
+	defer func() { now = time.Now }()
+	timeNow := now()
+	maxPrice := int64(50000)
 
 	testCases := []struct {
 		name           string
+		input          dto.ListProductsRequest
 		setup          func()
-		expectedOutput []dto.Product
+		expectedOutput dto.ProductList
 		expectedErr    error
 	}{
 		{
 			name: "Success",
+			input: dto.ListProductsRequest{
+				Category: "Premium",
+				MaxPrice: &maxPrice,
+				InStock:  true,
+				Query:    "xy",
+				SortBy:   "price",
+				SortDesc: true,
+				Page:     1,
+				PageSize: 10,
+			},
 			setup: func() {
-				suite.productRepo.On("ListProducts", mock.Anything, mock.Anything).Return([]repository.Product{{
+				filter := repository.ProductFilter{
+					Category:     "Premium",
+					MaxPrice:     &maxPrice,
+					InStockAt:    timeNow,
+					NameContains: "xy",
+					SortBy:       repository.ProductSortByPrice,
+					SortDesc:     true,
+					Limit:        10,
+					Offset:       0,
+				}
+				suite.productRepo.On("CountProducts", mock.Anything, mock.Anything, filter).Return(int64(1), nil)
+				suite.productRepo.On("SearchProducts", mock.Anything, mock.Anything, filter).Return([]repository.Product{{
 					ID:       1,
 					Name:     "XYZ",
 					Category: "Premium",
-					Price:    100.0,
+					Price:    10000,
+					Currency: "INR",
 					Quantity: 10,
 				},
 				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, mock.Anything, []int64{1}, mock.Anything).Return(map[int64]int64{}, nil)
+			},
+			expectedOutput: dto.ProductList{
+				Products: []dto.Product{{
+					ID:              1,
+					Name:            "XYZ",
+					Category:        "Premium",
+					Price:           money.New(10000, "INR"),
+					Quantity:        10,
+					AvailableToSell: 10,
+				}},
+				Pagination: dto.Pagination{Page: 1, PageSize: 10, TotalItems: 1, TotalPages: 1},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Fail Because Category Invalid",
+			input: dto.ListProductsRequest{Category: "Luxury", Page: 1, PageSize: 10},
+			setup: func() {},
+			expectedOutput: dto.ProductList{
+				Products:   []dto.Product{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 10},
+			},
+			expectedErr: apperrors.ProductListQueryInvalid{Param: "category", Value: "Luxury"},
+		},
+		{
+			name:  "Fail Because Sort Column Invalid",
+			input: dto.ListProductsRequest{SortBy: "quantity", Page: 1, PageSize: 10},
+			setup: func() {},
+			expectedOutput: dto.ProductList{
+				Products:   []dto.Product{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 10},
+			},
+			expectedErr: apperrors.ProductListQueryInvalid{Param: "sort_by", Value: "quantity"},
+		},
+		{
+			name:  "Fail Because DB Query Failed",
+			input: dto.ListProductsRequest{Page: 1, PageSize: 10},
+			setup: func() {
+				suite.productRepo.On("CountProducts", mock.Anything, mock.Anything, mock.Anything).Return(int64(2), nil)
+				suite.productRepo.On("SearchProducts", mock.Anything, mock.Anything, mock.Anything).Return([]repository.Product{}, errors.New("Something went wrong in db"))
+			},
+			expectedOutput: dto.ProductList{
+				Products:   []dto.Product{},
+				Pagination: dto.Pagination{Page: 1, PageSize: 10, TotalItems: 2, TotalPages: 1},
+			},
+			expectedErr: errors.New("Something went wrong in db"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			productList, err := suite.service.ListProducts(context.Background(), test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, productList)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestAdjustStock() {
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name           string
+		input          []dto.StockAdjustment
+		setup          func()
+		expectedOutput []dto.StockAdjustedEvent
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			input: []dto.StockAdjustment{
+				{ProductID: 1, Delta: 2, Reason: repository.StockReasonCancel, OrderID: 7},
+				{ProductID: 2, Delta: -5, Reason: repository.StockReasonCorrection},
+			},
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 10}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(2)).Return(repository.Product{ID: 1, Quantity: 12}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 1, Delta: 2, QuantityAfter: 12, Reason: repository.StockReasonCancel, OrderID: 7,
+				}).Return(repository.StockMovement{ID: 1}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 5}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(2), int64(-5)).Return(repository.Product{ID: 2, Quantity: 0}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 2, Delta: -5, QuantityAfter: 0, Reason: repository.StockReasonCorrection,
+				}).Return(repository.StockMovement{ID: 2}, nil).Once()
+			},
+			expectedOutput: []dto.StockAdjustedEvent{
+				{ProductID: 1, Delta: 2, Quantity: 12, Reason: repository.StockReasonCancel, OrderID: 7},
+				{ProductID: 2, Delta: -5, Quantity: 0, Reason: repository.StockReasonCorrection},
+			},
+		},
+		{
+			name:  "Fail Because Product Not Found",
+			input: []dto.StockAdjustment{{ProductID: 1, Delta: 2, Reason: repository.StockReasonCancel}},
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{}, nil).Once()
+			},
+			expectedOutput: []dto.StockAdjustedEvent{},
+			expectedErr:    apperrors.ProductNotFound{ID: 1},
+		},
+		{
+			name:  "Fail Because Product Quantity Insufficient",
+			input: []dto.StockAdjustment{{ProductID: 1, Delta: -3, Reason: repository.StockReasonCorrection}},
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+			},
+			expectedOutput: []dto.StockAdjustedEvent{},
+			expectedErr:    apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 3, QuantityRemaining: 2},
+		},
+		{
+			name:  "Fail Because Stock Taken Concurrently",
+			input: []dto.StockAdjustment{{ProductID: 1, Delta: -2, Reason: repository.StockReasonCorrection}},
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(-2)).Return(repository.Product{}, nil).Once()
+			},
+			expectedOutput: []dto.StockAdjustedEvent{},
+			expectedErr:    apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 2, QuantityRemaining: 2},
+		},
+		{
+			name:  "Fail Because DB Query Failed",
+			input: []dto.StockAdjustment{{ProductID: 1, Delta: 2, Reason: repository.StockReasonCancel}},
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(2)).Return(repository.Product{}, errors.New("Something went wrong in db")).Once()
+			},
+			expectedOutput: []dto.StockAdjustedEvent{},
+			expectedErr:    errors.New("Something went wrong in db"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			adjusted, err := suite.service.AdjustStock(context.Background(), tx, test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, adjusted)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestCreateProduct() {
+
+	testCases := []struct {
+		name           string
+		input          dto.CreateProductRequest
+		setup          func()
+		expectedOutput dto.Product
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Quantity: 10,
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("CreateProduct", mock.Anything, tx, repository.Product{
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    10000,
+					Currency: "INR",
+				}).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    10000,
+					Currency: "INR",
+				}, nil)
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(10)).Return(repository.Product{ID: 1, Quantity: 10}, nil)
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 1, Delta: 10, QuantityAfter: 10, Reason: repository.StockReasonInitial,
+				}).Return(repository.StockMovement{ID: 1}, nil)
 			},
-			expectedOutput: []dto.Product{{
+			expectedOutput: dto.Product{
 				ID:       1,
 				Name:     "XYZ",
 				Category: "Premium",
-				Price:    100.0,
+				Price:    money.New(10000, "INR"),
 				Quantity: 10,
-			}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Product Category Invalid",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Luxury",
+				Price:    money.New(10000, "INR"),
+				Quantity: 10,
+			},
+			setup:          func() {},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductCategoryInvalid{Category: "Luxury"},
+		},
+		{
+			name: "Fail Because Price In Another Currency",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "USD"),
+				Quantity: 10,
+			},
+			setup:          func() {},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.CurrencyMismatch{Currency: "USD", Expected: "INR"},
+		},
+		{
+			name: "Success With Prices In Other Currencies",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Prices:   []money.Money{money.New(150, "USD"), money.New(130, "EUR")},
+			},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("CreateProduct", mock.Anything, tx, repository.Product{
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    10000,
+					Currency: "INR",
+					Prices:   map[string]int64{"USD": 150, "EUR": 130},
+				}).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    10000,
+					Currency: "INR",
+					Prices:   map[string]int64{"USD": 150, "EUR": 130},
+				}, nil)
+			},
+			expectedOutput: dto.Product{
+				ID:       1,
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Prices:   []money.Money{money.New(130, "EUR"), money.New(150, "USD")},
+			},
 			expectedErr: nil,
 		},
+		{
+			name: "Fail Because Other Price In Catalog Currency",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(10000, "INR"),
+				Prices:   []money.Money{money.New(9000, "INR")},
+				Quantity: 10,
+			},
+			setup:          func() {},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.PriceCurrencyRepeated{Currency: "INR"},
+		},
 		{
 			name: "Fail Because DB Query Failed",
+			input: dto.CreateProductRequest{
+				Name:     "XYZ",
+				Category: "Budget",
+				Price:    money.New(10000, "INR"),
+				Quantity: 10,
+			},
 			setup: func() {
-				suite.productRepo.On("ListProducts", mock.Anything, mock.Anything).Return([]repository.Product{}, errors.New("Something went wrong in db"))
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("CreateProduct", mock.Anything, tx, mock.Anything).Return(repository.Product{}, errors.New("Something went wrong in db"))
 			},
-			expectedOutput: []dto.Product{},
+			expectedOutput: dto.Product{},
 			expectedErr:    errors.New("Something went wrong in db"),
 		},
 	}
@@ -137,37 +467,824 @@ func (suite *ProductServiceTestSuite) TestListProducts() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			product, err := suite.service.ListProducts(context.Background())
+			product, err := suite.service.CreateProduct(context.Background(), test.input)
 			suite.Equal(test.expectedErr, err)
-			suite.Equal(len(test.expectedOutput), len(product))
+			suite.Equal(test.expectedOutput.ID, product.ID)
+			suite.Equal(test.expectedOutput.Name, product.Name)
+			suite.Equal(test.expectedOutput.Category, product.Category)
+			suite.Equal(test.expectedOutput.Quantity, product.Quantity)
+			suite.Equal(test.expectedOutput.Prices, product.Prices)
 		})
 		suite.TearDownTest()
 	}
 }
 
-func (suite *ProductServiceTestSuite) TestUpdateProductQuantity() {
+func (suite *ProductServiceTestSuite) TestUpdateProduct() {
+	price := money.New(15000, "INR")
+	category := "Luxury"
+	quantity := int64(4)
+	threshold := int64(8)
+	weight := int64(850)
 
 	testCases := []struct {
-		name        string
-		input       map[int64]int64
-		setup       func()
-		expectedErr error
+		name           string
+		input          dto.UpdateProductRequest
+		setup          func()
+		expectedOutput dto.Product
+		expectedErr    error
 	}{
 		{
 			name:  "Success",
-			input: map[int64]int64{},
+			input: dto.UpdateProductRequest{Price: &price},
 			setup: func() {
-				suite.productRepo.On("UpdateProductQuantity", mock.Anything, mock.Anything, map[int64]int64{1: 2}).Return(nil)
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    10000,
+					Currency: "INR",
+					Quantity: 10,
+				}, nil)
+				suite.productRepo.On("UpdateProduct", mock.Anything, tx, repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    15000,
+					Currency: "INR",
+					Quantity: 10,
+				}).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Category: "Premium",
+					Price:    15000,
+					Currency: "INR",
+					Quantity: 10,
+				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{}, nil)
+			},
+			expectedOutput: dto.Product{
+				ID:       1,
+				Name:     "XYZ",
+				Category: "Premium",
+				Price:    money.New(15000, "INR"),
+				Quantity: 10,
 			},
 			expectedErr: nil,
 		},
 		{
-			name:  "Fail Because DB Query Failed",
-			input: map[int64]int64{1: 2},
+			name:  "Success When Quantity Corrected",
+			input: dto.UpdateProductRequest{Quantity: &quantity},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{1}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Price:    10000,
+					Currency: "INR",
+					Quantity: 10,
+				}, nil)
+				suite.productRepo.On("UpdateProduct", mock.Anything, tx, repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Price:    10000,
+					Currency: "INR",
+					Quantity: 10,
+				}).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Price:    10000,
+					Currency: "INR",
+					Quantity: 10,
+				}, nil)
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(-6)).Return(repository.Product{ID: 1, Quantity: 4}, nil)
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 1, Delta: -6, QuantityAfter: 4, Reason: repository.StockReasonCorrection,
+				}).Return(repository.StockMovement{ID: 1}, nil)
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), dto.StockAdjustedEvent{
+					ProductID: 1, Delta: -6, Quantity: 4, Reason: repository.StockReasonCorrection,
+				}).Return(nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{}, nil)
+			},
+			expectedOutput: dto.Product{
+				ID:       1,
+				Name:     "XYZ",
+				Price:    money.New(10000, "INR"),
+				Quantity: 4,
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Fail Because Quantity Below Reserved Stock",
+			input: dto.UpdateProductRequest{Quantity: &quantity},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{1}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Price:    10000,
+					Currency: "INR",
+					Quantity: 10,
+				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 8}, nil)
+			},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 6, QuantityRemaining: 2},
+		},
+		{
+			name:  "Success When Weight And Dimensions Set",
+			input: dto.UpdateProductRequest{WeightGrams: &weight, Dimensions: &dto.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 120}},
 			setup: func() {
-				suite.productRepo.On("UpdateProductQuantity", mock.Anything, mock.Anything, map[int64]int64{1: 2}).Return(errors.New("Something went wrong in db"))
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Quantity: 10,
+				}, nil)
+				suite.productRepo.On("UpdateProduct", mock.Anything, tx, repository.Product{
+					ID:          1,
+					Name:        "XYZ",
+					Quantity:    10,
+					WeightGrams: 850,
+					LengthMM:    300,
+					WidthMM:     200,
+					HeightMM:    120,
+				}).Return(repository.Product{
+					ID:          1,
+					Name:        "XYZ",
+					Quantity:    10,
+					WeightGrams: 850,
+					LengthMM:    300,
+					WidthMM:     200,
+					HeightMM:    120,
+				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{}, nil)
 			},
-			expectedErr: errors.New("Something went wrong in db"),
+			expectedOutput: dto.Product{
+				ID:          1,
+				Name:        "XYZ",
+				Quantity:    10,
+				WeightGrams: 850,
+				Dimensions:  &dto.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 120},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Success And Notifies Low Stock When Threshold Raised",
+			input: dto.UpdateProductRequest{ReorderThreshold: &threshold},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:               1,
+					Name:             "XYZ",
+					Quantity:         10,
+					ReorderThreshold: 2,
+				}, nil)
+				suite.productRepo.On("UpdateProduct", mock.Anything, tx, repository.Product{
+					ID:               1,
+					Name:             "XYZ",
+					Quantity:         10,
+					ReorderThreshold: 8,
+				}).Return(repository.Product{
+					ID:               1,
+					Name:             "XYZ",
+					Quantity:         10,
+					ReorderThreshold: 8,
+				}, nil)
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 3}, nil)
+				suite.notifier.On("NotifyLowStock", mock.Anything, tx, dto.LowStockProduct{
+					ProductID: 1, Name: "XYZ", Quantity: 10, Reserved: 3, AvailableToSell: 7, ReorderThreshold: 8,
+				}).Return(nil).Once()
+			},
+			expectedOutput: dto.Product{
+				ID:       1,
+				Name:     "XYZ",
+				Quantity: 10,
+			},
+			expectedErr: nil,
+		},
+		{
+			name:           "Fail Because Product Category Invalid",
+			input:          dto.UpdateProductRequest{Category: &category},
+			setup:          func() {},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductCategoryInvalid{Category: "Luxury"},
+		},
+		{
+			name:  "Fail Because Product Not Found",
+			input: dto.UpdateProductRequest{Price: &price},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{}, nil)
+			},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductNotFound{ID: 1},
+		},
+		{
+			name:  "Fail Because Product Archived",
+			input: dto.UpdateProductRequest{Price: &price},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID:       1,
+					Name:     "XYZ",
+					Archived: true,
+				}, nil)
+			},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductArchived{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			product, err := suite.service.UpdateProduct(context.Background(), 1, test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput.ID, product.ID)
+			suite.Equal(test.expectedOutput.Price, product.Price)
+			suite.Equal(test.expectedOutput.Quantity, product.Quantity)
+			suite.Equal(test.expectedOutput.WeightGrams, product.WeightGrams)
+			suite.Equal(test.expectedOutput.Dimensions, product.Dimensions)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestArchiveProduct() {
+	now = func() time.Time { return time.Date(2023, 05, 18, 00, 00, 00, 00, time.UTC) }
+	timeNow := now()
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1}, nil)
+				suite.productRepo.On("ArchiveProduct", mock.Anything, tx, int64(1), timeNow).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success When Product Already Archived",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Archived: true}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Fail Because Product Not Found",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{}, nil)
+			},
+			expectedErr: apperrors.ProductNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			err := suite.service.ArchiveProduct(context.Background(), 1)
+			suite.Equal(test.expectedErr, err)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestReserveStock() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	testCases := []struct {
+		name        string
+		setup       func(tx repository.Transaction)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func(tx repository.Transaction) {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 20}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(4)).Return(repository.Product{ID: 4, Quantity: 20}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2, 4}, timeNow).Return(map[int64]int64{}, nil).Once()
+			},
+		},
+		{
+			name: "Success And Notifies Product Falling To Reorder Threshold",
+			setup: func(tx repository.Transaction) {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{
+					ID: 2, Name: "XYZ", Quantity: 10, ReorderThreshold: 5, ReorderQuantity: 20,
+				}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(4)).Return(repository.Product{ID: 4, Quantity: 20}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2, 4}, timeNow).Return(map[int64]int64{2: 2}, nil).Once()
+				suite.notifier.On("NotifyLowStock", mock.Anything, tx, dto.LowStockProduct{
+					ProductID: 2, Name: "XYZ", Quantity: 10, Reserved: 5, AvailableToSell: 5, ReorderThreshold: 5, ReorderQuantity: 20,
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "Success Without Notifying Product Already Low",
+			setup: func(tx repository.Transaction) {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{
+					ID: 2, Quantity: 10, ReorderThreshold: 5,
+				}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(4)).Return(repository.Product{ID: 4, Quantity: 20}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2, 4}, timeNow).Return(map[int64]int64{2: 6}, nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Notifier Failed",
+			setup: func(tx repository.Transaction) {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{
+					ID: 2, Quantity: 7, ReorderThreshold: 5,
+				}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(4)).Return(repository.Product{ID: 4, Quantity: 20}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2, 4}, timeNow).Return(map[int64]int64{}, nil).Once()
+				suite.notifier.On("NotifyLowStock", mock.Anything, tx, mock.Anything).Return(errors.New("outbox unavailable")).Once()
+				suite.notifier.On("Name").Return("event").Once()
+			},
+			expectedErr: errors.New("error occured while notifying low stock through event: outbox unavailable"),
+		},
+		{
+			name: "Fail Because Stock Reserved Meanwhile",
+			setup: func(tx repository.Transaction) {
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 5}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2, 4}, timeNow).Return(map[int64]int64{2: 3}, nil).Once()
+			},
+			expectedErr: apperrors.ProductQuantityInsufficient{ID: 2, QuantityAsked: 3, QuantityRemaining: 2},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			tx := &storm.DB{}
+			suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{2, 4}).Return(nil).Once()
+
+			//orders short of stock reserve nothing
+			if _, insufficient := test.expectedErr.(apperrors.ProductQuantityInsufficient); !insufficient {
+				suite.reservationRepo.On("CreateReservations", mock.Anything, tx, []repository.InventoryReservation{
+					{OrderID: 1, ProductID: 2, Quantity: 3, Status: repository.ReservationActive, ExpiresAt: timeNow.Add(24 * time.Hour)},
+					{OrderID: 1, ProductID: 4, Quantity: 1, Status: repository.ReservationActive, ExpiresAt: timeNow.Add(24 * time.Hour)},
+				}).Return(nil).Once()
+			}
+			test.setup(tx)
+
+			err := suite.service.ReserveStock(context.Background(), tx, 1, []dto.ProductInfo{
+				{ProductID: 2, Quantity: 3},
+				{ProductID: 4, Quantity: 1},
+			})
+			if test.expectedErr != nil {
+				suite.EqualError(err, test.expectedErr.Error())
+				return
+			}
+			suite.NoError(err)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestCommitReservations() {
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name                string
+		setup               func()
+		expectedAdjustments []dto.StockAdjustedEvent
+		expectedErr         error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.reservationRepo.On("ListReservationsByOrderID", mock.Anything, tx, int64(1)).Return([]repository.InventoryReservation{
+					{ID: 5, OrderID: 1, ProductID: 2, Quantity: 3, Status: repository.ReservationActive},
+					{ID: 6, OrderID: 1, ProductID: 4, Quantity: 1, Status: repository.ReservationReleased},
+				}, nil).Once()
+				suite.reservationRepo.On("UpdateReservationStatus", mock.Anything, tx, []int64{5, 6}, repository.ReservationCommitted).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 10}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2}, mock.Anything).Return(map[int64]int64{2: 7}, nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(4)).Return(repository.Product{ID: 4, Quantity: 1}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{4}, mock.Anything).Return(map[int64]int64{}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(2), int64(-3)).Return(repository.Product{ID: 2, Quantity: 7}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 2, Delta: -3, QuantityAfter: 7, Reason: repository.StockReasonSale, OrderID: 1,
+				}).Return(repository.StockMovement{ID: 1}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(4), int64(-1)).Return(repository.Product{ID: 4, Quantity: 0}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 4, Delta: -1, QuantityAfter: 0, Reason: repository.StockReasonSale, OrderID: 1,
+				}).Return(repository.StockMovement{ID: 2}, nil).Once()
+			},
+			expectedAdjustments: []dto.StockAdjustedEvent{
+				{ProductID: 2, Delta: -3, Quantity: 7, Reason: repository.StockReasonSale, OrderID: 1},
+				{ProductID: 4, Delta: -1, Quantity: 0, Reason: repository.StockReasonSale, OrderID: 1},
+			},
+		},
+		{
+			name: "Success Without Reservations",
+			setup: func() {
+				suite.reservationRepo.On("ListReservationsByOrderID", mock.Anything, tx, int64(1)).Return([]repository.InventoryReservation{}, nil).Once()
+			},
+			expectedAdjustments: []dto.StockAdjustedEvent{},
+		},
+		{
+			name: "Fail Because Expired Hold Was Sold Meanwhile",
+			setup: func() {
+				suite.reservationRepo.On("ListReservationsByOrderID", mock.Anything, tx, int64(1)).Return([]repository.InventoryReservation{
+					{ID: 5, OrderID: 1, ProductID: 2, Quantity: 3, Status: repository.ReservationReleased},
+				}, nil).Once()
+				suite.reservationRepo.On("UpdateReservationStatus", mock.Anything, tx, []int64{5}, repository.ReservationCommitted).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 10}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{2}, mock.Anything).Return(map[int64]int64{2: 9}, nil).Once()
+			},
+			expectedAdjustments: []dto.StockAdjustedEvent{},
+			expectedErr:         apperrors.ProductQuantityInsufficient{ID: 2, QuantityAsked: 3, QuantityRemaining: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			adjustments, err := suite.service.CommitReservations(context.Background(), tx, 1)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedAdjustments, adjustments)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestReleaseReservations() {
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name             string
+		setup            func()
+		expectedReserved bool
+	}{
+		{
+			name: "Success Releasing Active Holds",
+			setup: func() {
+				suite.reservationRepo.On("ListReservationsByOrderID", mock.Anything, tx, int64(1)).Return([]repository.InventoryReservation{
+					{ID: 5, Status: repository.ReservationActive},
+					{ID: 6, Status: repository.ReservationReleased},
+				}, nil).Once()
+				suite.reservationRepo.On("UpdateReservationStatus", mock.Anything, tx, []int64{5}, repository.ReservationReleased).Return(nil).Once()
+			},
+			expectedReserved: true,
+		},
+		{
+			name: "Success For Order Placed Before Reservations",
+			setup: func() {
+				suite.reservationRepo.On("ListReservationsByOrderID", mock.Anything, tx, int64(1)).Return([]repository.InventoryReservation{}, nil).Once()
+				suite.reservationRepo.On("UpdateReservationStatus", mock.Anything, tx, []int64{}, repository.ReservationReleased).Return(nil).Once()
+			},
+			expectedReserved: false,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			reserved, err := suite.service.ReleaseReservations(context.Background(), tx, 1)
+			suite.NoError(err)
+			suite.Equal(test.expectedReserved, reserved)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestReleaseExpiredReservations() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	tx := &storm.DB{}
+	suite.SetupTest()
+	suite.reservationRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	suite.reservationRepo.On("ListExpiredReservations", mock.Anything, tx, timeNow, 100).Return([]repository.InventoryReservation{
+		{ID: 5, Status: repository.ReservationActive},
+		{ID: 7, Status: repository.ReservationActive},
+	}, nil).Once()
+	suite.reservationRepo.On("UpdateReservationStatus", mock.Anything, tx, []int64{5, 7}, repository.ReservationReleased).Return(nil).Once()
+	suite.reservationRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+
+	released, err := suite.service.ReleaseExpiredReservations(context.Background(), 100)
+	suite.NoError(err)
+	suite.Equal(2, released)
+	suite.TearDownTest()
+}
+
+func (suite *ProductServiceTestSuite) TestRestockProduct() {
+	testCases := []struct {
+		name           string
+		setup          func()
+		expectedOutput dto.Product
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(10)).Return(repository.Product{ID: 1, Quantity: 12}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, repository.StockMovement{
+					ProductID: 1, Delta: 10, QuantityAfter: 12, Reason: repository.StockReasonRestock,
+				}).Return(repository.StockMovement{ID: 1}, nil).Once()
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), dto.StockAdjustedEvent{
+					ProductID: 1, Delta: 10, Quantity: 12, Reason: repository.StockReasonRestock,
+				}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 12}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 3}, nil).Once()
+			},
+			expectedOutput: dto.Product{ID: 1, Quantity: 12, Reserved: 3, AvailableToSell: 9},
+		},
+		{
+			name: "Fail Because Product Archived",
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Archived: true}, nil).Once()
+			},
+			expectedOutput: dto.Product{},
+			expectedErr:    apperrors.ProductArchived{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			product, err := suite.service.RestockProduct(context.Background(), 1, dto.RestockProductRequest{Quantity: 10})
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, product)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestAdjustInventory() {
+	testCases := []struct {
+		name           string
+		input          dto.InventoryAdjustmentRequest
+		setup          func()
+		expectedOutput dto.InventoryAdjustmentList
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: 5, Reason: repository.StockReasonRestock},
+				{ProductID: 1, Delta: -7, Reason: repository.StockReasonCorrection},
+			}},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(5)).Return(repository.Product{ID: 1, Quantity: 7}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, mock.Anything).Return(repository.StockMovement{ID: 1}, nil).Twice()
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{1}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 7}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(-7)).Return(repository.Product{ID: 1, Quantity: 0}, nil).Once()
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), mock.Anything).Return(nil).Twice()
+			},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{
+				{ProductID: 1, Delta: 5, Quantity: 7, Reason: repository.StockReasonRestock},
+				{ProductID: 1, Delta: -7, Quantity: 0, Reason: repository.StockReasonCorrection},
+			}},
+		},
+		{
+			name: "Success And Notifies Low Stock When Correction Takes Stock",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: -4, Reason: repository.StockReasonCorrection},
+			}},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{1}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{
+					ID: 1, Name: "XYZ", Quantity: 9, ReorderThreshold: 4, ReorderQuantity: 10,
+				}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 1}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(-4)).Return(repository.Product{ID: 1, Quantity: 5}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, mock.Anything).Return(repository.StockMovement{ID: 1}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 1}, nil).Once()
+				suite.notifier.On("NotifyLowStock", mock.Anything, tx, dto.LowStockProduct{
+					ProductID: 1, Name: "XYZ", Quantity: 5, Reserved: 1, AvailableToSell: 4, ReorderThreshold: 4, ReorderQuantity: 10,
+				}).Return(nil).Once()
+				suite.eventService.On("Publish", mock.Anything, tx, event.StockAdjusted, int64(1), mock.Anything).Return(nil).Once()
+			},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{
+				{ProductID: 1, Delta: -4, Quantity: 5, Reason: repository.StockReasonCorrection},
+			}},
+		},
+		{
+			name: "Fail Because Restock Takes Stock",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: -5, Reason: repository.StockReasonRestock},
+			}},
+			setup:          func() {},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{}},
+			expectedErr:    apperrors.StockAdjustmentInvalid{ProductID: 1, Delta: -5, Reason: repository.StockReasonRestock},
+		},
+		{
+			name: "Fail Because Reason Is Recorded By Orders",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: -5, Reason: repository.StockReasonSale},
+			}},
+			setup:          func() {},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{}},
+			expectedErr:    apperrors.StockAdjustmentInvalid{ProductID: 1, Delta: -5, Reason: repository.StockReasonSale},
+		},
+		{
+			name: "Fail Because Stock Would Go Negative",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: 5, Reason: repository.StockReasonRestock},
+				{ProductID: 2, Delta: -3, Reason: repository.StockReasonCorrection},
+			}},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 2}, nil).Once()
+				suite.productRepo.On("AdjustProductQuantity", mock.Anything, tx, int64(1), int64(5)).Return(repository.Product{ID: 1, Quantity: 7}, nil).Once()
+				suite.movementRepo.On("CreateStockMovement", mock.Anything, tx, mock.Anything).Return(repository.StockMovement{ID: 1}, nil).Once()
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{2}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(2)).Return(repository.Product{ID: 2, Quantity: 1}, nil).Once()
+			},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{}},
+			expectedErr:    apperrors.ProductQuantityInsufficient{ID: 2, QuantityAsked: 3, QuantityRemaining: 1},
+		},
+		{
+			name: "Fail Because Correction Takes Reserved Stock",
+			input: dto.InventoryAdjustmentRequest{Adjustments: []dto.InventoryAdjustmentLine{
+				{ProductID: 1, Delta: -4, Reason: repository.StockReasonCorrection},
+			}},
+			setup: func() {
+				tx := &storm.DB{}
+				suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+				suite.productRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil)
+				suite.productRepo.On("LockProducts", mock.Anything, tx, []int64{1}).Return(nil).Once()
+				suite.productRepo.On("GetProductByID", mock.Anything, tx, int64(1)).Return(repository.Product{ID: 1, Quantity: 5}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, tx, []int64{1}, mock.Anything).Return(map[int64]int64{1: 3}, nil).Once()
+			},
+			expectedOutput: dto.InventoryAdjustmentList{Adjustments: []dto.StockAdjustedEvent{}},
+			expectedErr:    apperrors.ProductQuantityInsufficient{ID: 1, QuantityAsked: 4, QuantityRemaining: 2},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			adjustmentList, err := suite.service.AdjustInventory(context.Background(), test.input)
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, adjustmentList)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestListStockMovements() {
+	createdAt := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		setup          func()
+		expectedOutput dto.StockMovementList
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Product{ID: 1}, nil)
+				suite.movementRepo.On("CountStockMovements", mock.Anything, mock.Anything, int64(1)).Return(int64(3), nil)
+				suite.movementRepo.On("ListStockMovements", mock.Anything, mock.Anything, int64(1), 2, 2).Return([]repository.StockMovement{
+					{ID: 1, ProductID: 1, Delta: 10, QuantityAfter: 10, Reason: repository.StockReasonInitial, CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedOutput: dto.StockMovementList{
+				Movements: []dto.StockMovement{
+					{ID: 1, ProductID: 1, Delta: 10, QuantityAfter: 10, Reason: repository.StockReasonInitial, CreatedAt: createdAt},
+				},
+				Pagination: dto.Pagination{Page: 2, PageSize: 2, TotalItems: 3, TotalPages: 2},
+			},
+		},
+		{
+			name: "Fail Because Product Not Found",
+			setup: func() {
+				suite.productRepo.On("GetProductByID", mock.Anything, mock.Anything, int64(1)).Return(repository.Product{}, nil)
+			},
+			expectedOutput: dto.StockMovementList{
+				Movements:  []dto.StockMovement{},
+				Pagination: dto.Pagination{Page: 2, PageSize: 2},
+			},
+			expectedErr: apperrors.ProductNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			movementList, err := suite.service.ListStockMovements(context.Background(), dto.ListStockMovementsRequest{ProductID: 1, Page: 2, PageSize: 2})
+			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, movementList)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestReconcileStock() {
+	tx := &storm.DB{}
+	suite.SetupTest()
+	suite.productRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+	suite.productRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil)
+	suite.productRepo.On("ListProductQuantities", mock.Anything, tx).Return(map[int64]int64{1: 10, 2: 0, 3: 4, 4: 7}, nil)
+	suite.movementRepo.On("SumStockMovements", mock.Anything, tx).Return(map[int64]int64{1: 10, 3: 6, 4: 5}, nil)
+
+	reconciliation, err := suite.service.ReconcileStock(context.Background())
+	suite.NoError(err)
+	suite.Equal(dto.StockReconciliation{
+		CheckedProducts: 4,
+		Mismatches: []dto.StockMismatch{
+			{ProductID: 3, Quantity: 4, LedgerQuantity: 6},
+			{ProductID: 4, Quantity: 7, LedgerQuantity: 5},
+		},
+	}, reconciliation)
+	suite.TearDownTest()
+}
+
+func (suite *ProductServiceTestSuite) TestLowStockReport() {
+	testCases := []struct {
+		name           string
+		setup          func()
+		expectedOutput dto.LowStockReport
+		expectedErr    error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.productRepo.On("ListReorderProducts", mock.Anything, nil).Return([]repository.Product{
+					{ID: 1, Name: "XYZ", Quantity: 10, ReorderThreshold: 5, ReorderQuantity: 20},
+					{ID: 2, Name: "ABC", Quantity: 4, ReorderThreshold: 5, ReorderQuantity: 10},
+				}, nil).Once()
+				suite.reservationRepo.On("GetReservedQuantities", mock.Anything, nil, []int64{1, 2}, mock.Anything).Return(map[int64]int64{2: 1}, nil).Once()
+			},
+			expectedOutput: dto.LowStockReport{Products: []dto.LowStockProduct{
+				{ProductID: 2, Name: "ABC", Quantity: 4, Reserved: 1, AvailableToSell: 3, ReorderThreshold: 5, ReorderQuantity: 10},
+			}},
+		},
+		{
+			name: "Fail Because Of Database Error",
+			setup: func() {
+				suite.productRepo.On("ListReorderProducts", mock.Anything, nil).Return([]repository.Product{}, errors.New("db error")).Once()
+			},
+			expectedOutput: dto.LowStockReport{Products: []dto.LowStockProduct{}},
+			expectedErr:    errors.New("db error"),
 		},
 	}
 
@@ -176,8 +1293,9 @@ func (suite *ProductServiceTestSuite) TestUpdateProductQuantity() {
 		suite.Run(test.name, func() {
 			test.setup()
 
-			err := suite.service.UpdateProductQuantity(context.Background(), nil, map[int64]int64{1: 2})
+			report, err := suite.service.LowStockReport(context.Background())
 			suite.Equal(test.expectedErr, err)
+			suite.Equal(test.expectedOutput, report)
 		})
 		suite.TearDownTest()
 	}
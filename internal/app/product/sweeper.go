@@ -0,0 +1,54 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ReservationSweeper releases the stock holds of orders which were not dispatched before their reservation expired
+type ReservationSweeper struct {
+	productSvc Service
+	cfg        config.InventoryConfig
+}
+
+func NewReservationSweeper(productSvc Service, cfg config.InventoryConfig) *ReservationSweeper {
+	return &ReservationSweeper{
+		productSvc: productSvc,
+		cfg:        cfg,
+	}
+}
+
+// Run releases the expired holds every sweep interval until ctx is cancelled
+func (rs *ReservationSweeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(rs.cfg.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		//keep going without waiting while full batches expire
+		for ctx.Err() == nil {
+			released, err := rs.productSvc.ReleaseExpiredReservations(ctx, rs.cfg.SweepBatchSize)
+			if err != nil {
+				logger.Errorw(ctx, "error occured while releasing expired reservations", zap.Error(err))
+				break
+			}
+
+			if released > 0 {
+				logger.Infow(ctx, "released expired reservations", zap.Int("count", released))
+			}
+
+			if released < rs.cfg.SweepBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
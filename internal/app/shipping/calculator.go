@@ -0,0 +1,124 @@
+package shipping
+
+import (
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// Parcel is an ordered product shipped Quantity times, its weight is in grams and its dimensions in millimetres
+type Parcel struct {
+	WeightGrams int64
+	LengthMM    int64
+	WidthMM     int64
+	HeightMM    int64
+	Quantity    int64
+}
+
+// Quote is the shipping of an order with Method, WeightGrams is the weight charged by weight methods.
+// Orders shipped with no method have an empty Method and a zero Amount.
+type Quote struct {
+	Method      string
+	WeightGrams int64
+	Amount      money.Money
+}
+
+// Calculator prices the shipping of orders with the methods of the shipping catalog,
+// whose amounts are in the catalog currency
+type Calculator struct {
+	cfg config.ShippingConfig
+}
+
+func NewCalculator(cfg config.ShippingConfig) *Calculator {
+	return &Calculator{cfg: cfg}
+}
+
+// Methods returns the shipping catalog priced in the currency the rate converts into
+func (c *Calculator) Methods(rate money.Rate) []dto.ShippingMethod {
+	methods := make([]dto.ShippingMethod, 0, len(c.cfg.Methods))
+	for _, method := range c.cfg.Methods {
+		shippingMethod := dto.ShippingMethod{
+			Name:         method.Name,
+			Type:         method.Type,
+			Amount:       convert(rate, method.Amount),
+			DeliveryDays: method.DeliveryDays,
+			Default:      method.Name == c.cfg.DefaultMethod,
+		}
+
+		if method.Type == constants.ShippingRateWeight {
+			perKg := convert(rate, method.PerKg)
+			shippingMethod.PerKg = &perKg
+		}
+
+		if method.FreeAbove > 0 {
+			freeAbove := convert(rate, method.FreeAbove)
+			shippingMethod.FreeAbove = &freeAbove
+		}
+
+		methods = append(methods, shippingMethod)
+	}
+
+	return methods
+}
+
+// Quote prices the shipping of the parcels of an order of amount with method, the default method when empty.
+// The amounts of the method are converted at the rate into the currency of the order amount.
+// Without any method the order is not shipped, a method outside the catalog fails with ShippingMethodUnknown.
+func (c *Calculator) Quote(methodName string, rate money.Rate, amount money.Money, parcels []Parcel) (Quote, error) {
+	if methodName == "" {
+		methodName = c.cfg.DefaultMethod
+	}
+
+	if methodName == "" {
+		return Quote{Amount: money.New(0, amount.Currency)}, nil
+	}
+
+	method, ok := c.cfg.Method(methodName)
+	if !ok {
+		return Quote{}, apperrors.ShippingMethodUnknown{Method: methodName}
+	}
+
+	quote := Quote{
+		Method: method.Name,
+		Amount: convert(rate, method.Amount),
+	}
+
+	if method.Type == constants.ShippingRateWeight {
+		for _, parcel := range parcels {
+			quote.WeightGrams += chargeableWeight(method, parcel) * parcel.Quantity
+		}
+
+		//every started kilogram is charged
+		kilograms := (quote.WeightGrams + 999) / 1000
+		quote.Amount = quote.Amount.Add(convert(rate, method.PerKg).Mul(kilograms))
+	}
+
+	//orders reaching the threshold once discounted ship free
+	if method.FreeAbove > 0 && amount.Cmp(convert(rate, method.FreeAbove)) >= 0 {
+		quote.Amount = money.New(0, amount.Currency)
+	}
+
+	return quote, nil
+}
+
+// chargeableWeight returns the weight of one unit of the parcel in grams, its volumetric weight when that is more.
+// A divisor of cubic centimetres per kilogram turns cubic millimetres into grams.
+func chargeableWeight(method config.ShippingMethodConfig, parcel Parcel) int64 {
+	if method.VolumetricDivisor == 0 {
+		return parcel.WeightGrams
+	}
+
+	volumetric := parcel.LengthMM * parcel.WidthMM * parcel.HeightMM / method.VolumetricDivisor
+	if volumetric > parcel.WeightGrams {
+		return volumetric
+	}
+
+	return parcel.WeightGrams
+}
+
+// convert returns the minor units of the catalog currency converted at the rate
+func convert(rate money.Rate, minorUnits int64) money.Money {
+	return rate.Convert(money.New(minorUnits, rate.From))
+}
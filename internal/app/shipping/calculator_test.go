@@ -0,0 +1,125 @@
+package shipping
+
+import (
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inr(minorUnits int64) money.Money {
+	return money.New(minorUnits, "INR")
+}
+
+var catalog = config.ShippingConfig{
+	DefaultMethod: "standard",
+	Methods: []config.ShippingMethodConfig{
+		{Name: "standard", Type: "flat", Amount: 4900, FreeAbove: 99900, DeliveryDays: 5},
+		{Name: "express", Type: "weight", Amount: 9900, PerKg: 2000, VolumetricDivisor: 5000, DeliveryDays: 1},
+	},
+}
+
+func TestCalculatorQuote(t *testing.T) {
+	shoes := Parcel{WeightGrams: 850, LengthMM: 250, WidthMM: 150, HeightMM: 100, Quantity: 2}
+	pillow := Parcel{WeightGrams: 400, LengthMM: 500, WidthMM: 400, HeightMM: 200, Quantity: 1}
+
+	testCases := []struct {
+		name           string
+		cfg            config.ShippingConfig
+		method         string
+		rate           money.Rate
+		amount         money.Money
+		parcels        []Parcel
+		expectedOutput Quote
+		expectedErr    error
+	}{
+		{
+			name:           "Flat Rate Of Default Method",
+			cfg:            catalog,
+			rate:           money.Identity("INR"),
+			amount:         inr(50000),
+			parcels:        []Parcel{shoes},
+			expectedOutput: Quote{Method: "standard", Amount: inr(4900)},
+		},
+		{
+			name:           "Free Above Threshold",
+			cfg:            catalog,
+			method:         "standard",
+			rate:           money.Identity("INR"),
+			amount:         inr(99900),
+			parcels:        []Parcel{shoes},
+			expectedOutput: Quote{Method: "standard", Amount: inr(0)},
+		},
+		{
+			name:           "Every Started Kilogram Charged By Weight",
+			cfg:            catalog,
+			method:         "express",
+			rate:           money.Identity("INR"),
+			amount:         inr(50000),
+			parcels:        []Parcel{shoes},
+			expectedOutput: Quote{Method: "express", WeightGrams: 1700, Amount: inr(13900)},
+		},
+		{
+			name:           "Bulky Parcel Charged By Volume",
+			cfg:            catalog,
+			method:         "express",
+			rate:           money.Identity("INR"),
+			amount:         inr(50000),
+			parcels:        []Parcel{shoes, pillow},
+			expectedOutput: Quote{Method: "express", WeightGrams: 9700, Amount: inr(29900)},
+		},
+		{
+			name:           "Converted Into Order Currency",
+			cfg:            catalog,
+			method:         "express",
+			rate:           money.Rate{From: "INR", To: "USD", Value: 0.012},
+			amount:         money.New(600, "USD"),
+			parcels:        []Parcel{shoes},
+			expectedOutput: Quote{Method: "express", WeightGrams: 1700, Amount: money.New(167, "USD")},
+		},
+		{
+			name:           "Not Shipped Without Any Method",
+			cfg:            config.ShippingConfig{},
+			rate:           money.Identity("INR"),
+			amount:         inr(50000),
+			parcels:        []Parcel{shoes},
+			expectedOutput: Quote{Amount: inr(0)},
+		},
+		{
+			name:        "Unknown Method",
+			cfg:         catalog,
+			method:      "drone",
+			rate:        money.Identity("INR"),
+			amount:      inr(50000),
+			parcels:     []Parcel{shoes},
+			expectedErr: apperrors.ShippingMethodUnknown{Method: "drone"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			quote, err := NewCalculator(test.cfg).Quote(test.method, test.rate, test.amount, test.parcels)
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, quote)
+		})
+	}
+}
+
+func TestCalculatorMethods(t *testing.T) {
+	perKg := money.New(24, "USD")
+	freeAbove := money.New(1199, "USD")
+
+	assert.Equal(t, []dto.ShippingMethod{
+		{Name: "standard", Type: "flat", Amount: money.New(59, "USD"), FreeAbove: &freeAbove, DeliveryDays: 5, Default: true},
+		{Name: "express", Type: "weight", Amount: money.New(119, "USD"), PerKg: &perKg, DeliveryDays: 1},
+	}, NewCalculator(catalog).Methods(money.Rate{From: "INR", To: "USD", Value: 0.012}))
+}
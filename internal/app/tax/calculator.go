@@ -0,0 +1,101 @@
+package tax
+
+import (
+	"strings"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// Line is an ordered product taxed on Amount, what is left to pay on it once the discounts are taken off
+type Line struct {
+	Category string
+	Amount   money.Money
+}
+
+// LineTax is the tax charged on a line at Rate percent
+type LineTax struct {
+	Rate   float64
+	Amount money.Money
+}
+
+// Assessment is the tax of an order in Region, Lines break Amount down in the order of the taxed lines.
+// Inclusive amounts are held by the prices of the lines, the others are charged on top of them.
+type Assessment struct {
+	Region    string
+	Inclusive bool
+	Lines     []LineTax
+	Amount    money.Money
+}
+
+// Calculator taxes orders at the rates of the region they are shipped to, by the tax class of the ordered categories
+type Calculator struct {
+	cfg config.TaxConfig
+}
+
+func NewCalculator(cfg config.TaxConfig) *Calculator {
+	return &Calculator{cfg: cfg}
+}
+
+// Calculate taxes the lines priced in currency in the region of the destination, orders shipped to no address
+// are taxed in the default region. The lines are not taxed without a region,
+// a destination in none of the regions with tax rates fails with TaxRegionUnknown.
+func (c *Calculator) Calculate(destination *dto.Address, currency string, lines []Line) (Assessment, error) {
+	region, err := c.region(destination)
+	if err != nil {
+		return Assessment{}, err
+	}
+
+	rates := c.cfg.Regions[region]
+	assessment := Assessment{
+		Region:    region,
+		Inclusive: c.cfg.Inclusive && region != "",
+		Lines:     make([]LineTax, 0, len(lines)),
+		Amount:    money.New(0, currency),
+	}
+
+	for _, line := range lines {
+		//classes the region lists no percent for are exempt
+		rate := rates[c.cfg.Class(line.Category)]
+
+		tax := line.Amount.Percent(rate)
+		if assessment.Inclusive {
+			tax = line.Amount.IncludedPercent(rate)
+		}
+
+		assessment.Lines = append(assessment.Lines, LineTax{Rate: rate, Amount: tax})
+		assessment.Amount = assessment.Amount.Add(tax)
+	}
+
+	return assessment, nil
+}
+
+// region returns the tax region of the destination, the region of its country and state such as IN-MH
+// when it has tax rates and the region of its country, such as IN, otherwise
+func (c *Calculator) region(destination *dto.Address) (string, error) {
+	if len(c.cfg.Regions) == 0 {
+		return "", nil
+	}
+
+	if destination == nil {
+		return c.cfg.DefaultRegion, nil
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(destination.Country))
+	state := strings.ToUpper(strings.TrimSpace(destination.State))
+
+	candidates := []string{country}
+	if state != "" {
+		candidates = []string{country + "-" + state, country}
+	}
+
+	for _, region := range candidates {
+		if _, ok := c.cfg.Regions[region]; ok {
+			return region, nil
+		}
+	}
+
+	return "", apperrors.TaxRegionUnknown{Region: candidates[0]}
+}
@@ -0,0 +1,123 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inr(minorUnits int64) money.Money {
+	return money.New(minorUnits, "INR")
+}
+
+func TestCalculatorCalculate(t *testing.T) {
+	cfg := config.TaxConfig{
+		DefaultRegion: "IN-MH",
+		Classes:       map[string]string{"Budget": "reduced", "Regular": "exempt"},
+		Regions: map[string]map[string]float64{
+			"IN-MH": {"standard": 18, "reduced": 5},
+			"IN-KA": {"standard": 12.5},
+			"IN":    {"standard": 12},
+		},
+	}
+	inclusive := cfg
+	inclusive.Inclusive = true
+
+	lines := []Line{
+		{Category: "Premium", Amount: inr(450000)},
+		{Category: "Budget", Amount: inr(35999)},
+		{Category: "Regular", Amount: inr(10000)},
+	}
+
+	testCases := []struct {
+		name           string
+		cfg            config.TaxConfig
+		destination    *dto.Address
+		expectedOutput Assessment
+		expectedErr    error
+	}{
+		{
+			name:        "Exclusive Tax By Class",
+			cfg:         cfg,
+			destination: &dto.Address{Country: "IN", State: "MH"},
+			expectedOutput: Assessment{
+				Region: "IN-MH",
+				Lines:  []LineTax{{Rate: 18, Amount: inr(81000)}, {Rate: 5, Amount: inr(1800)}, {Rate: 0, Amount: inr(0)}},
+				Amount: inr(82800),
+			},
+		},
+		{
+			name:        "Inclusive Tax Broken Out Of The Amounts",
+			cfg:         inclusive,
+			destination: &dto.Address{Country: "IN", State: "MH"},
+			expectedOutput: Assessment{
+				Region:    "IN-MH",
+				Inclusive: true,
+				Lines:     []LineTax{{Rate: 18, Amount: inr(68644)}, {Rate: 5, Amount: inr(1714)}, {Rate: 0, Amount: inr(0)}},
+				Amount:    inr(70358),
+			},
+		},
+		{
+			name: "Default Region Without Destination",
+			cfg:  cfg,
+			expectedOutput: Assessment{
+				Region: "IN-MH",
+				Lines:  []LineTax{{Rate: 18, Amount: inr(81000)}, {Rate: 5, Amount: inr(1800)}, {Rate: 0, Amount: inr(0)}},
+				Amount: inr(82800),
+			},
+		},
+		{
+			name:        "Classes Missing In Region Are Exempt",
+			cfg:         cfg,
+			destination: &dto.Address{Country: " in ", State: "ka"},
+			expectedOutput: Assessment{
+				Region: "IN-KA",
+				Lines:  []LineTax{{Rate: 12.5, Amount: inr(56250)}, {Rate: 0, Amount: inr(0)}, {Rate: 0, Amount: inr(0)}},
+				Amount: inr(56250),
+			},
+		},
+		{
+			name:        "Country Region For State Without Rates",
+			cfg:         cfg,
+			destination: &dto.Address{Country: "IN", State: "TN"},
+			expectedOutput: Assessment{
+				Region: "IN",
+				Lines:  []LineTax{{Rate: 12, Amount: inr(54000)}, {Rate: 0, Amount: inr(0)}, {Rate: 0, Amount: inr(0)}},
+				Amount: inr(54000),
+			},
+		},
+		{
+			name:        "No Tax Without Any Region",
+			cfg:         config.TaxConfig{Inclusive: true},
+			destination: &dto.Address{Country: "IN", State: "MH"},
+			expectedOutput: Assessment{
+				Lines:  []LineTax{{Rate: 0, Amount: inr(0)}, {Rate: 0, Amount: inr(0)}, {Rate: 0, Amount: inr(0)}},
+				Amount: inr(0),
+			},
+		},
+		{
+			name:        "Destination In Unknown Region",
+			cfg:         cfg,
+			destination: &dto.Address{Country: "US", State: "CA"},
+			expectedErr: apperrors.TaxRegionUnknown{Region: "US-CA"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assessment, err := NewCalculator(test.cfg).Calculate(test.destination, "INR", lines)
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, assessment)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Dispatcher posts the due webhook deliveries to their subscriptions, signed with the subscription secret.
+// Failed deliveries are retried with a doubling backoff and dead lettered once they run out of attempts.
+type Dispatcher struct {
+	subscriptionRepo repository.WebhookSubscriptionStorer
+	deliveryRepo     repository.WebhookDeliveryStorer
+	client           *http.Client
+	cfg              config.WebhookConfig
+}
+
+func NewDispatcher(subscriptionRepo repository.WebhookSubscriptionStorer, deliveryRepo repository.WebhookDeliveryStorer,
+	cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           &http.Client{Timeout: cfg.Timeout},
+		cfg:              cfg,
+	}
+}
+
+// Run delivers the due deliveries every poll interval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		//keep going without waiting while full batches are due
+		for ctx.Err() == nil {
+			processed, err := d.DeliverDue(ctx)
+			if err != nil {
+				logger.Errorw(ctx, "error occured while dispatching webhook deliveries", zap.Error(err))
+				break
+			}
+
+			if processed < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue makes one attempt for a batch of due deliveries and returns the number of deliveries attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.deliveryRepo.ListDueWebhookDeliveries(ctx, nil, now(), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, delivery := range deliveries {
+		//stop between deliveries on shutdown, the rest stay due for the next run
+		if ctx.Err() != nil {
+			return i, nil
+		}
+
+		subscription, err := d.subscriptionRepo.GetWebhookSubscriptionByID(ctx, nil, delivery.SubscriptionID)
+		if err != nil {
+			return i, err
+		}
+
+		if subscription.ID == 0 {
+			delivery.Status = repository.WebhookDeliveryDead
+			delivery.LastError = "subscription deleted"
+		} else {
+			statusCode, err := d.post(ctx, subscription, delivery)
+			delivery = d.recordAttempt(ctx, delivery, statusCode, err)
+		}
+
+		err = d.deliveryRepo.UpdateWebhookDelivery(ctx, nil, delivery)
+		if err != nil {
+			return i, fmt.Errorf("error occured while updating webhook delivery %d: %w", delivery.ID, err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// post sends the delivery to the subscription, any response other than 2xx fails the attempt
+func (d *Dispatcher) post(ctx context.Context, subscription repository.WebhookSubscription, delivery repository.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	//drain the body so the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscription responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// recordAttempt updates the delivery state after an attempt answered with statusCode, failed when deliveryErr is not nil
+func (d *Dispatcher) recordAttempt(ctx context.Context, delivery repository.WebhookDelivery, statusCode int, deliveryErr error) repository.WebhookDelivery {
+	delivery.Attempts++
+	delivery.ResponseStatus = statusCode
+
+	if deliveryErr == nil {
+		delivery.Status = repository.WebhookDeliveryDelivered
+		delivery.DeliveredAt = now()
+		delivery.LastError = ""
+		return delivery
+	}
+
+	delivery.LastError = deliveryErr.Error()
+
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = repository.WebhookDeliveryDead
+		logger.Errorw(ctx, "webhook delivery dead lettered",
+			zap.Uint("id", delivery.ID),
+			zap.Int64("subscription_id", delivery.SubscriptionID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(deliveryErr),
+		)
+		return delivery
+	}
+
+	delivery.NextAttemptAt = now().Add(event.RetryBackoff(d.cfg.RetryBackoff, delivery.Attempts))
+	logger.Warnw(ctx, "error occured while delivering webhook, retrying later",
+		zap.Uint("id", delivery.ID),
+		zap.Int64("subscription_id", delivery.SubscriptionID),
+		zap.Int("attempts", delivery.Attempts),
+		zap.Time("next_attempt_at", delivery.NextAttemptAt),
+		zap.Error(deliveryErr),
+	)
+	return delivery
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/config"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	subscriptionRepo *mocks.WebhookSubscriptionStorer
+	deliveryRepo     *mocks.WebhookDeliveryStorer
+	dispatcher       *Dispatcher
+}
+
+func TestDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.subscriptionRepo = &mocks.WebhookSubscriptionStorer{}
+	suite.deliveryRepo = &mocks.WebhookDeliveryStorer{}
+
+	cfg := config.Default().Webhook
+	cfg.MaxAttempts = 3
+	suite.dispatcher = NewDispatcher(suite.subscriptionRepo, suite.deliveryRepo, cfg)
+}
+
+// this function executes after all tests executed
+func (suite *DispatcherTestSuite) TearDownTest() {
+	suite.subscriptionRepo.AssertExpectations(suite.T())
+	suite.deliveryRepo.AssertExpectations(suite.T())
+}
+
+func (suite *DispatcherTestSuite) TestDeliverDue() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	const secret = "0123456789abcdef"
+	payload := []byte(`{"id":7,"type":"OrderPlaced"}`)
+
+	//the partner verifies the signature the way the README documents it
+	var partnerStatus int
+	partner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+
+		if r.Header.Get(SignatureHeader) != Sign(secret, timestamp, body) || r.Header.Get(EventHeader) != "OrderPlaced" ||
+			r.Header.Get(DeliveryHeader) != "1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(partnerStatus)
+	}))
+	defer partner.Close()
+
+	subscription := repository.WebhookSubscription{ID: 1, URL: partner.URL, Secret: secret}
+	pending := repository.WebhookDelivery{
+		ID:             1,
+		SubscriptionID: 1,
+		EventID:        7,
+		EventType:      "OrderPlaced",
+		Payload:        payload,
+		Status:         repository.WebhookDeliveryPending,
+	}
+
+	testCases := []struct {
+		name           string
+		partnerStatus  int
+		attempts       int
+		subscription   repository.WebhookSubscription
+		expectedUpdate func(delivery repository.WebhookDelivery) bool
+	}{
+		{
+			name:          "Success Delivering Signed Payload",
+			partnerStatus: http.StatusNoContent,
+			subscription:  subscription,
+			expectedUpdate: func(delivery repository.WebhookDelivery) bool {
+				return delivery.Status == repository.WebhookDeliveryDelivered && delivery.Attempts == 1 &&
+					delivery.ResponseStatus == http.StatusNoContent && delivery.DeliveredAt.Equal(timeNow)
+			},
+		},
+		{
+			name:          "Retry With Backoff When Partner Fails",
+			partnerStatus: http.StatusInternalServerError,
+			attempts:      1,
+			subscription:  subscription,
+			expectedUpdate: func(delivery repository.WebhookDelivery) bool {
+				return delivery.Status == repository.WebhookDeliveryPending && delivery.Attempts == 2 &&
+					delivery.ResponseStatus == http.StatusInternalServerError && delivery.LastError != "" &&
+					delivery.NextAttemptAt.Equal(timeNow.Add(time.Minute))
+			},
+		},
+		{
+			name:          "Dead Letter After Last Attempt",
+			partnerStatus: http.StatusBadGateway,
+			attempts:      2,
+			subscription:  subscription,
+			expectedUpdate: func(delivery repository.WebhookDelivery) bool {
+				return delivery.Status == repository.WebhookDeliveryDead && delivery.Attempts == 3
+			},
+		},
+		{
+			name:         "Dead Letter When Subscription Was Deleted",
+			subscription: repository.WebhookSubscription{},
+			expectedUpdate: func(delivery repository.WebhookDelivery) bool {
+				return delivery.Status == repository.WebhookDeliveryDead && delivery.Attempts == 0 &&
+					delivery.LastError == "subscription deleted"
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			partnerStatus = test.partnerStatus
+
+			delivery := pending
+			delivery.Attempts = test.attempts
+
+			suite.deliveryRepo.On("ListDueWebhookDeliveries", mock.Anything, nil, timeNow, suite.dispatcher.cfg.BatchSize).
+				Return([]repository.WebhookDelivery{delivery}, nil).Once()
+			suite.subscriptionRepo.On("GetWebhookSubscriptionByID", mock.Anything, nil, int64(1)).Return(test.subscription, nil).Once()
+			suite.deliveryRepo.On("UpdateWebhookDelivery", mock.Anything, nil, mock.MatchedBy(test.expectedUpdate)).Return(nil).Once()
+
+			processed, err := suite.dispatcher.DeliverDue(context.Background())
+			suite.NoError(err)
+			suite.Equal(1, processed)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func TestSign(t *testing.T) {
+	//HMAC-SHA256 of "1684405800.{}" keyed with "secret"
+	signature := Sign("secret", 1684405800, []byte(`{}`))
+	assert.Equal(t, "sha256=a050689964d86a235cd88b6c0b3103454e0f5711a30a83a370cd734fce2aa6bb", signature)
+	assert.NotEqual(t, signature, Sign("secret", 1684405801, []byte(`{}`)))
+	assert.NotEqual(t, signature, Sign("other-secret", 1684405800, []byte(`{}`)))
+}
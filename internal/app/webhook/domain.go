@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+// headers sent with every delivery, partners verify SignatureHeader to trust the request
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// subscribableEvents are the domain events partners can receive
+var subscribableEvents = map[string]bool{
+	event.OrderPlaced:        true,
+	event.OrderStatusChanged: true,
+	event.LowStock:           true,
+}
+
+var deliveryStatuses = map[string]bool{
+	repository.WebhookDeliveryPending:   true,
+	repository.WebhookDeliveryDelivered: true,
+	repository.WebhookDeliveryDead:      true,
+}
+
+// Sign returns the SignatureHeader value of a delivery: the hex encoded HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription secret, prefixed with "sha256="
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func MapSubscriptionRepoToDto(subscription repository.WebhookSubscription) dto.WebhookSubscription {
+	return dto.WebhookSubscription{
+		ID:         int64(subscription.ID),
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func MapDeliveryRepoToDto(delivery repository.WebhookDelivery) dto.WebhookDelivery {
+	deliveryDto := dto.WebhookDelivery{
+		ID:             int64(delivery.ID),
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		ResponseStatus: delivery.ResponseStatus,
+		Payload:        delivery.Payload,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+
+	//only pending deliveries wait for another attempt
+	if delivery.Status == repository.WebhookDeliveryPending {
+		deliveryDto.NextAttemptAt = timeOrNil(delivery.NextAttemptAt)
+	}
+
+	deliveryDto.DeliveredAt = timeOrNil(delivery.DeliveredAt)
+	return deliveryDto
+}
+
+func mapListDeliveriesRequestToFilter(req dto.ListWebhookDeliveriesRequest) (repository.WebhookDeliveryFilter, error) {
+	if req.Status != "" && !deliveryStatuses[req.Status] {
+		return repository.WebhookDeliveryFilter{}, apperrors.WebhookDeliveryStatusInvalid{Status: req.Status}
+	}
+
+	return repository.WebhookDeliveryFilter{
+		SubscriptionID: req.SubscriptionID,
+		Status:         req.Status,
+		Limit:          req.PageSize,
+		Offset:         (req.Page - 1) * req.PageSize,
+	}, nil
+}
+
+func subscribes(subscription repository.WebhookSubscription, eventType string) bool {
+	for _, t := range subscription.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+var now = time.Now
+
+type service struct {
+	subscriptionRepo repository.WebhookSubscriptionStorer
+	deliveryRepo     repository.WebhookDeliveryStorer
+}
+
+type Service interface {
+	CreateSubscription(ctx context.Context, req dto.CreateWebhookSubscriptionRequest) (dto.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID int64) (dto.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]dto.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+	//ListDeliveries returns the requested page of deliveries, newest first, dead letters are listed with the dead status
+	ListDeliveries(ctx context.Context, req dto.ListWebhookDeliveriesRequest) (dto.WebhookDeliveryList, error)
+	//ReplayDelivery queues a dead delivery again with a fresh set of attempts
+	ReplayDelivery(ctx context.Context, deliveryID int64) (dto.WebhookDelivery, error)
+	//Name and Send make the service a sink of the outbox relay,
+	//Send queues a delivery of the event for every subscription to its type
+	Name() string
+	Send(ctx context.Context, event dto.Event) error
+}
+
+func NewService(subscriptionRepo repository.WebhookSubscriptionStorer, deliveryRepo repository.WebhookDeliveryStorer) Service {
+	return &service{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+func (ws *service) CreateSubscription(ctx context.Context, req dto.CreateWebhookSubscriptionRequest) (dto.WebhookSubscription, error) {
+	//event type not offered to partners, return error WebhookEventTypeInvalid
+	for _, eventType := range req.EventTypes {
+		if !subscribableEvents[eventType] {
+			return dto.WebhookSubscription{}, apperrors.WebhookEventTypeInvalid{EventType: eventType}
+		}
+	}
+
+	subscriptionDB, err := ws.subscriptionRepo.CreateWebhookSubscription(ctx, nil, repository.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		return dto.WebhookSubscription{}, err
+	}
+
+	return MapSubscriptionRepoToDto(subscriptionDB), nil
+}
+
+func (ws *service) GetSubscription(ctx context.Context, subscriptionID int64) (dto.WebhookSubscription, error) {
+	subscriptionDB, err := ws.subscriptionRepo.GetWebhookSubscriptionByID(ctx, nil, subscriptionID)
+	if err != nil {
+		return dto.WebhookSubscription{}, err
+	}
+
+	if subscriptionDB.ID == 0 {
+		return dto.WebhookSubscription{}, apperrors.WebhookSubscriptionNotFound{ID: subscriptionID}
+	}
+
+	return MapSubscriptionRepoToDto(subscriptionDB), nil
+}
+
+func (ws *service) ListSubscriptions(ctx context.Context) ([]dto.WebhookSubscription, error) {
+	subscriptions := make([]dto.WebhookSubscription, 0)
+
+	subscriptionsDB, err := ws.subscriptionRepo.ListWebhookSubscriptions(ctx, nil)
+	if err != nil {
+		return subscriptions, err
+	}
+
+	for _, subscription := range subscriptionsDB {
+		subscriptions = append(subscriptions, MapSubscriptionRepoToDto(subscription))
+	}
+
+	return subscriptions, nil
+}
+
+func (ws *service) DeleteSubscription(ctx context.Context, subscriptionID int64) (err error) {
+	//initializing database transaction
+	tx, err := ws.subscriptionRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		txErr := ws.subscriptionRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	subscriptionDB, err := ws.subscriptionRepo.GetWebhookSubscriptionByID(ctx, tx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if subscriptionDB.ID == 0 {
+		return apperrors.WebhookSubscriptionNotFound{ID: subscriptionID}
+	}
+
+	//pending deliveries of the subscription are dead lettered by the dispatcher
+	return ws.subscriptionRepo.DeleteWebhookSubscription(ctx, tx, subscriptionID)
+}
+
+func (ws *service) ListDeliveries(ctx context.Context, req dto.ListWebhookDeliveriesRequest) (dto.WebhookDeliveryList, error) {
+	deliveryList := dto.WebhookDeliveryList{
+		Deliveries: make([]dto.WebhookDelivery, 0),
+		Pagination: dto.NewPagination(req.Page, req.PageSize, 0),
+	}
+
+	//status unknown, return error WebhookDeliveryStatusInvalid
+	filter, err := mapListDeliveriesRequestToFilter(req)
+	if err != nil {
+		return deliveryList, err
+	}
+
+	totalItems, err := ws.deliveryRepo.CountWebhookDeliveries(ctx, nil, filter)
+	if err != nil {
+		return deliveryList, err
+	}
+
+	deliveryList.Pagination = dto.NewPagination(req.Page, req.PageSize, totalItems)
+
+	deliveriesDB, err := ws.deliveryRepo.ListWebhookDeliveries(ctx, nil, filter)
+	if err != nil {
+		return deliveryList, err
+	}
+
+	for _, delivery := range deliveriesDB {
+		deliveryList.Deliveries = append(deliveryList.Deliveries, MapDeliveryRepoToDto(delivery))
+	}
+
+	return deliveryList, nil
+}
+
+func (ws *service) ReplayDelivery(ctx context.Context, deliveryID int64) (delivery dto.WebhookDelivery, err error) {
+	//initializing database transaction
+	tx, err := ws.deliveryRepo.BeginTx(ctx)
+	if err != nil {
+		return dto.WebhookDelivery{}, err
+	}
+
+	defer func() {
+		txErr := ws.deliveryRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	deliveryDB, err := ws.deliveryRepo.GetWebhookDeliveryByID(ctx, tx, deliveryID)
+	if err != nil {
+		return dto.WebhookDelivery{}, err
+	}
+
+	if deliveryDB.ID == 0 {
+		return dto.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound{ID: deliveryID}
+	}
+
+	//pending deliveries are still retried and delivered ones are done, return error WebhookDeliveryNotDead
+	if deliveryDB.Status != repository.WebhookDeliveryDead {
+		return dto.WebhookDelivery{}, apperrors.WebhookDeliveryNotDead{ID: deliveryID, Status: deliveryDB.Status}
+	}
+
+	deliveryDB.Status = repository.WebhookDeliveryPending
+	deliveryDB.Attempts = 0
+	deliveryDB.NextAttemptAt = now()
+
+	err = ws.deliveryRepo.UpdateWebhookDelivery(ctx, tx, deliveryDB)
+	if err != nil {
+		return dto.WebhookDelivery{}, err
+	}
+
+	return MapDeliveryRepoToDto(deliveryDB), nil
+}
+
+func (ws *service) Name() string {
+	return "webhooks"
+}
+
+func (ws *service) Send(ctx context.Context, event dto.Event) (err error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	//initializing database transaction
+	tx, err := ws.deliveryRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		txErr := ws.deliveryRepo.HandleTransaction(ctx, tx, err)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+	}()
+
+	subscriptionsDB, err := ws.subscriptionRepo.ListWebhookSubscriptions(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptionsDB {
+		if !subscribes(subscription, event.Type) {
+			continue
+		}
+
+		//the relay delivers events at least once, queue each event once per subscription
+		deliveryDB, err := ws.deliveryRepo.GetWebhookDeliveryByEvent(ctx, tx, int64(subscription.ID), event.ID)
+		if err != nil {
+			return err
+		}
+
+		if deliveryDB.ID != 0 {
+			continue
+		}
+
+		_, err = ws.deliveryRepo.CreateWebhookDelivery(ctx, tx, repository.WebhookDelivery{
+			SubscriptionID: int64(subscription.ID),
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         repository.WebhookDeliveryPending,
+			NextAttemptAt:  now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/app/event"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/dto"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/sagar23sj/go-ecommerce/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	service          Service
+	subscriptionRepo *mocks.WebhookSubscriptionStorer
+	deliveryRepo     *mocks.WebhookDeliveryStorer
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
+
+// this function executes before the test suite begins execution
+func (suite *WebhookServiceTestSuite) SetupTest() {
+	suite.subscriptionRepo = &mocks.WebhookSubscriptionStorer{}
+	suite.deliveryRepo = &mocks.WebhookDeliveryStorer{}
+	suite.service = NewService(suite.subscriptionRepo, suite.deliveryRepo)
+}
+
+// this function executes after all tests executed
+func (suite *WebhookServiceTestSuite) TearDownTest() {
+	suite.subscriptionRepo.AssertExpectations(suite.T())
+	suite.deliveryRepo.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookServiceTestSuite) TestCreateSubscription() {
+	req := dto.CreateWebhookSubscriptionRequest{
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{event.OrderPlaced},
+	}
+
+	testCases := []struct {
+		name        string
+		req         dto.CreateWebhookSubscriptionRequest
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			req:  req,
+			setup: func() {
+				suite.subscriptionRepo.On("CreateWebhookSubscription", mock.Anything, mock.Anything, repository.WebhookSubscription{
+					URL:        req.URL,
+					Secret:     req.Secret,
+					EventTypes: req.EventTypes,
+				}).Return(repository.WebhookSubscription{ID: 1, URL: req.URL, Secret: req.Secret, EventTypes: req.EventTypes}, nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Event Type Cannot Be Subscribed",
+			req: dto.CreateWebhookSubscriptionRequest{
+				URL:        req.URL,
+				Secret:     req.Secret,
+				EventTypes: []string{event.StockAdjusted},
+			},
+			setup:       func() {},
+			expectedErr: apperrors.WebhookEventTypeInvalid{EventType: event.StockAdjusted},
+		},
+		{
+			name: "Fail Because Something Wrong With Storing Subscription",
+			req:  req,
+			setup: func() {
+				suite.subscriptionRepo.On("CreateWebhookSubscription", mock.Anything, mock.Anything, mock.Anything).
+					Return(repository.WebhookSubscription{}, errors.New("bucket not found")).Once()
+			},
+			expectedErr: errors.New("bucket not found"),
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			subscription, err := suite.service.CreateSubscription(context.Background(), test.req)
+			suite.Equal(test.expectedErr, err)
+			if err == nil {
+				suite.Equal(int64(1), subscription.ID)
+				suite.Equal(req.EventTypes, subscription.EventTypes)
+			}
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookServiceTestSuite) TestDeleteSubscription() {
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.subscriptionRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.subscriptionRepo.On("GetWebhookSubscriptionByID", mock.Anything, tx, int64(1)).Return(repository.WebhookSubscription{ID: 1}, nil).Once()
+				suite.subscriptionRepo.On("DeleteWebhookSubscription", mock.Anything, tx, int64(1)).Return(nil).Once()
+				suite.subscriptionRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Subscription Does Not Exist",
+			setup: func() {
+				suite.subscriptionRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.subscriptionRepo.On("GetWebhookSubscriptionByID", mock.Anything, tx, int64(1)).Return(repository.WebhookSubscription{}, nil).Once()
+				suite.subscriptionRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil).Once()
+			},
+			expectedErr: apperrors.WebhookSubscriptionNotFound{ID: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			err := suite.service.DeleteSubscription(context.Background(), 1)
+			suite.Equal(test.expectedErr, err)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookServiceTestSuite) TestListDeliveries() {
+	testCases := []struct {
+		name          string
+		req           dto.ListWebhookDeliveriesRequest
+		setup         func()
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "Success",
+			req:  dto.ListWebhookDeliveriesRequest{SubscriptionID: 1, Status: repository.WebhookDeliveryDead, Page: 2, PageSize: 10},
+			setup: func() {
+				filter := repository.WebhookDeliveryFilter{SubscriptionID: 1, Status: repository.WebhookDeliveryDead, Limit: 10, Offset: 10}
+				suite.deliveryRepo.On("CountWebhookDeliveries", mock.Anything, mock.Anything, filter).Return(int64(11), nil).Once()
+				suite.deliveryRepo.On("ListWebhookDeliveries", mock.Anything, mock.Anything, filter).
+					Return([]repository.WebhookDelivery{{ID: 11, SubscriptionID: 1, Status: repository.WebhookDeliveryDead}}, nil).Once()
+			},
+			expectedCount: 1,
+		},
+		{
+			name:        "Fail Because Status Is Unknown",
+			req:         dto.ListWebhookDeliveriesRequest{Status: "Lost", Page: 1, PageSize: 10},
+			setup:       func() {},
+			expectedErr: apperrors.WebhookDeliveryStatusInvalid{Status: "Lost"},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			deliveryList, err := suite.service.ListDeliveries(context.Background(), test.req)
+			suite.Equal(test.expectedErr, err)
+			suite.Len(deliveryList.Deliveries, test.expectedCount)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookServiceTestSuite) TestReplayDelivery() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	tx := &storm.DB{}
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByID", mock.Anything, tx, int64(1)).
+					Return(repository.WebhookDelivery{ID: 1, Status: repository.WebhookDeliveryDead, Attempts: 8, LastError: "timeout"}, nil).Once()
+				suite.deliveryRepo.On("UpdateWebhookDelivery", mock.Anything, tx, repository.WebhookDelivery{
+					ID:            1,
+					Status:        repository.WebhookDeliveryPending,
+					LastError:     "timeout",
+					NextAttemptAt: timeNow,
+				}).Return(nil).Once()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Delivery Does Not Exist",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByID", mock.Anything, tx, int64(1)).Return(repository.WebhookDelivery{}, nil).Once()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil).Once()
+			},
+			expectedErr: apperrors.WebhookDeliveryNotFound{ID: 1},
+		},
+		{
+			name: "Fail Because Delivery Is Not Dead",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByID", mock.Anything, tx, int64(1)).
+					Return(repository.WebhookDelivery{ID: 1, Status: repository.WebhookDeliveryDelivered}, nil).Once()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil).Once()
+			},
+			expectedErr: apperrors.WebhookDeliveryNotDead{ID: 1, Status: repository.WebhookDeliveryDelivered},
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			_, err := suite.service.ReplayDelivery(context.Background(), 1)
+			suite.Equal(test.expectedErr, err)
+		})
+		suite.TearDownTest()
+	}
+}
+
+func (suite *WebhookServiceTestSuite) TestSend() {
+	timeNow := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return timeNow }
+	defer func() { now = time.Now }()
+
+	tx := &storm.DB{}
+	evt := dto.Event{ID: 7, Type: event.OrderPlaced, AggregateID: 1, OccurredAt: timeNow, Payload: []byte(`{"order_id":1}`)}
+
+	subscriptions := []repository.WebhookSubscription{
+		{ID: 1, EventTypes: []string{event.OrderPlaced}},
+		{ID: 2, EventTypes: []string{event.OrderStatusChanged}},
+		{ID: 3, EventTypes: []string{event.OrderStatusChanged, event.OrderPlaced}},
+	}
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		expectedErr bool
+	}{
+		{
+			name: "Success Queueing A Delivery Per Subscribed Subscription",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.subscriptionRepo.On("ListWebhookSubscriptions", mock.Anything, tx).Return(subscriptions, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByEvent", mock.Anything, tx, int64(1), int64(7)).Return(repository.WebhookDelivery{}, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByEvent", mock.Anything, tx, int64(3), int64(7)).Return(repository.WebhookDelivery{}, nil).Once()
+				suite.deliveryRepo.On("CreateWebhookDelivery", mock.Anything, tx, mock.MatchedBy(func(delivery repository.WebhookDelivery) bool {
+					return delivery.EventID == 7 && delivery.Status == repository.WebhookDeliveryPending && delivery.NextAttemptAt.Equal(timeNow)
+				})).Return(repository.WebhookDelivery{}, nil).Twice()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+			},
+		},
+		{
+			name: "Success Skipping Subscriptions Already Holding The Event",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.subscriptionRepo.On("ListWebhookSubscriptions", mock.Anything, tx).Return(subscriptions, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByEvent", mock.Anything, tx, int64(1), int64(7)).Return(repository.WebhookDelivery{ID: 4}, nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByEvent", mock.Anything, tx, int64(3), int64(7)).Return(repository.WebhookDelivery{ID: 5}, nil).Once()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, nil).Return(nil).Once()
+			},
+		},
+		{
+			name: "Fail Because Something Wrong With Storing Delivery",
+			setup: func() {
+				suite.deliveryRepo.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				suite.subscriptionRepo.On("ListWebhookSubscriptions", mock.Anything, tx).Return(subscriptions[:1], nil).Once()
+				suite.deliveryRepo.On("GetWebhookDeliveryByEvent", mock.Anything, tx, int64(1), int64(7)).Return(repository.WebhookDelivery{}, nil).Once()
+				suite.deliveryRepo.On("CreateWebhookDelivery", mock.Anything, tx, mock.Anything).Return(repository.WebhookDelivery{}, errors.New("bucket not found")).Once()
+				suite.deliveryRepo.On("HandleTransaction", mock.Anything, tx, mock.Anything).Return(nil).Once()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		suite.SetupTest()
+		suite.Run(test.name, func() {
+			test.setup()
+
+			err := suite.service.Send(context.Background(), evt)
+			suite.Equal(test.expectedErr, err != nil)
+		})
+		suite.TearDownTest()
+	}
+}
@@ -0,0 +1,8 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("authentication required, send an api key or a bearer token")
+	ErrForbidden       = errors.New("caller is not allowed to perform this action")
+)
@@ -0,0 +1,37 @@
+package apperrors
+
+import "fmt"
+
+type CartNotFound struct {
+	ID int64
+}
+
+func (c CartNotFound) Error() string {
+	return fmt.Sprintf("cart not found with id: %d", c.ID)
+}
+
+type CartItemNotFound struct {
+	CartID    int64
+	ProductID int64
+}
+
+func (c CartItemNotFound) Error() string {
+	return fmt.Sprintf("product with id: %d not found in cart with id: %d", c.ProductID, c.CartID)
+}
+
+type CartNotOpen struct {
+	ID     int64
+	Status string
+}
+
+func (c CartNotOpen) Error() string {
+	return fmt.Sprintf("cart with id: %d cannot be modified, current_state: %s", c.ID, c.Status)
+}
+
+type CartEmpty struct {
+	ID int64
+}
+
+func (c CartEmpty) Error() string {
+	return fmt.Sprintf("cart with id: %d has no products to checkout", c.ID)
+}
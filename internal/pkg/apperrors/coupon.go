@@ -0,0 +1,37 @@
+package apperrors
+
+import "fmt"
+
+type CouponNotFound struct {
+	ID int64
+}
+
+func (c CouponNotFound) Error() string {
+	return fmt.Sprintf("coupon not found with id: %d", c.ID)
+}
+
+type CouponCodeExists struct {
+	Code string
+}
+
+func (c CouponCodeExists) Error() string {
+	return fmt.Sprintf("coupon already exists with code: %s", c.Code)
+}
+
+// CouponNotRedeemable is returned for orders with a coupon code which is unknown, expired or does not apply to them
+type CouponNotRedeemable struct {
+	Code   string
+	Reason string
+}
+
+func (c CouponNotRedeemable) Error() string {
+	return fmt.Sprintf("coupon %s cannot be redeemed: %s", c.Code, c.Reason)
+}
+
+type CouponUsageExceeded struct {
+	Code string
+}
+
+func (c CouponUsageExceeded) Error() string {
+	return fmt.Sprintf("coupon %s reached its usage limit", c.Code)
+}
@@ -0,0 +1,19 @@
+package apperrors
+
+import "fmt"
+
+type CustomerNotFound struct {
+	ID int64
+}
+
+func (c CustomerNotFound) Error() string {
+	return fmt.Sprintf("customer not found with id: %d", c.ID)
+}
+
+type CustomerEmailExists struct {
+	Email string
+}
+
+func (c CustomerEmailExists) Error() string {
+	return fmt.Sprintf("customer already exists with email: %s", c.Email)
+}
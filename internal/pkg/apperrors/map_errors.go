@@ -10,12 +10,68 @@ func MapError(err error) (statusCode int, errResponse error) {
 		return http.StatusUnprocessableEntity, err
 	case ProductQuantityExceeded:
 		return http.StatusUnprocessableEntity, err
+	case ProductCategoryInvalid:
+		return http.StatusUnprocessableEntity, err
+	case ProductArchived:
+		return http.StatusUnprocessableEntity, err
+	case ProductListQueryInvalid:
+		return http.StatusBadRequest, err
+	case StockAdjustmentInvalid:
+		return http.StatusUnprocessableEntity, err
 	case OrderNotFound:
 		return http.StatusNotFound, err
 	case OrderStatusInvalid:
 		return http.StatusUnprocessableEntity, err
 	case OrderUpdationInvalid:
 		return http.StatusUnprocessableEntity, err
+	case OrderUpdationForbidden:
+		return http.StatusForbidden, err
+	case OrderListQueryInvalid:
+		return http.StatusBadRequest, err
+	case IdempotencyKeyReused:
+		return http.StatusUnprocessableEntity, err
+	case CartNotFound:
+		return http.StatusNotFound, err
+	case CartItemNotFound:
+		return http.StatusNotFound, err
+	case CartNotOpen:
+		return http.StatusConflict, err
+	case CartEmpty:
+		return http.StatusUnprocessableEntity, err
+	case CustomerNotFound:
+		return http.StatusNotFound, err
+	case CustomerEmailExists:
+		return http.StatusConflict, err
+	case WebhookSubscriptionNotFound:
+		return http.StatusNotFound, err
+	case WebhookEventTypeInvalid:
+		return http.StatusUnprocessableEntity, err
+	case WebhookDeliveryNotFound:
+		return http.StatusNotFound, err
+	case WebhookDeliveryNotDead:
+		return http.StatusConflict, err
+	case WebhookDeliveryStatusInvalid:
+		return http.StatusBadRequest, err
+	case CouponNotFound:
+		return http.StatusNotFound, err
+	case CouponCodeExists:
+		return http.StatusConflict, err
+	case CouponNotRedeemable:
+		return http.StatusUnprocessableEntity, err
+	case CouponUsageExceeded:
+		return http.StatusConflict, err
+	case CurrencyMismatch:
+		return http.StatusUnprocessableEntity, err
+	case CurrencyUnavailable:
+		return http.StatusUnprocessableEntity, err
+	case PriceCurrencyRepeated:
+		return http.StatusUnprocessableEntity, err
+	case TaxRegionUnknown:
+		return http.StatusUnprocessableEntity, err
+	case ShippingMethodUnknown:
+		return http.StatusUnprocessableEntity, err
+	case ShippingAddressRequired:
+		return http.StatusUnprocessableEntity, err
 
 	default:
 		return http.StatusInternalServerError, err
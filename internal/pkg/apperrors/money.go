@@ -0,0 +1,31 @@
+package apperrors
+
+import "fmt"
+
+// CurrencyMismatch rejects an amount in another currency than the catalog is priced in
+type CurrencyMismatch struct {
+	Currency string
+	Expected string
+}
+
+func (c CurrencyMismatch) Error() string {
+	return fmt.Sprintf("amounts in %s are not accepted, the catalog is priced in %s", c.Currency, c.Expected)
+}
+
+// CurrencyUnavailable rejects an order in a currency without an exchange rate from the catalog currency
+type CurrencyUnavailable struct {
+	Currency string
+}
+
+func (c CurrencyUnavailable) Error() string {
+	return fmt.Sprintf("orders cannot be placed in %s, it has no exchange rate", c.Currency)
+}
+
+// PriceCurrencyRepeated rejects a product price in another currency which is in the catalog currency
+type PriceCurrencyRepeated struct {
+	Currency string
+}
+
+func (p PriceCurrencyRepeated) Error() string {
+	return fmt.Sprintf("prices take currencies other than %s, the currency of the catalog price", p.Currency)
+}
@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	ErrNoProductsToOrder = errors.New("no products to order")
+	ErrNoProductsToOrder     = errors.New("no products to order")
+	ErrIdempotencyKeyTooLong = errors.New("idempotency key must be at most 255 characters")
 )
 
 type OrderNotFound struct {
@@ -34,3 +35,30 @@ type OrderUpdationInvalid struct {
 func (o OrderUpdationInvalid) Error() string {
 	return fmt.Sprintf("order updation invalid for order with id: %d, current_state: %s, requested_state: %s", o.ID, o.CurrentState, o.RequestedState)
 }
+
+type OrderUpdationForbidden struct {
+	ID             int64
+	Role           string
+	RequestedState string
+}
+
+func (o OrderUpdationForbidden) Error() string {
+	return fmt.Sprintf("role %s is not allowed to move order with id: %d to state: %s", o.Role, o.ID, o.RequestedState)
+}
+
+type OrderListQueryInvalid struct {
+	Param string
+	Value string
+}
+
+func (o OrderListQueryInvalid) Error() string {
+	return fmt.Sprintf("invalid value %q for order list query param: %s", o.Value, o.Param)
+}
+
+type IdempotencyKeyReused struct {
+	Key string
+}
+
+func (i IdempotencyKeyReused) Error() string {
+	return fmt.Sprintf("idempotency key %q was already used with a different request", i.Key)
+}
@@ -29,3 +29,39 @@ type ProductQuantityExceeded struct {
 func (p ProductQuantityExceeded) Error() string {
 	return fmt.Sprintf("product quantity exceeded for id: %d, quantity_limit : %d and quantity_asked : %d", p.ID, p.QuantityLimit, p.QuantityAsked)
 }
+
+type ProductCategoryInvalid struct {
+	Category string
+}
+
+func (p ProductCategoryInvalid) Error() string {
+	return fmt.Sprintf("invalid product category: %s, allowed categories : Premium, Regular, Budget", p.Category)
+}
+
+type ProductArchived struct {
+	ID int64
+}
+
+func (p ProductArchived) Error() string {
+	return fmt.Sprintf("product with id: %d is archived", p.ID)
+}
+
+type ProductListQueryInvalid struct {
+	Param string
+	Value string
+}
+
+func (p ProductListQueryInvalid) Error() string {
+	return fmt.Sprintf("invalid value %q for product list query param: %s", p.Value, p.Param)
+}
+
+type StockAdjustmentInvalid struct {
+	ProductID int64
+	Delta     int64
+	Reason    string
+}
+
+func (s StockAdjustmentInvalid) Error() string {
+	return fmt.Sprintf("invalid stock adjustment for product id: %d, delta : %d and reason : %s, allowed reasons : restock to add stock, correction",
+		s.ProductID, s.Delta, s.Reason)
+}
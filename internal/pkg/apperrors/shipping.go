@@ -0,0 +1,21 @@
+package apperrors
+
+import "fmt"
+
+// ShippingMethodUnknown rejects an order asking for a method outside the shipping catalog
+type ShippingMethodUnknown struct {
+	Method string
+}
+
+func (s ShippingMethodUnknown) Error() string {
+	return fmt.Sprintf("orders cannot be shipped with %s, it is not a shipping method", s.Method)
+}
+
+// ShippingAddressRequired rejects a shipped order without an address of its own or of the customer
+type ShippingAddressRequired struct {
+	Method string
+}
+
+func (s ShippingAddressRequired) Error() string {
+	return fmt.Sprintf("orders shipped with %s need a shipping address", s.Method)
+}
@@ -0,0 +1,12 @@
+package apperrors
+
+import "fmt"
+
+// TaxRegionUnknown rejects an order shipped to a region without configured tax rates
+type TaxRegionUnknown struct {
+	Region string
+}
+
+func (t TaxRegionUnknown) Error() string {
+	return fmt.Sprintf("orders cannot be taxed in region %s, it has no tax rates", t.Region)
+}
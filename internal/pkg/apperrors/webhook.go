@@ -0,0 +1,44 @@
+package apperrors
+
+import "fmt"
+
+type WebhookSubscriptionNotFound struct {
+	ID int64
+}
+
+func (w WebhookSubscriptionNotFound) Error() string {
+	return fmt.Sprintf("webhook subscription not found with id: %d", w.ID)
+}
+
+type WebhookEventTypeInvalid struct {
+	EventType string
+}
+
+func (w WebhookEventTypeInvalid) Error() string {
+	return fmt.Sprintf("invalid webhook event type: %s", w.EventType)
+}
+
+type WebhookDeliveryNotFound struct {
+	ID int64
+}
+
+func (w WebhookDeliveryNotFound) Error() string {
+	return fmt.Sprintf("webhook delivery not found with id: %d", w.ID)
+}
+
+type WebhookDeliveryNotDead struct {
+	ID     int64
+	Status string
+}
+
+func (w WebhookDeliveryNotDead) Error() string {
+	return fmt.Sprintf("only dead webhook deliveries can be replayed, delivery %d is %s", w.ID, w.Status)
+}
+
+type WebhookDeliveryStatusInvalid struct {
+	Status string
+}
+
+func (w WebhookDeliveryStatusInvalid) Error() string {
+	return fmt.Sprintf("invalid webhook delivery status: %s", w.Status)
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials in request")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Claims are the JWT claims identifying a user, the subject is stored in the registered sub claim
+type Claims struct {
+	Role       Role  `json:"role"`
+	CustomerID int64 `json:"customer_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Authenticator identifies callers by API key, for service to service calls,
+// or by an HS256 signed JWT, for users
+type Authenticator struct {
+	//apiKeys are indexed by the sha256 digest of the key so lookups do not compare raw secrets
+	apiKeys   map[[sha256.Size]byte]Principal
+	jwtSecret []byte
+}
+
+// NewAuthenticator builds an authenticator, an empty jwtSecret disables JWT authentication
+func NewAuthenticator(jwtSecret string, apiKeys map[string]Principal) *Authenticator {
+	hashedKeys := make(map[[sha256.Size]byte]Principal)
+	for key, principal := range apiKeys {
+		hashedKeys[sha256.Sum256([]byte(key))] = principal
+	}
+
+	return &Authenticator{
+		apiKeys:   hashedKeys,
+		jwtSecret: []byte(jwtSecret),
+	}
+}
+
+// Authenticate returns the caller of the request, ErrNoCredentials when the request is anonymous
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+		principal, ok := a.apiKeys[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return Principal{}, ErrInvalidCredentials
+		}
+
+		return principal, nil
+	}
+
+	authorization := r.Header.Get(AuthorizationHeader)
+	if authorization == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return a.parseToken(strings.TrimPrefix(authorization, bearerPrefix))
+}
+
+// IssueToken signs a JWT for the principal valid for ttl
+func (a *Authenticator) IssueToken(principal Principal, ttl time.Duration) (string, error) {
+	if len(a.jwtSecret) == 0 {
+		return "", errors.New("jwt secret is not configured")
+	}
+
+	issuedAt := time.Now()
+	claims := Claims{
+		Role:       principal.Role,
+		CustomerID: principal.CustomerID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   principal.Subject,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.jwtSecret)
+}
+
+func (a *Authenticator) parseToken(rawToken string) (Principal, error) {
+	if len(a.jwtSecret) == 0 {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(rawToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		return a.jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %s", ErrInvalidCredentials, err.Error())
+	}
+
+	if !claims.Role.IsValid() {
+		return Principal{}, fmt.Errorf("%w: unknown role %q", ErrInvalidCredentials, claims.Role)
+	}
+
+	//customer tokens must name the customer so requests can be scoped to it
+	if claims.Role == RoleCustomer && claims.CustomerID <= 0 {
+		return Principal{}, fmt.Errorf("%w: customer token without customer_id", ErrInvalidCredentials)
+	}
+
+	return Principal{
+		Subject:    claims.Subject,
+		Role:       claims.Role,
+		CustomerID: claims.CustomerID,
+	}, nil
+}
+
+// ParseAPIKeys reads API keys configured as comma separated name:role:key entries,
+// API keys identify services so only the ops and admin roles are accepted
+func ParseAPIKeys(raw string) (map[string]Principal, error) {
+	apiKeys := make(map[string]Principal)
+	if strings.TrimSpace(raw) == "" {
+		return apiKeys, nil
+	}
+
+	for _, entry := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid api key entry %q, expected name:role:key", entry)
+		}
+
+		role := Role(parts[1])
+		if role != RoleOps && role != RoleAdmin {
+			return nil, fmt.Errorf("invalid role %q for api key %s, allowed roles : ops, admin", role, parts[0])
+		}
+
+		if _, ok := apiKeys[parts[2]]; ok {
+			return nil, fmt.Errorf("duplicate api key for %s", parts[0])
+		}
+
+		apiKeys[parts[2]] = Principal{Subject: parts[0], Role: role}
+	}
+
+	return apiKeys, nil
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	authenticator := NewAuthenticator("test-secret", map[string]Principal{
+		"ops-key": {Subject: "warehouse", Role: RoleOps},
+	})
+
+	customer := Principal{Subject: "jane", Role: RoleCustomer, CustomerID: 7}
+	customerToken, _ := authenticator.IssueToken(customer, time.Hour)
+	expiredToken, _ := authenticator.IssueToken(customer, -time.Hour)
+	foreignToken, _ := NewAuthenticator("other-secret", nil).IssueToken(customer, time.Hour)
+	noCustomerToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Role:             RoleCustomer,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}).SignedString([]byte("test-secret"))
+	unsignedToken, _ := jwt.NewWithClaims(jwt.SigningMethodNone, Claims{
+		Role:             RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	testCases := []struct {
+		name           string
+		headers        map[string]string
+		expectedOutput Principal
+		expectedErr    error
+	}{
+		{
+			name:           "Valid API Key",
+			headers:        map[string]string{APIKeyHeader: "ops-key"},
+			expectedOutput: Principal{Subject: "warehouse", Role: RoleOps},
+		},
+		{
+			name:        "Unknown API Key",
+			headers:     map[string]string{APIKeyHeader: "other-key"},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:           "Valid Customer Token",
+			headers:        map[string]string{AuthorizationHeader: "Bearer " + customerToken},
+			expectedOutput: customer,
+		},
+		{
+			name:        "Expired Token",
+			headers:     map[string]string{AuthorizationHeader: "Bearer " + expiredToken},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Token Signed With Another Secret",
+			headers:     map[string]string{AuthorizationHeader: "Bearer " + foreignToken},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Unsigned Token",
+			headers:     map[string]string{AuthorizationHeader: "Bearer " + unsignedToken},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Customer Token Without Customer ID",
+			headers:     map[string]string{AuthorizationHeader: "Bearer " + noCustomerToken},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Authorization Without Bearer Scheme",
+			headers:     map[string]string{AuthorizationHeader: "Basic amFuZTpzZWNyZXQ="},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Anonymous Request",
+			headers:     map[string]string{},
+			expectedErr: ErrNoCredentials,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/orders", nil)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+
+			principal, err := authenticator.Authenticate(req)
+			assert.True(t, errors.Is(err, test.expectedErr), "unexpected error: %v", err)
+			assert.Equal(t, test.expectedOutput, principal)
+		})
+	}
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOutput map[string]Principal
+		expectErr      bool
+	}{
+		{
+			name:           "No Keys Configured",
+			input:          "",
+			expectedOutput: map[string]Principal{},
+		},
+		{
+			name:  "Valid Keys",
+			input: "billing:ops:key-1, backoffice:admin:key-2",
+			expectedOutput: map[string]Principal{
+				"key-1": {Subject: "billing", Role: RoleOps},
+				"key-2": {Subject: "backoffice", Role: RoleAdmin},
+			},
+		},
+		{
+			name:      "Customer Role Not Allowed",
+			input:     "shop:customer:key-1",
+			expectErr: true,
+		},
+		{
+			name:      "Malformed Entry",
+			input:     "billing:key-1",
+			expectErr: true,
+		},
+		{
+			name:      "Duplicate Key",
+			input:     "billing:ops:key-1,backoffice:admin:key-1",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			apiKeys, err := ParseAPIKeys(test.input)
+			assert.Equal(t, test.expectErr, err != nil)
+			if !test.expectErr {
+				assert.Equal(t, test.expectedOutput, apiKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import "context"
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleOps      Role = "ops"
+	RoleAdmin    Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleCustomer, RoleOps, RoleAdmin:
+		return true
+	}
+
+	return false
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Role    Role
+	//CustomerID is set for callers with RoleCustomer only
+	CustomerID int64
+}
+
+func (p Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller stored by the authentication middleware,
+// ok is false for anonymous requests
+func PrincipalFromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
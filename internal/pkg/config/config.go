@@ -0,0 +1,702 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the application settings, loaded from an optional YAML file
+// and overridden by environment variables
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Order     OrderConfig     `yaml:"order"`
+	Outbox    OutboxConfig    `yaml:"outbox"`
+	Webhook   WebhookConfig   `yaml:"webhook"`
+	Inventory InventoryConfig `yaml:"inventory"`
+}
+
+type ServerConfig struct {
+	Port int `yaml:"port"`
+	//ShutdownTimeout bounds the graceful shutdown of the HTTP server
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	Driver string `yaml:"driver"`
+	DSN    string `yaml:"dsn"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret"`
+	//APIKeys are comma separated name:role:key entries
+	APIKeys string `yaml:"api_keys"`
+}
+
+// OrderConfig holds the business limits applied while pricing orders and carts
+type OrderConfig struct {
+	//without Promotions, orders with PremiumProductsForDiscount or more premium products get DiscountPercentage off
+	DiscountPercentage         float64 `yaml:"discount_percentage"`
+	PremiumProductsForDiscount int     `yaml:"premium_products_for_discount"`
+	//MaxProductQuantity is the most units of one product an order may ask for
+	MaxProductQuantity int64 `yaml:"max_product_quantity"`
+	//Currency is the ISO 4217 code the catalog is priced in, the amounts of promotions and coupons are in it
+	Currency string `yaml:"currency"`
+	//RatesFile names a YAML file of the other currencies orders may be placed in along with their exchange rate
+	//from Currency, it is read into Rates
+	RatesFile string `yaml:"rates_file"`
+	//Rates hold the amount of each other currency one unit of Currency is worth
+	Rates map[string]float64 `yaml:"-"`
+	//Promotions are the discount rules priced on every order and cart
+	Promotions []PromotionConfig `yaml:"promotions"`
+	//Tax is charged on orders after the discounts, carts are priced before it
+	Tax TaxConfig `yaml:"tax"`
+	//Shipping lists the methods orders are delivered with
+	Shipping ShippingConfig `yaml:"shipping"`
+}
+
+// Rate returns the exchange rate pricing orders placed in currency, ok is false for currencies orders cannot be placed in
+func (c OrderConfig) Rate(currency string) (rate money.Rate, ok bool) {
+	if currency == c.Currency {
+		return money.Identity(c.Currency), true
+	}
+
+	value, ok := c.Rates[currency]
+	if !ok {
+		return money.Rate{}, false
+	}
+
+	return money.Rate{From: c.Currency, To: currency, Value: value}, true
+}
+
+// PromotionConfig is a discount rule evaluated by the pricing engine. It applies to the order lines of Category,
+// every line when empty, once the order reaches MinOrderValue and holds MinItems such lines.
+// The discount is taken off those lines, or off the whole order with a DiscountScope of order.
+// Amounts are in minor units of the order currency, e.g. paise.
+type PromotionConfig struct {
+	Name string `yaml:"name"`
+	//Type is one of the constants.Promotion types, which reads the matching settings below
+	Type     string `yaml:"type"`
+	Category string `yaml:"category"`
+	//DiscountScope is one of the constants.DiscountScope scopes, lines when empty
+	DiscountScope string `yaml:"discount_scope"`
+	//promotions are evaluated by descending Priority, a promotion which is not Stackable is only applied alone
+	Priority      int   `yaml:"priority"`
+	Stackable     bool  `yaml:"stackable"`
+	MinOrderValue int64 `yaml:"min_order_value_minor"`
+	MinItems      int   `yaml:"min_items"`
+
+	//Percent off the lines of percentage promotions, Amount off those of fixed ones
+	Percent float64 `yaml:"percent"`
+	Amount  int64   `yaml:"amount_minor"`
+	//buy_x_get_y promotions give GetQuantity units free for every BuyQuantity units bought of a line
+	BuyQuantity int64 `yaml:"buy_quantity"`
+	GetQuantity int64 `yaml:"get_quantity"`
+	//Tiers of tiered promotions, the highest tier reached by the lines gives its Percent off
+	Tiers []PromotionTier `yaml:"tiers"`
+}
+
+// TaxConfig holds the tax rates of every region by tax class, the class of a product follows from its category
+type TaxConfig struct {
+	//Inclusive prices already hold the tax, which orders break out of their amounts instead of adding it on top
+	Inclusive bool `yaml:"inclusive"`
+	//DefaultRegion taxes the orders delivered to no address, it is required with Regions
+	DefaultRegion string `yaml:"default_region"`
+	//Classes map product categories to their tax class, the other categories are in the standard class
+	Classes map[string]string `yaml:"classes"`
+	//Regions hold the percent charged on each tax class in the region, classes a region lists no percent for are exempt.
+	//Regions are keyed by country, such as IN, or by country and state, such as IN-MH, orders are taxed in the region of their address.
+	Regions map[string]map[string]float64 `yaml:"regions"`
+}
+
+// Class returns the tax class products of category are taxed in
+func (c TaxConfig) Class(category string) string {
+	class, ok := c.Classes[category]
+	if !ok {
+		return constants.TaxClassStandard
+	}
+
+	return class
+}
+
+// ShippingConfig is the catalog of shipping methods, amounts are in minor units of the catalog currency
+// and are converted into the currency of each order
+type ShippingConfig struct {
+	//DefaultMethod ships the orders naming no method, they are not charged shipping when empty
+	DefaultMethod string                 `yaml:"default_method"`
+	Methods       []ShippingMethodConfig `yaml:"methods"`
+}
+
+// ShippingMethodConfig prices the delivery of an order. Flat methods charge Amount, weight methods charge Amount
+// and PerKg for every started kilogram the products weigh. Orders reaching FreeAbove once discounted ship free.
+type ShippingMethodConfig struct {
+	Name string `yaml:"name"`
+	//Type is one of the constants.ShippingRate types
+	Type   string `yaml:"type"`
+	Amount int64  `yaml:"amount_minor"`
+	PerKg  int64  `yaml:"per_kg_minor"`
+	//VolumetricDivisor in cubic centimetres per kilogram charges bulky products by their volume
+	//when it weighs more than they do, 0 charges their weight alone
+	VolumetricDivisor int64 `yaml:"volumetric_divisor"`
+	//FreeAbove of 0 never ships free
+	FreeAbove    int64 `yaml:"free_above_minor"`
+	DeliveryDays int   `yaml:"delivery_days"`
+}
+
+// Method returns the shipping method of name, ok is false for methods not in the catalog
+func (c ShippingConfig) Method(name string) (method ShippingMethodConfig, ok bool) {
+	for _, method := range c.Methods {
+		if method.Name == name {
+			return method, true
+		}
+	}
+
+	return ShippingMethodConfig{}, false
+}
+
+type PromotionTier struct {
+	MinAmount int64   `yaml:"min_amount_minor"`
+	Percent   float64 `yaml:"percent"`
+}
+
+// ActivePromotions returns the configured promotions, or when none are configured the premium product discount
+// taking DiscountPercentage off the whole order holding PremiumProductsForDiscount premium products
+func (c OrderConfig) ActivePromotions() []PromotionConfig {
+	if len(c.Promotions) > 0 {
+		return c.Promotions
+	}
+
+	return []PromotionConfig{{
+		Name:          "premium_products",
+		Type:          constants.PromotionPercentage,
+		Category:      "Premium",
+		DiscountScope: constants.DiscountScopeOrder,
+		MinItems:      c.PremiumProductsForDiscount,
+		Percent:       c.DiscountPercentage,
+	}}
+}
+
+// OutboxConfig tunes the relay delivering domain events from the outbox to the sinks
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	//MaxAttempts is the number of deliveries tried before an event is marked failed
+	MaxAttempts int `yaml:"max_attempts"`
+	//RetryBackoff is the wait before the first retry, doubled on every further attempt
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	//Sinks are comma separated sink names: log, file and webhook
+	Sinks          string        `yaml:"sinks"`
+	FilePath       string        `yaml:"file_path"`
+	WebhookURL     string        `yaml:"webhook_url"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout"`
+}
+
+// WebhookConfig tunes the dispatcher delivering domain events to the webhook subscriptions
+type WebhookConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	//MaxAttempts is the number of deliveries tried before a delivery is dead lettered
+	MaxAttempts int `yaml:"max_attempts"`
+	//RetryBackoff is the wait before the first retry, doubled on every further attempt
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	Timeout      time.Duration `yaml:"timeout"`
+}
+
+// InventoryConfig tunes how long orders hold stock and the sweeper releasing expired holds
+type InventoryConfig struct {
+	//ReservationTTL is how long a placed order holds its stock before it has to be dispatched
+	ReservationTTL time.Duration `yaml:"reservation_ttl"`
+	SweepInterval  time.Duration `yaml:"sweep_interval"`
+	SweepBatchSize int           `yaml:"sweep_batch_size"`
+	//LowStockNotifiers are comma separated notifier names: log and event
+	LowStockNotifiers string `yaml:"low_stock_notifiers"`
+}
+
+// SinkNames returns the configured sink names without blanks
+func (c OutboxConfig) SinkNames() []string {
+	return splitNames(c.Sinks)
+}
+
+// NotifierNames returns the configured low stock notifier names without blanks
+func (c InventoryConfig) NotifierNames() []string {
+	return splitNames(c.LowStockNotifiers)
+}
+
+func splitNames(list string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Default returns the settings used when neither the config file nor the environment sets a value
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:            8080,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver: constants.DBDriverBolt,
+			DSN:    "test.db",
+		},
+		Order: OrderConfig{
+			DiscountPercentage:         10,
+			PremiumProductsForDiscount: 3,
+			MaxProductQuantity:         10,
+			Currency:                   money.DefaultCurrency,
+		},
+		Outbox: OutboxConfig{
+			PollInterval:   time.Second,
+			BatchSize:      100,
+			MaxAttempts:    10,
+			RetryBackoff:   time.Second,
+			Sinks:          constants.EventSinkLog,
+			WebhookTimeout: 5 * time.Second,
+		},
+		Webhook: WebhookConfig{
+			PollInterval: time.Second,
+			BatchSize:    50,
+			MaxAttempts:  8,
+			RetryBackoff: 30 * time.Second,
+			Timeout:      10 * time.Second,
+		},
+		Inventory: InventoryConfig{
+			ReservationTTL:    24 * time.Hour,
+			SweepInterval:     time.Minute,
+			SweepBatchSize:    100,
+			LowStockNotifiers: constants.LowStockNotifierLog,
+		},
+	}
+}
+
+// Load reads the YAML file at path over the defaults, applies the environment overrides and validates the result.
+// An empty path skips the file.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("error reading config file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&cfg)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("error parsing config file %s: %w", path, err)
+		}
+	}
+
+	err := cfg.applyEnv(os.LookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if cfg.Order.RatesFile != "" {
+		cfg.Order.Rates, err = loadRates(cfg.Order.RatesFile)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// loadRates reads the exchange rates file, a YAML mapping of currency codes to rates such as `USD: 0.012`
+func loadRates(path string) (map[string]float64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rates file: %w", err)
+	}
+
+	rates := make(map[string]float64)
+	err = yaml.Unmarshal(content, &rates)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rates file %s: %w", path, err)
+	}
+
+	return rates, nil
+}
+
+// applyEnv overrides the settings with the environment variables that are set
+func (c *Config) applyEnv(lookupEnv func(key string) (string, bool)) error {
+	overrides := []struct {
+		key   string
+		apply func(value string) error
+	}{
+		{"HTTP_PORT", intSetter(&c.Server.Port)},
+		{"HTTP_SHUTDOWN_TIMEOUT", durationSetter(&c.Server.ShutdownTimeout)},
+		{"DB_DRIVER", stringSetter(&c.Database.Driver)},
+		{"DB_DSN", stringSetter(&c.Database.DSN)},
+		{"AUTH_JWT_SECRET", stringSetter(&c.Auth.JWTSecret)},
+		{"AUTH_API_KEYS", stringSetter(&c.Auth.APIKeys)},
+		{"ORDER_DISCOUNT_PERCENTAGE", floatSetter(&c.Order.DiscountPercentage)},
+		{"ORDER_PREMIUM_PRODUCTS_FOR_DISCOUNT", intSetter(&c.Order.PremiumProductsForDiscount)},
+		{"ORDER_MAX_PRODUCT_QUANTITY", int64Setter(&c.Order.MaxProductQuantity)},
+		{"ORDER_CURRENCY", stringSetter(&c.Order.Currency)},
+		{"ORDER_RATES_FILE", stringSetter(&c.Order.RatesFile)},
+		{"ORDER_TAX_INCLUSIVE", boolSetter(&c.Order.Tax.Inclusive)},
+		{"ORDER_TAX_DEFAULT_REGION", stringSetter(&c.Order.Tax.DefaultRegion)},
+		{"ORDER_SHIPPING_DEFAULT_METHOD", stringSetter(&c.Order.Shipping.DefaultMethod)},
+		{"OUTBOX_POLL_INTERVAL", durationSetter(&c.Outbox.PollInterval)},
+		{"OUTBOX_BATCH_SIZE", intSetter(&c.Outbox.BatchSize)},
+		{"OUTBOX_MAX_ATTEMPTS", intSetter(&c.Outbox.MaxAttempts)},
+		{"OUTBOX_RETRY_BACKOFF", durationSetter(&c.Outbox.RetryBackoff)},
+		{"OUTBOX_SINKS", stringSetter(&c.Outbox.Sinks)},
+		{"OUTBOX_FILE_PATH", stringSetter(&c.Outbox.FilePath)},
+		{"OUTBOX_WEBHOOK_URL", stringSetter(&c.Outbox.WebhookURL)},
+		{"OUTBOX_WEBHOOK_TIMEOUT", durationSetter(&c.Outbox.WebhookTimeout)},
+		{"WEBHOOK_POLL_INTERVAL", durationSetter(&c.Webhook.PollInterval)},
+		{"WEBHOOK_BATCH_SIZE", intSetter(&c.Webhook.BatchSize)},
+		{"WEBHOOK_MAX_ATTEMPTS", intSetter(&c.Webhook.MaxAttempts)},
+		{"WEBHOOK_RETRY_BACKOFF", durationSetter(&c.Webhook.RetryBackoff)},
+		{"WEBHOOK_TIMEOUT", durationSetter(&c.Webhook.Timeout)},
+		{"INVENTORY_RESERVATION_TTL", durationSetter(&c.Inventory.ReservationTTL)},
+		{"INVENTORY_SWEEP_INTERVAL", durationSetter(&c.Inventory.SweepInterval)},
+		{"INVENTORY_SWEEP_BATCH_SIZE", intSetter(&c.Inventory.SweepBatchSize)},
+		{"INVENTORY_LOW_STOCK_NOTIFIERS", stringSetter(&c.Inventory.LowStockNotifiers)},
+	}
+
+	for _, override := range overrides {
+		value, ok := lookupEnv(override.key)
+		if !ok {
+			continue
+		}
+
+		err := override.apply(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, override.key, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate reports the first setting the application cannot start with
+func (c Config) Validate() error {
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("server port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+
+	if c.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("server shutdown timeout must be positive, got %s", c.Server.ShutdownTimeout)
+	}
+
+	switch c.Database.Driver {
+	case constants.DBDriverBolt, constants.DBDriverSQLite, constants.DBDriverPostgres:
+	default:
+		return fmt.Errorf("unsupported database driver: %s", c.Database.Driver)
+	}
+
+	if c.Database.DSN == "" {
+		return fmt.Errorf("database dsn is required")
+	}
+
+	if c.Order.DiscountPercentage < 0 || c.Order.DiscountPercentage > 100 {
+		return fmt.Errorf("order discount percentage must be between 0 and 100, got %v", c.Order.DiscountPercentage)
+	}
+
+	if c.Order.PremiumProductsForDiscount <= 0 {
+		return fmt.Errorf("order premium products for discount must be positive, got %d", c.Order.PremiumProductsForDiscount)
+	}
+
+	if c.Order.MaxProductQuantity <= 0 {
+		return fmt.Errorf("order max product quantity must be positive, got %d", c.Order.MaxProductQuantity)
+	}
+
+	if !money.IsSupported(c.Order.Currency) {
+		return fmt.Errorf("order currency %q is not a supported ISO 4217 code", c.Order.Currency)
+	}
+
+	for currency, rate := range c.Order.Rates {
+		if !money.IsSupported(currency) || currency == c.Order.Currency {
+			return fmt.Errorf("order rates: %q is not a supported ISO 4217 code other than the order currency", currency)
+		}
+
+		if rate <= 0 {
+			return fmt.Errorf("order rates: rate of %s must be positive, got %v", currency, rate)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, promotion := range c.Order.Promotions {
+		if names[promotion.Name] {
+			return fmt.Errorf("order promotion names must be unique, got %q twice", promotion.Name)
+		}
+		names[promotion.Name] = true
+
+		err := promotion.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.Order.Tax.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Order.Shipping.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Outbox.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Webhook.Validate()
+	if err != nil {
+		return err
+	}
+
+	return c.Inventory.Validate()
+}
+
+// Validate reports the first setting of the promotion the pricing engine cannot apply
+func (c PromotionConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("order promotion name is required")
+	}
+
+	if c.MinOrderValue < 0 || c.MinItems < 0 {
+		return fmt.Errorf("order promotion %s: min order value and min items cannot be negative", c.Name)
+	}
+
+	switch c.DiscountScope {
+	case "", constants.DiscountScopeLines, constants.DiscountScopeOrder:
+	default:
+		return fmt.Errorf("order promotion %s: unsupported discount scope %q", c.Name, c.DiscountScope)
+	}
+
+	switch c.Type {
+	case constants.PromotionPercentage:
+		if c.Percent <= 0 || c.Percent > 100 {
+			return fmt.Errorf("order promotion %s: percent must be above 0 and at most 100, got %v", c.Name, c.Percent)
+		}
+	case constants.PromotionFixed:
+		if c.Amount <= 0 {
+			return fmt.Errorf("order promotion %s: amount must be positive, got %d", c.Name, c.Amount)
+		}
+	case constants.PromotionBuyXGetY:
+		if c.BuyQuantity <= 0 || c.GetQuantity <= 0 {
+			return fmt.Errorf("order promotion %s: buy and get quantities must be positive", c.Name)
+		}
+	case constants.PromotionTiered:
+		if len(c.Tiers) == 0 {
+			return fmt.Errorf("order promotion %s: at least one tier is required", c.Name)
+		}
+
+		for _, tier := range c.Tiers {
+			if tier.MinAmount < 0 || tier.Percent <= 0 || tier.Percent > 100 {
+				return fmt.Errorf("order promotion %s: tiers need a min amount of at least 0 and a percent above 0 and at most 100", c.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("order promotion %s: unsupported type %q", c.Name, c.Type)
+	}
+
+	return nil
+}
+
+// Validate reports the first tax setting orders cannot be taxed with
+func (c TaxConfig) Validate() error {
+	if len(c.Regions) > 0 && c.DefaultRegion == "" {
+		return fmt.Errorf("order tax default region is required with tax regions, orders shipped to no address are taxed in it")
+	}
+
+	if c.DefaultRegion != "" {
+		_, ok := c.Regions[c.DefaultRegion]
+		if !ok {
+			return fmt.Errorf("order tax default region %s has no tax rates", c.DefaultRegion)
+		}
+	}
+
+	for category, class := range c.Classes {
+		if class == "" {
+			return fmt.Errorf("order tax class of category %s is required", category)
+		}
+	}
+
+	for region, rates := range c.Regions {
+		if region == "" || region != strings.ToUpper(strings.TrimSpace(region)) {
+			return fmt.Errorf("order tax regions must be upper case codes, got %q", region)
+		}
+
+		for class, percent := range rates {
+			if percent < 0 || percent > 100 {
+				return fmt.Errorf("order tax region %s: percent of class %s must be between 0 and 100, got %v", region, class, percent)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate reports the first shipping setting orders cannot be shipped with
+func (c ShippingConfig) Validate() error {
+	names := make(map[string]bool)
+	for _, method := range c.Methods {
+		if method.Name == "" {
+			return fmt.Errorf("order shipping method name is required")
+		}
+
+		if names[method.Name] {
+			return fmt.Errorf("order shipping method names must be unique, got %q twice", method.Name)
+		}
+		names[method.Name] = true
+
+		switch method.Type {
+		case constants.ShippingRateFlat, constants.ShippingRateWeight:
+		default:
+			return fmt.Errorf("order shipping method %s: unsupported type %q", method.Name, method.Type)
+		}
+
+		if method.Amount < 0 || method.PerKg < 0 || method.VolumetricDivisor < 0 || method.FreeAbove < 0 || method.DeliveryDays < 0 {
+			return fmt.Errorf("order shipping method %s: amounts, divisor and delivery days cannot be negative", method.Name)
+		}
+	}
+
+	if c.DefaultMethod != "" && !names[c.DefaultMethod] {
+		return fmt.Errorf("order shipping default method %s is not a shipping method", c.DefaultMethod)
+	}
+
+	return nil
+}
+
+// Validate reports the first outbox setting the relay cannot run with
+func (c OutboxConfig) Validate() error {
+	if c.PollInterval <= 0 || c.RetryBackoff <= 0 || c.WebhookTimeout <= 0 {
+		return fmt.Errorf("outbox poll interval, retry backoff and webhook timeout must be positive")
+	}
+
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("outbox batch size must be positive, got %d", c.BatchSize)
+	}
+
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("outbox max attempts must be positive, got %d", c.MaxAttempts)
+	}
+
+	for _, sink := range c.SinkNames() {
+		switch sink {
+		case constants.EventSinkLog:
+		case constants.EventSinkFile:
+			if c.FilePath == "" {
+				return fmt.Errorf("outbox file path is required by the file sink")
+			}
+		case constants.EventSinkWebhook:
+			if c.WebhookURL == "" {
+				return fmt.Errorf("outbox webhook url is required by the webhook sink")
+			}
+		default:
+			return fmt.Errorf("unsupported outbox sink: %s", sink)
+		}
+	}
+
+	return nil
+}
+
+// Validate reports the first webhook setting the dispatcher cannot run with
+func (c WebhookConfig) Validate() error {
+	if c.PollInterval <= 0 || c.RetryBackoff <= 0 || c.Timeout <= 0 {
+		return fmt.Errorf("webhook poll interval, retry backoff and timeout must be positive")
+	}
+
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("webhook batch size must be positive, got %d", c.BatchSize)
+	}
+
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("webhook max attempts must be positive, got %d", c.MaxAttempts)
+	}
+
+	return nil
+}
+
+// Validate reports the first inventory setting reservations cannot work with
+func (c InventoryConfig) Validate() error {
+	if c.ReservationTTL <= 0 || c.SweepInterval <= 0 {
+		return fmt.Errorf("inventory reservation ttl and sweep interval must be positive")
+	}
+
+	if c.SweepBatchSize <= 0 {
+		return fmt.Errorf("inventory sweep batch size must be positive, got %d", c.SweepBatchSize)
+	}
+
+	for _, notifier := range c.NotifierNames() {
+		switch notifier {
+		case constants.LowStockNotifierLog, constants.LowStockNotifierEvent:
+		default:
+			return fmt.Errorf("unsupported low stock notifier: %s", notifier)
+		}
+	}
+
+	return nil
+}
+
+func stringSetter(field *string) func(string) error {
+	return func(value string) error {
+		*field = value
+		return nil
+	}
+}
+
+func intSetter(field *int) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.Atoi(value)
+		return err
+	}
+}
+
+func int64Setter(field *int64) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.ParseInt(value, 10, 64)
+		return err
+	}
+}
+
+func boolSetter(field *bool) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.ParseBool(value)
+		return err
+	}
+}
+
+func floatSetter(field *float64) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.ParseFloat(value, 64)
+		return err
+	}
+}
+
+func durationSetter(field *time.Duration) func(string) error {
+	return func(value string) (err error) {
+		*field, err = time.ParseDuration(value)
+		return err
+	}
+}
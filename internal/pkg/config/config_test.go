@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+server:
+  port: 9090
+  shutdown_timeout: 5s
+database:
+  driver: sqlite
+  dsn: file:ecommerce.db
+order:
+  max_product_quantity: 25
+  promotions:
+    - name: budget_3_for_2
+      type: buy_x_get_y
+      category: Budget
+      buy_quantity: 2
+      get_quantity: 1
+`), 0o600))
+
+	promotions := []PromotionConfig{
+		{Name: "budget_3_for_2", Type: "buy_x_get_y", Category: "Budget", BuyQuantity: 2, GetQuantity: 1},
+	}
+
+	ratesFile := filepath.Join(t.TempDir(), "rates.yaml")
+	require.NoError(t, os.WriteFile(ratesFile, []byte("USD: 0.012\nEUR: 0.011\n"), 0o600))
+
+	unknownFieldFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(unknownFieldFile, []byte("server:\n  host: localhost\n"), 0o600))
+
+	testCases := []struct {
+		name           string
+		path           string
+		env            map[string]string
+		expectedOutput func(cfg *Config)
+		expectedErr    bool
+	}{
+		{
+			name:           "Defaults Without File",
+			expectedOutput: func(cfg *Config) {},
+		},
+		{
+			name: "File Overrides Defaults",
+			path: configFile,
+			expectedOutput: func(cfg *Config) {
+				cfg.Server = ServerConfig{Port: 9090, ShutdownTimeout: 5 * time.Second}
+				cfg.Database = DatabaseConfig{Driver: "sqlite", DSN: "file:ecommerce.db"}
+				cfg.Order.MaxProductQuantity = 25
+				cfg.Order.Promotions = promotions
+			},
+		},
+		{
+			name: "Environment Overrides File",
+			path: configFile,
+			env:  map[string]string{"HTTP_PORT": "7070", "ORDER_DISCOUNT_PERCENTAGE": "15", "ORDER_CURRENCY": "USD", "AUTH_JWT_SECRET": "secret", "ORDER_TAX_INCLUSIVE": "true"},
+			expectedOutput: func(cfg *Config) {
+				cfg.Server = ServerConfig{Port: 7070, ShutdownTimeout: 5 * time.Second}
+				cfg.Database = DatabaseConfig{Driver: "sqlite", DSN: "file:ecommerce.db"}
+				cfg.Auth.JWTSecret = "secret"
+				cfg.Order.MaxProductQuantity = 25
+				cfg.Order.Promotions = promotions
+				cfg.Order.DiscountPercentage = 15
+				cfg.Order.Currency = "USD"
+				cfg.Order.Tax.Inclusive = true
+			},
+		},
+		{
+			name: "Rates Read From Rates File",
+			env:  map[string]string{"ORDER_RATES_FILE": ratesFile},
+			expectedOutput: func(cfg *Config) {
+				cfg.Order.RatesFile = ratesFile
+				cfg.Order.Rates = map[string]float64{"USD": 0.012, "EUR": 0.011}
+			},
+		},
+		{
+			name:        "Missing Rates File",
+			env:         map[string]string{"ORDER_RATES_FILE": filepath.Join(t.TempDir(), "missing.yaml")},
+			expectedErr: true,
+		},
+		{
+			name:        "Missing File",
+			path:        filepath.Join(t.TempDir(), "missing.yaml"),
+			expectedErr: true,
+		},
+		{
+			name:        "Unknown Field",
+			path:        unknownFieldFile,
+			expectedErr: true,
+		},
+		{
+			name:        "Malformed Environment Value",
+			env:         map[string]string{"ORDER_MAX_PRODUCT_QUANTITY": "ten"},
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid Setting",
+			env:         map[string]string{"DB_DRIVER": "mysql"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load(test.path)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			expected := Default()
+			test.expectedOutput(&expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		update      func(cfg *Config)
+		expectedErr bool
+	}{
+		{name: "Defaults Are Valid", update: func(cfg *Config) {}},
+		{name: "Port Out Of Range", update: func(cfg *Config) { cfg.Server.Port = 70000 }, expectedErr: true},
+		{name: "Empty DSN", update: func(cfg *Config) { cfg.Database.DSN = "" }, expectedErr: true},
+		{name: "Discount Above 100", update: func(cfg *Config) { cfg.Order.DiscountPercentage = 120 }, expectedErr: true},
+		{name: "Zero Max Quantity", update: func(cfg *Config) { cfg.Order.MaxProductQuantity = 0 }, expectedErr: true},
+		{name: "Unknown Currency", update: func(cfg *Config) { cfg.Order.Currency = "RUPEE" }, expectedErr: true},
+		{name: "Rate Of Unknown Currency", update: func(cfg *Config) { cfg.Order.Rates = map[string]float64{"DOLLAR": 0.012} }, expectedErr: true},
+		{name: "Rate Of Order Currency", update: func(cfg *Config) { cfg.Order.Rates = map[string]float64{"INR": 1} }, expectedErr: true},
+		{name: "Zero Rate", update: func(cfg *Config) { cfg.Order.Rates = map[string]float64{"USD": 0} }, expectedErr: true},
+		{name: "Valid Rates", update: func(cfg *Config) { cfg.Order.Rates = map[string]float64{"USD": 0.012, "JPY": 1.78} }},
+		{name: "Unknown Default Tax Region", update: func(cfg *Config) { cfg.Order.Tax.DefaultRegion = "IN-MH" }, expectedErr: true},
+		{name: "Tax Regions Without Default Region", update: func(cfg *Config) {
+			cfg.Order.Tax.Regions = map[string]map[string]float64{"IN-MH": {"standard": 18}}
+		}, expectedErr: true},
+		{name: "Lower Case Tax Region", update: func(cfg *Config) {
+			cfg.Order.Tax.DefaultRegion = "in-mh"
+			cfg.Order.Tax.Regions = map[string]map[string]float64{"in-mh": {"standard": 18}}
+		}, expectedErr: true},
+		{name: "Tax Percent Above 100", update: func(cfg *Config) {
+			cfg.Order.Tax.DefaultRegion = "IN-MH"
+			cfg.Order.Tax.Regions = map[string]map[string]float64{"IN-MH": {"standard": 180}}
+		}, expectedErr: true},
+		{name: "Empty Tax Class", update: func(cfg *Config) { cfg.Order.Tax.Classes = map[string]string{"Budget": ""} }, expectedErr: true},
+		{name: "Valid Taxes", update: func(cfg *Config) {
+			cfg.Order.Tax = TaxConfig{
+				DefaultRegion: "IN-MH",
+				Classes:       map[string]string{"Budget": "reduced"},
+				Regions:       map[string]map[string]float64{"IN-MH": {"standard": 18, "reduced": 5}, "IN": {"standard": 18}},
+			}
+		}},
+		{name: "Unknown Shipping Method Type", update: func(cfg *Config) {
+			cfg.Order.Shipping.Methods = []ShippingMethodConfig{{Name: "standard", Type: "distance"}}
+		}, expectedErr: true},
+		{name: "Duplicate Shipping Method Names", update: func(cfg *Config) {
+			cfg.Order.Shipping.Methods = []ShippingMethodConfig{{Name: "standard", Type: "flat"}, {Name: "standard", Type: "weight"}}
+		}, expectedErr: true},
+		{name: "Negative Shipping Amount", update: func(cfg *Config) {
+			cfg.Order.Shipping.Methods = []ShippingMethodConfig{{Name: "standard", Type: "flat", Amount: -100}}
+		}, expectedErr: true},
+		{name: "Unknown Default Shipping Method", update: func(cfg *Config) { cfg.Order.Shipping.DefaultMethod = "standard" }, expectedErr: true},
+		{name: "Valid Shipping Methods", update: func(cfg *Config) {
+			cfg.Order.Shipping = ShippingConfig{
+				DefaultMethod: "standard",
+				Methods: []ShippingMethodConfig{
+					{Name: "standard", Type: "flat", Amount: 4900, FreeAbove: 99900},
+					{Name: "express", Type: "weight", Amount: 9900, PerKg: 2000, VolumetricDivisor: 5000},
+				},
+			}
+		}},
+		{name: "Zero Webhook Attempts", update: func(cfg *Config) { cfg.Webhook.MaxAttempts = 0 }, expectedErr: true},
+		{name: "Zero Reservation TTL", update: func(cfg *Config) { cfg.Inventory.ReservationTTL = 0 }, expectedErr: true},
+		{name: "Unknown Low Stock Notifier", update: func(cfg *Config) { cfg.Inventory.LowStockNotifiers = "log,sms" }, expectedErr: true},
+		{name: "Unknown Promotion Type", update: func(cfg *Config) {
+			cfg.Order.Promotions = []PromotionConfig{{Name: "summer", Type: "lottery"}}
+		}, expectedErr: true},
+		{name: "Duplicate Promotion Names", update: func(cfg *Config) {
+			cfg.Order.Promotions = []PromotionConfig{
+				{Name: "summer", Type: "fixed", Amount: 10000},
+				{Name: "summer", Type: "percentage", Percent: 5},
+			}
+		}, expectedErr: true},
+		{name: "Tiered Promotion Without Tiers", update: func(cfg *Config) {
+			cfg.Order.Promotions = []PromotionConfig{{Name: "spend_more", Type: "tiered"}}
+		}, expectedErr: true},
+		{name: "Unknown Promotion Discount Scope", update: func(cfg *Config) {
+			cfg.Order.Promotions = []PromotionConfig{{Name: "summer", Type: "percentage", Percent: 5, DiscountScope: "cart"}}
+		}, expectedErr: true},
+		{name: "Valid Promotions", update: func(cfg *Config) {
+			cfg.Order.Promotions = []PromotionConfig{
+				{Name: "budget_3_for_2", Type: "buy_x_get_y", Category: "Budget", BuyQuantity: 2, GetQuantity: 1},
+				{Name: "premium_order", Type: "percentage", Category: "Premium", DiscountScope: "order", MinItems: 3, Percent: 10},
+				{Name: "spend_more", Type: "tiered", Stackable: true, Tiers: []PromotionTier{{MinAmount: 500000, Percent: 5}}},
+			}
+		}},
+		{name: "Unknown Outbox Sink", update: func(cfg *Config) { cfg.Outbox.Sinks = "log,kafka" }, expectedErr: true},
+		{name: "File Sink Without Path", update: func(cfg *Config) { cfg.Outbox.Sinks = "file" }, expectedErr: true},
+		{name: "Webhook Sink With URL", update: func(cfg *Config) {
+			cfg.Outbox.Sinks = "log, webhook"
+			cfg.Outbox.WebhookURL = "http://localhost:9000/events"
+		}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := Default()
+			test.update(&cfg)
+
+			err := cfg.Validate()
+			assert.Equal(t, test.expectedErr, err != nil)
+		})
+	}
+}
+
+func TestOrderConfigRate(t *testing.T) {
+	cfg := Default().Order
+	cfg.Rates = map[string]float64{"USD": 0.012}
+
+	rate, ok := cfg.Rate("INR")
+	assert.True(t, ok)
+	assert.Equal(t, money.Identity("INR"), rate)
+
+	rate, ok = cfg.Rate("USD")
+	assert.True(t, ok)
+	assert.Equal(t, money.Rate{From: "INR", To: "USD", Value: 0.012}, rate)
+
+	_, ok = cfg.Rate("EUR")
+	assert.False(t, ok)
+}
+
+func TestTaxConfigClass(t *testing.T) {
+	cfg := TaxConfig{Classes: map[string]string{"Budget": "reduced"}}
+
+	assert.Equal(t, "reduced", cfg.Class("Budget"))
+	assert.Equal(t, "standard", cfg.Class("Premium"))
+}
+
+func TestShippingConfigMethod(t *testing.T) {
+	cfg := ShippingConfig{Methods: []ShippingMethodConfig{{Name: "standard", Type: "flat", Amount: 4900}}}
+
+	method, ok := cfg.Method("standard")
+	assert.True(t, ok)
+	assert.Equal(t, int64(4900), method.Amount)
+
+	_, ok = cfg.Method("express")
+	assert.False(t, ok)
+}
@@ -1,5 +1,49 @@
 package constants
 
+// supported database drivers, selected with the database.driver setting or the DB_DRIVER environment variable
 const (
-	HTTPPort = 8080
+	DBDriverBolt     = "bolt"
+	DBDriverSQLite   = "sqlite"
+	DBDriverPostgres = "postgres"
+)
+
+// sinks the outbox relay delivers domain events to, listed in the outbox.sinks setting or the OUTBOX_SINKS environment variable
+const (
+	EventSinkLog     = "log"
+	EventSinkFile    = "file"
+	EventSinkWebhook = "webhook"
+)
+
+// notifiers told about products running low on stock, listed in the inventory.low_stock_notifiers setting
+// or the INVENTORY_LOW_STOCK_NOTIFIERS environment variable
+const (
+	LowStockNotifierLog   = "log"
+	LowStockNotifierEvent = "event"
+)
+
+// types of the promotions priced by the pricing engine, set as the type of each order.promotions entry
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+	PromotionBuyXGetY   = "buy_x_get_y"
+	PromotionTiered     = "tiered"
+)
+
+// scopes promotions take their discount off once their category qualifies them, set as the discount_scope
+// of each order.promotions entry
+const (
+	DiscountScopeLines = "lines"
+	DiscountScopeOrder = "order"
+)
+
+// DiscountCoupon is the type of the discounts coupon codes take off orders, listed in the discount breakdown of orders
+const DiscountCoupon = "coupon"
+
+// TaxClassStandard is the tax class of the product categories the order.tax.classes setting maps to no other class
+const TaxClassStandard = "standard"
+
+// rate types of the shipping methods, set as the type of each order.shipping.methods entry
+const (
+	ShippingRateFlat   = "flat"
+	ShippingRateWeight = "weight"
 )
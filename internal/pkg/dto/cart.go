@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+type Cart struct {
+	ID                 int64             `json:"id"`
+	Status             string            `json:"status"`
+	Items              []CartItem        `json:"items"`
+	Amount             money.Money       `json:"amount"`
+	DiscountPercentage float64           `json:"discount_percent"`
+	Discounts          []AppliedDiscount `json:"discounts,omitempty"`
+	FinalAmount        money.Money       `json:"final_amount"`
+	OrderID            int64             `json:"order_id,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+type CartItem struct {
+	ProductID int64       `json:"product_id"`
+	Name      string      `json:"name"`
+	Category  string      `json:"category"`
+	Price     money.Money `json:"price"`
+	Quantity  int64       `json:"quantity"`
+	LineTotal money.Money `json:"line_total"`
+}
+
+type AddCartItemRequest struct {
+	ProductID int64 `json:"product_id"`
+	Quantity  int64 `json:"quantity"`
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int64 `json:"quantity"`
+}
+
+func (req *AddCartItemRequest) Validate() error {
+	if req.ProductID <= 0 {
+		return errors.New("product_id cannot be empty")
+	}
+
+	if req.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	return nil
+}
+
+func (req *UpdateCartItemRequest) Validate() error {
+	if req.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	return nil
+}
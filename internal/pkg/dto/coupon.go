@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/constants"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// couponCodePattern accepts codes of letters, digits, dashes and underscores, codes are stored upper case
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Coupon is returned with its usage, zero limits are unlimited and a missing bound leaves the validity window open
+type Coupon struct {
+	ID               int64        `json:"id"`
+	Code             string       `json:"code"`
+	DiscountType     string       `json:"discount_type"`
+	Percent          float64      `json:"percent,omitempty"`
+	Amount           *money.Money `json:"amount,omitempty"`
+	Categories       []string     `json:"categories"`
+	ValidFrom        *time.Time   `json:"valid_from,omitempty"`
+	ValidUntil       *time.Time   `json:"valid_until,omitempty"`
+	UsageLimit       int64        `json:"usage_limit"`
+	PerCustomerLimit int64        `json:"per_customer_limit"`
+	TimesUsed        int64        `json:"times_used"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type CreateCouponRequest struct {
+	Code string `json:"code"`
+	//DiscountType is percentage, taking Percent off the eligible products, or fixed, taking Amount off them
+	DiscountType string       `json:"discount_type"`
+	Percent      float64      `json:"percent"`
+	Amount       *money.Money `json:"amount"`
+	//Categories the coupon applies to, every product when empty
+	Categories       []string   `json:"categories"`
+	ValidFrom        *time.Time `json:"valid_from"`
+	ValidUntil       *time.Time `json:"valid_until"`
+	UsageLimit       int64      `json:"usage_limit"`
+	PerCustomerLimit int64      `json:"per_customer_limit"`
+}
+
+// NormalizeCouponCode makes coupon codes case insensitive
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (req *CreateCouponRequest) Validate() error {
+	req.Code = NormalizeCouponCode(req.Code)
+	if !couponCodePattern.MatchString(req.Code) {
+		return errors.New("code must be 3 to 32 letters, digits, dashes or underscores")
+	}
+
+	switch req.DiscountType {
+	case constants.PromotionPercentage:
+		if req.Percent <= 0 || req.Percent > 100 || req.Amount != nil {
+			return errors.New("a percentage coupon takes a percent above 0 and at most 100 and no amount")
+		}
+	case constants.PromotionFixed:
+		if req.Amount == nil || req.Percent != 0 {
+			return errors.New("a fixed coupon takes an amount and no percent")
+		}
+
+		err := req.Amount.Validate()
+		if err != nil {
+			return fmt.Errorf("amount: %w", err)
+		}
+
+		if !req.Amount.IsPositive() {
+			return errors.New("amount of a fixed coupon must be positive")
+		}
+	default:
+		return fmt.Errorf("discount_type must be %s or %s", constants.PromotionPercentage, constants.PromotionFixed)
+	}
+
+	if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidFrom.Before(*req.ValidUntil) {
+		return errors.New("valid_from must be before valid_until")
+	}
+
+	if req.UsageLimit < 0 {
+		return errors.New("usage_limit cannot be negative")
+	}
+
+	if req.PerCustomerLimit < 0 {
+		return errors.New("per_customer_limit cannot be negative")
+	}
+
+	//drop repeated categories, keeping the order they were sent in
+	seen := make(map[string]bool)
+	categories := make([]string, 0, len(req.Categories))
+	for _, category := range req.Categories {
+		category = strings.TrimSpace(category)
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	req.Categories = categories
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type Customer struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Addresses []Address `json:"addresses"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Address struct {
+	Label      string `json:"label,omitempty"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+type CreateCustomerRequest struct {
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Addresses []Address `json:"addresses"`
+}
+
+// UpdateCustomerRequest only changes the fields present in the request,
+// addresses replace the whole address list of the customer
+type UpdateCustomerRequest struct {
+	Name      *string    `json:"name"`
+	Addresses *[]Address `json:"addresses"`
+}
+
+func (req *CreateCustomerRequest) Validate() error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	email, err := mail.ParseAddress(req.Email)
+	if err != nil || email.Address != strings.TrimSpace(req.Email) {
+		return errors.New("email is not a valid email address")
+	}
+
+	//emails are matched case insensitively
+	req.Email = strings.ToLower(email.Address)
+
+	return validateAddresses(req.Addresses)
+}
+
+func (req *UpdateCustomerRequest) Validate() error {
+	if req.Name == nil && req.Addresses == nil {
+		return errors.New("no fields to update")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return errors.New("name cannot be empty")
+		}
+		req.Name = &name
+	}
+
+	if req.Addresses != nil {
+		return validateAddresses(*req.Addresses)
+	}
+
+	return nil
+}
+
+func validateAddresses(addresses []Address) error {
+	for i, address := range addresses {
+		if !address.complete() {
+			return fmt.Errorf("invalid request, address at index %d requires line1, city, postal_code and country", i)
+		}
+	}
+
+	return nil
+}
+
+// complete reports whether an order can be delivered to the address
+func (address Address) complete() bool {
+	return address.Line1 != "" && address.City != "" && address.PostalCode != "" && address.Country != ""
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// Event is the envelope delivered to the event sinks, ID is unique per event
+// so consumers can drop the duplicates of an at-least-once delivery
+type Event struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID int64           `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+type OrderPlacedEvent struct {
+	OrderID            int64             `json:"order_id"`
+	CustomerID         int64             `json:"customer_id"`
+	Products           []ProductInfo     `json:"products"`
+	Amount             money.Money       `json:"amount"`
+	DiscountPercentage float64           `json:"discount_percent"`
+	Discounts          []AppliedDiscount `json:"discounts,omitempty"`
+	TaxAmount          money.Money       `json:"tax_amount"`
+	ShippingMethod     string            `json:"shipping_method,omitempty"`
+	ShippingAmount     money.Money       `json:"shipping_amount"`
+	FinalAmount        money.Money       `json:"final_amount"`
+	ExchangeRate       money.Rate        `json:"exchange_rate"`
+	Status             string            `json:"status"`
+}
+
+type OrderStatusChangedEvent struct {
+	OrderID    int64  `json:"order_id"`
+	CustomerID int64  `json:"customer_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"`
+	ActorRole  string `json:"actor_role"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// StockAdjustedEvent reports a change of the stock of a product by Delta units, leaving Quantity in stock
+type StockAdjustedEvent struct {
+	ProductID int64  `json:"product_id"`
+	Delta     int64  `json:"delta"`
+	Quantity  int64  `json:"quantity"`
+	Reason    string `json:"reason"`
+	OrderID   int64  `json:"order_id,omitempty"`
+}
@@ -3,21 +3,60 @@ package dto
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
 )
 
+// Order amounts are in the currency the order was placed in, ExchangeRate converted the catalog prices into it.
+// Items break the order down by line as it was sold, orders placed before lines were recorded have none.
+// TaxAmount is charged after the discounts in TaxRegion, the region of the shipping address,
+// FinalAmount holds it and so does Amount when TaxInclusive.
+// ShippingAmount is charged by ShippingMethod to deliver the order to ShippingAddress, FinalAmount holds it untaxed.
 type Order struct {
-	ID                 int64         `json:"id"`
-	Products           []ProductInfo `json:"products,omitempty"`
-	Amount             float64       `json:"amount"`
-	DiscountPercentage float64       `json:"discount_percent"`
-	FinalAmount        float64       `json:"final_amount"`
-	Status             string        `json:"status"`
-	DispatchedAt       *time.Time    `json:"dispatched_at,omitempty"`
-	CreatedAt          time.Time     `json:"created_at"`
-	UpdatedAt          time.Time     `json:"updated_at"`
+	ID                 int64             `json:"id"`
+	CustomerID         int64             `json:"customer_id,omitempty"`
+	Products           []ProductInfo     `json:"products,omitempty"`
+	Items              []OrderItem       `json:"items,omitempty"`
+	Amount             money.Money       `json:"amount"`
+	DiscountPercentage float64           `json:"discount_percent"`
+	Discounts          []AppliedDiscount `json:"discounts,omitempty"`
+	TaxAmount          money.Money       `json:"tax_amount"`
+	TaxRegion          string            `json:"tax_region,omitempty"`
+	TaxInclusive       bool              `json:"tax_inclusive"`
+	ShippingMethod     string            `json:"shipping_method,omitempty"`
+	ShippingAmount     money.Money       `json:"shipping_amount"`
+	ShippingAddress    *Address          `json:"shipping_address,omitempty"`
+	FinalAmount        money.Money       `json:"final_amount"`
+	ExchangeRate       money.Rate        `json:"exchange_rate"`
+	Status             string            `json:"status"`
+	DispatchedAt       *time.Time        `json:"dispatched_at,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// AppliedDiscount is the Amount a promotion took off an order or cart
+type AppliedDiscount struct {
+	Promotion string      `json:"promotion"`
+	Type      string      `json:"type"`
+	Amount    money.Money `json:"amount"`
+}
+
+// OrderItem is an ordered product at the price it was sold, Discount is its share of the order discounts
+// and Tax is charged on what is left at TaxRate percent
+type OrderItem struct {
+	ProductID   int64       `json:"product_id"`
+	Name        string      `json:"name"`
+	Category    string      `json:"category"`
+	Price       money.Money `json:"price"`
+	Quantity    int64       `json:"quantity"`
+	LineTotal   money.Money `json:"line_total"`
+	Discount    money.Money `json:"discount"`
+	TaxRate     float64     `json:"tax_rate"`
+	Tax         money.Money `json:"tax"`
+	FinalAmount money.Money `json:"final_amount"`
 }
 
 type ProductInfo struct {
@@ -26,12 +65,67 @@ type ProductInfo struct {
 }
 
 type CreateOrderRequest struct {
-	Products []ProductInfo `json:"products"`
+	//CustomerID is set from the calling customer, never from the request body
+	CustomerID int64 `json:"-"`
+	//IdempotencyKey is set from the Idempotency-Key header, repeats of a keyed request replay the first response
+	IdempotencyKey string        `json:"-"`
+	Products       []ProductInfo `json:"products"`
+	//CouponCode is redeemed by the order, codes are case insensitive
+	CouponCode string `json:"coupon_code,omitempty"`
+	//Currency the order is placed in, the catalog currency when empty
+	Currency string `json:"currency,omitempty"`
+	//ShippingMethod the order is delivered with, the default shipping method when empty
+	ShippingMethod string `json:"shipping_method,omitempty"`
+	//ShippingAddress the order is delivered to and taxed at, the first address of the customer when empty
+	ShippingAddress *Address `json:"shipping_address,omitempty"`
+}
+
+// ListOrdersRequest holds the filters, sort and page of an order listing, nil filters are not applied.
+// MinAmount and MaxAmount bound the final amount in minor units of Currency, which they and sorting by
+// the final amount require since amounts of orders placed in different currencies cannot be compared.
+type ListOrdersRequest struct {
+	Statuses    []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Currency    string
+	MinAmount   *int64
+	MaxAmount   *int64
+	SortBy      string
+	SortDesc    bool
+	Page        int
+	PageSize    int
+}
+
+type OrderList struct {
+	Orders     []Order    `json:"orders"`
+	Pagination Pagination `json:"pagination"`
 }
 
 type UpdateOrderStatusRequest struct {
 	OrderID int64  `json:"order_id"`
 	Status  string `json:"status"`
+	//Reason is kept in the order history, e.g. why the order was cancelled
+	Reason string `json:"reason,omitempty"`
+}
+
+// MaxStatusReasonLength caps the reason stored with every status transition
+const MaxStatusReasonLength = 500
+
+// OrderHistory lists the status transitions of an order, oldest first
+type OrderHistory struct {
+	OrderID   int64              `json:"order_id"`
+	Status    string             `json:"status"`
+	CreatedAt time.Time          `json:"created_at"`
+	Events    []OrderStatusEvent `json:"events"`
+}
+
+type OrderStatusEvent struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	ActorRole  string    `json:"actor_role"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 func (req *CreateOrderRequest) Validate() error {
@@ -40,6 +134,18 @@ func (req *CreateOrderRequest) Validate() error {
 		return apperrors.ErrNoProductsToOrder
 	}
 
+	req.CouponCode = NormalizeCouponCode(req.CouponCode)
+
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	if req.Currency != "" && !money.IsSupported(req.Currency) {
+		return fmt.Errorf("invalid request, unsupported currency : %s", req.Currency)
+	}
+
+	req.ShippingMethod = strings.ToLower(strings.TrimSpace(req.ShippingMethod))
+	if req.ShippingAddress != nil && !req.ShippingAddress.complete() {
+		return errors.New("invalid request, shipping_address requires line1, city, postal_code and country")
+	}
+
 	//map[ProductID]bool
 	productMap := make(map[int64]bool)
 	for _, p := range req.Products {
@@ -66,5 +172,38 @@ func (req *UpdateOrderStatusRequest) Validate() error {
 		return errors.New("status cannot be empty")
 	}
 
+	if len(req.Reason) > MaxStatusReasonLength {
+		return fmt.Errorf("reason cannot be longer than %d characters", MaxStatusReasonLength)
+	}
+
+	return nil
+}
+
+func (req *ListOrdersRequest) Validate() error {
+	err := validatePage(req.Page, req.PageSize)
+	if err != nil {
+		return err
+	}
+
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return errors.New("created_from must be before created_to")
+	}
+
+	if req.Currency != "" && !money.IsSupported(req.Currency) {
+		return fmt.Errorf("invalid request, unsupported currency : %s", req.Currency)
+	}
+
+	if req.Currency == "" && (req.MinAmount != nil || req.MaxAmount != nil || req.SortBy == "final_amount") {
+		return errors.New("currency is required with min_amount, max_amount and sort_by=final_amount")
+	}
+
+	if req.MinAmount != nil && *req.MinAmount < 0 {
+		return errors.New("min_amount cannot be negative")
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return errors.New("min_amount cannot be greater than max_amount")
+	}
+
 	return nil
 }
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int64 `json:"total_pages"`
+}
+
+// NewPagination describes the requested page of a listing holding totalItems items
+func NewPagination(page, pageSize int, totalItems int64) Pagination {
+	pagination := Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: totalItems,
+	}
+
+	if pageSize > 0 {
+		pagination.TotalPages = (totalItems + int64(pageSize) - 1) / int64(pageSize)
+	}
+
+	return pagination
+}
+
+func validatePage(page, pageSize int) error {
+	if page < 1 {
+		return errors.New("page must be greater than 0")
+	}
+
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return fmt.Errorf("page_size must be between 1 and %d", MaxPageSize)
+	}
+
+	return nil
+}
@@ -1,17 +1,266 @@
 package dto
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// Product reports Quantity on hand, Reserved held by placed orders and
+// AvailableToSell, the units new orders can still take. WeightGrams and Dimensions, when known, price shipping by weight.
 type Product struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Price     float64   `json:"price"`
-	Category  string    `json:"category"`
-	Quantity  int64     `json:"quantity"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID    int64       `json:"id"`
+	Name  string      `json:"name"`
+	Price money.Money `json:"price"`
+	//Prices are set in other currencies, ordered by currency code
+	Prices          []money.Money `json:"prices,omitempty"`
+	Category        string        `json:"category"`
+	Quantity        int64         `json:"quantity"`
+	Reserved        int64         `json:"reserved"`
+	AvailableToSell int64         `json:"available_to_sell"`
+	//ReorderThreshold of 0 leaves the product out of low stock alerts
+	ReorderThreshold int64       `json:"reorder_threshold"`
+	ReorderQuantity  int64       `json:"reorder_quantity"`
+	WeightGrams      int64       `json:"weight_grams"`
+	Dimensions       *Dimensions `json:"dimensions,omitempty"`
+	Archived         bool        `json:"archived,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// PriceIn returns the price of the product in the currency the rate converts into,
+// its own price in that currency when it has one and Price converted at the rate otherwise.
+// Price must be in the currency the rate converts from.
+func (p Product) PriceIn(rate money.Rate) money.Money {
+	for _, price := range p.Prices {
+		if price.Currency == rate.To {
+			return price
+		}
+	}
+
+	return rate.Convert(p.Price)
 }
 
 type ProductList struct {
-	Products []Product `json:"products"`
+	Products   []Product  `json:"products"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// ListProductsRequest holds the filters, sort and page of a catalog search, empty filters are not applied
+type ListProductsRequest struct {
+	Category string
+	//MinPrice and MaxPrice are in minor units of the catalog currency
+	MinPrice *int64
+	MaxPrice *int64
+	InStock  bool
+	//Query matches anywhere in the product name, NamePrefix only at its start
+	Query      string
+	NamePrefix string
+	SortBy     string
+	SortDesc   bool
+	Page       int
+	PageSize   int
+}
+
+type CreateProductRequest struct {
+	Name  string      `json:"name"`
+	Price money.Money `json:"price"`
+	//Prices set the price in other currencies than Price, orders in the others convert Price
+	Prices           []money.Money `json:"prices"`
+	Category         string        `json:"category"`
+	Quantity         int64         `json:"quantity"`
+	ReorderThreshold int64         `json:"reorder_threshold"`
+	ReorderQuantity  int64         `json:"reorder_quantity"`
+	WeightGrams      int64         `json:"weight_grams"`
+	Dimensions       *Dimensions   `json:"dimensions"`
+}
+
+// UpdateProductRequest holds the fields to change on a product,
+// nil fields are left untouched
+type UpdateProductRequest struct {
+	Name  *string      `json:"name"`
+	Price *money.Money `json:"price"`
+	//Prices replace every price in other currencies, an empty list removes them
+	Prices           *[]money.Money `json:"prices"`
+	Category         *string        `json:"category"`
+	Quantity         *int64         `json:"quantity"`
+	ReorderThreshold *int64         `json:"reorder_threshold"`
+	ReorderQuantity  *int64         `json:"reorder_quantity"`
+	WeightGrams      *int64         `json:"weight_grams"`
+	//Dimensions of all zeros remove them
+	Dimensions *Dimensions `json:"dimensions"`
+}
+
+func (req *CreateProductRequest) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	err := validatePrice(req.Price)
+	if err != nil {
+		return err
+	}
+
+	err = validatePrices(req.Prices)
+	if err != nil {
+		return err
+	}
+
+	if req.Category == "" {
+		return errors.New("category cannot be empty")
+	}
+
+	if req.Quantity < 0 {
+		return errors.New("quantity cannot be negative")
+	}
+
+	err = validateShippingSettings(&req.WeightGrams, req.Dimensions)
+	if err != nil {
+		return err
+	}
+
+	return validateReorderSettings(&req.ReorderThreshold, &req.ReorderQuantity)
+}
+
+// ToUpdateRequest converts a full product payload into an update
+// request that replaces every editable field
+func (req *CreateProductRequest) ToUpdateRequest() UpdateProductRequest {
+	//a payload without dimensions removes them
+	dimensions := Dimensions{}
+	if req.Dimensions != nil {
+		dimensions = *req.Dimensions
+	}
+
+	return UpdateProductRequest{
+		Name:             &req.Name,
+		Price:            &req.Price,
+		Prices:           &req.Prices,
+		Category:         &req.Category,
+		Quantity:         &req.Quantity,
+		ReorderThreshold: &req.ReorderThreshold,
+		ReorderQuantity:  &req.ReorderQuantity,
+		WeightGrams:      &req.WeightGrams,
+		Dimensions:       &dimensions,
+	}
+}
+
+func (req *UpdateProductRequest) Validate() error {
+	if req.Name == nil && req.Price == nil && req.Prices == nil && req.Category == nil && req.Quantity == nil &&
+		req.ReorderThreshold == nil && req.ReorderQuantity == nil && req.WeightGrams == nil && req.Dimensions == nil {
+		return errors.New("no fields to update")
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	if req.Price != nil {
+		err := validatePrice(*req.Price)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Prices != nil {
+		err := validatePrices(*req.Prices)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Category != nil && *req.Category == "" {
+		return errors.New("category cannot be empty")
+	}
+
+	if req.Quantity != nil && *req.Quantity < 0 {
+		return errors.New("quantity cannot be negative")
+	}
+
+	err := validateShippingSettings(req.WeightGrams, req.Dimensions)
+	if err != nil {
+		return err
+	}
+
+	return validateReorderSettings(req.ReorderThreshold, req.ReorderQuantity)
+}
+
+func validatePrice(price money.Money) error {
+	err := price.Validate()
+	if err != nil {
+		return fmt.Errorf("price: %w", err)
+	}
+
+	if !price.IsPositive() {
+		return errors.New("price must be greater than zero")
+	}
+
+	return nil
+}
+
+// validatePrices checks the prices in other currencies, at most one per currency
+func validatePrices(prices []money.Money) error {
+	currencies := make(map[string]bool)
+	for _, price := range prices {
+		err := validatePrice(price)
+		if err != nil {
+			return fmt.Errorf("prices: %w", err)
+		}
+
+		if currencies[price.Currency] {
+			return fmt.Errorf("prices: more than one price in %s", price.Currency)
+		}
+		currencies[price.Currency] = true
+	}
+
+	return nil
+}
+
+// validateShippingSettings checks the weight and dimensions which are set, dimensions are all given or all zero
+func validateShippingSettings(weightGrams *int64, dimensions *Dimensions) error {
+	if weightGrams != nil && *weightGrams < 0 {
+		return errors.New("weight_grams cannot be negative")
+	}
+
+	if dimensions == nil || *dimensions == (Dimensions{}) {
+		return nil
+	}
+
+	if dimensions.LengthMM <= 0 || dimensions.WidthMM <= 0 || dimensions.HeightMM <= 0 {
+		return errors.New("dimensions need a positive length_mm, width_mm and height_mm")
+	}
+
+	return nil
+}
+
+// validateReorderSettings checks the reorder settings which are set
+func validateReorderSettings(threshold, quantity *int64) error {
+	if threshold != nil && *threshold < 0 {
+		return errors.New("reorder_threshold cannot be negative")
+	}
+
+	if quantity != nil && *quantity < 0 {
+		return errors.New("reorder_quantity cannot be negative")
+	}
+
+	return nil
+}
+
+func (req *ListProductsRequest) Validate() error {
+	err := validatePage(req.Page, req.PageSize)
+	if err != nil {
+		return err
+	}
+
+	if req.MinPrice != nil && *req.MinPrice < 0 {
+		return errors.New("min_price cannot be negative")
+	}
+
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return errors.New("min_price cannot be greater than max_price")
+	}
+
+	return nil
 }
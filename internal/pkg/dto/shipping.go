@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+
+// ShippingMethod is a method of the shipping catalog priced in the currency orders are placed in.
+// Weight methods charge PerKg on top of Amount for every started kilogram, orders reaching FreeAbove ship free.
+type ShippingMethod struct {
+	Name         string       `json:"name"`
+	Type         string       `json:"type"`
+	Amount       money.Money  `json:"amount"`
+	PerKg        *money.Money `json:"per_kg,omitempty"`
+	FreeAbove    *money.Money `json:"free_above,omitempty"`
+	DeliveryDays int          `json:"delivery_days,omitempty"`
+	Default      bool         `json:"default,omitempty"`
+}
+
+// Dimensions of a product are in millimetres
+type Dimensions struct {
+	LengthMM int64 `json:"length_mm"`
+	WidthMM  int64 `json:"width_mm"`
+	HeightMM int64 `json:"height_mm"`
+}
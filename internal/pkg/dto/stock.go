@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxInventoryAdjustments is the most lines one inventory adjustment request may hold
+const MaxInventoryAdjustments = 100
+
+// StockAdjustment changes the stock on hand of a product by Delta units, negative deltas take stock
+type StockAdjustment struct {
+	ProductID int64
+	Delta     int64
+	Reason    string
+	OrderID   int64
+}
+
+// StockMovement is one entry of the stock ledger of a product
+type StockMovement struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	Delta         int64     `json:"delta"`
+	QuantityAfter int64     `json:"quantity_after"`
+	Reason        string    `json:"reason"`
+	OrderID       int64     `json:"order_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RestockProductRequest adds Quantity newly arrived units to the stock of a product
+type RestockProductRequest struct {
+	Quantity int64 `json:"quantity"`
+}
+
+// InventoryAdjustmentRequest holds the lines of a bulk adjustment, applied all together or not at all
+type InventoryAdjustmentRequest struct {
+	Adjustments []InventoryAdjustmentLine `json:"adjustments"`
+}
+
+type InventoryAdjustmentLine struct {
+	ProductID int64  `json:"product_id"`
+	Delta     int64  `json:"delta"`
+	Reason    string `json:"reason"`
+}
+
+type InventoryAdjustmentList struct {
+	Adjustments []StockAdjustedEvent `json:"adjustments"`
+}
+
+type StockMovementList struct {
+	Movements  []StockMovement `json:"movements"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+type ListStockMovementsRequest struct {
+	ProductID int64
+	Page      int
+	PageSize  int
+}
+
+// StockReconciliation lists the products whose quantity on hand differs from the sum of their ledger
+type StockReconciliation struct {
+	CheckedProducts int             `json:"checked_products"`
+	Mismatches      []StockMismatch `json:"mismatches"`
+}
+
+type StockMismatch struct {
+	ProductID      int64 `json:"product_id"`
+	Quantity       int64 `json:"quantity"`
+	LedgerQuantity int64 `json:"ledger_quantity"`
+}
+
+// LowStockProduct is a product whose stock available to sell is at or below its reorder threshold,
+// it is both a line of the low stock report and the payload of a low stock notification
+type LowStockProduct struct {
+	ProductID        int64  `json:"product_id"`
+	Name             string `json:"name"`
+	Quantity         int64  `json:"quantity"`
+	Reserved         int64  `json:"reserved"`
+	AvailableToSell  int64  `json:"available_to_sell"`
+	ReorderThreshold int64  `json:"reorder_threshold"`
+	ReorderQuantity  int64  `json:"reorder_quantity"`
+}
+
+type LowStockReport struct {
+	Products []LowStockProduct `json:"products"`
+}
+
+func (req *ListStockMovementsRequest) Validate() error {
+	return validatePage(req.Page, req.PageSize)
+}
+
+func (req *RestockProductRequest) Validate() error {
+	if req.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	return nil
+}
+
+func (req *InventoryAdjustmentRequest) Validate() error {
+	if len(req.Adjustments) == 0 {
+		return errors.New("adjustments cannot be empty")
+	}
+
+	if len(req.Adjustments) > MaxInventoryAdjustments {
+		return fmt.Errorf("at most %d adjustments are allowed per request", MaxInventoryAdjustments)
+	}
+
+	for i, line := range req.Adjustments {
+		if line.ProductID <= 0 {
+			return fmt.Errorf("adjustments[%d]: product_id must be greater than zero", i)
+		}
+
+		if line.Delta == 0 {
+			return fmt.Errorf("adjustments[%d]: delta cannot be zero", i)
+		}
+
+		if line.Reason == "" {
+			return fmt.Errorf("adjustments[%d]: reason cannot be empty", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MinWebhookSecretLength is the shortest secret accepted for signing webhook deliveries
+const MinWebhookSecretLength = 16
+
+// WebhookSubscription is returned without its secret
+type WebhookSubscription struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error,omitempty"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// ListWebhookDeliveriesRequest holds the filters and page of a delivery listing, zero filters are not applied
+type ListWebhookDeliveriesRequest struct {
+	SubscriptionID int64
+	Status         string
+	Page           int
+	PageSize       int
+}
+
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+func (req *CreateWebhookSubscriptionRequest) Validate() error {
+	endpoint, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+	req.URL = endpoint.String()
+
+	if len(req.Secret) < MinWebhookSecretLength {
+		return fmt.Errorf("secret must be at least %d characters", MinWebhookSecretLength)
+	}
+
+	if len(req.EventTypes) == 0 {
+		return errors.New("event_types cannot be empty")
+	}
+
+	//drop repeated event types, keeping the order they were sent in
+	seen := make(map[string]bool)
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	req.EventTypes = eventTypes
+
+	return nil
+}
+
+func (req *ListWebhookDeliveriesRequest) Validate() error {
+	return validatePage(req.Page, req.PageSize)
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/apperrors"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/auth"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Authenticate stores the caller identified by the authenticator in the request context,
+// anonymous requests pass through and are rejected by RequireRole where needed
+func Authenticate(authenticator *auth.Authenticator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			principal, err := authenticator.Authenticate(r)
+			if errors.Is(err, auth.ErrNoCredentials) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err != nil {
+				logger.Warnw(ctx, "error occured while authenticating request", zap.Error(err))
+				ErrorResponse(ctx, w, http.StatusUnauthorized, apperrors.ErrUnauthenticated)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(ctx, principal)))
+		})
+	}
+}
+
+// RequireRole rejects anonymous callers and callers without one of the roles
+func RequireRole(roles ...auth.Role) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok {
+				ErrorResponse(ctx, w, http.StatusUnauthorized, apperrors.ErrUnauthenticated)
+				return
+			}
+
+			if !principal.HasRole(roles...) {
+				ErrorResponse(ctx, w, http.StatusForbidden, apperrors.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency prices the catalog unless ORDER_CURRENCY configures another one
+const DefaultCurrency = "INR"
+
+// exponents holds the number of minor unit digits of the supported ISO 4217 currencies
+var exponents = map[string]int{
+	"AED": 2,
+	"AUD": 2,
+	"BHD": 3,
+	"CAD": 2,
+	"CHF": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KWD": 3,
+	"SGD": 2,
+	"USD": 2,
+}
+
+// Money is an exact amount in the minor units of an ISO 4217 currency, {1050 INR} being 10.50 rupees.
+// Amounts are only added to or compared with amounts of the same currency.
+//
+// Rounding: every computation yielding a fraction of a minor unit, percentages and conversions
+// of decimal amounts, rounds half away from zero to the minor unit.
+type Money struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+func New(minorUnits int64, currency string) Money {
+	return Money{MinorUnits: minorUnits, Currency: currency}
+}
+
+// IsSupported reports whether amounts of the currency can be priced
+func IsSupported(currency string) bool {
+	_, ok := exponents[currency]
+	return ok
+}
+
+// FromMajor converts an amount in major units, such as the floats prices were stored as, to money.
+// It is rounded from its shortest decimal representation so 1.005 becomes 1.01 rather than the 1.00
+// its binary value would round to.
+func FromMajor(amount float64, currency string) (Money, error) {
+	exponent, ok := exponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, errors.New("amount must be a finite number")
+	}
+
+	decimal := strconv.FormatFloat(math.Abs(amount), 'f', -1, 64)
+	whole, fraction, _ := strings.Cut(decimal, ".")
+
+	//the digits past the minor unit only decide the rounding
+	roundUp := len(fraction) > exponent && fraction[exponent] >= '5'
+	if len(fraction) > exponent {
+		fraction = fraction[:exponent]
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	minorUnits, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("amount %v is out of range", amount)
+	}
+
+	if roundUp {
+		minorUnits++
+	}
+
+	if amount < 0 {
+		minorUnits = -minorUnits
+	}
+
+	return New(minorUnits, currency), nil
+}
+
+// MajorFloat returns the amount in major units, only for storage which keeps amounts as floats
+func (m Money) MajorFloat() float64 {
+	return float64(m.MinorUnits) / math.Pow10(exponents[m.Currency])
+}
+
+func (m Money) IsZero() bool {
+	return m.MinorUnits == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.MinorUnits > 0
+}
+
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return New(m.MinorUnits+other.MinorUnits, m.Currency)
+}
+
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other)
+	return New(m.MinorUnits-other.MinorUnits, m.Currency)
+}
+
+// Mul returns the amount of quantity units priced m
+func (m Money) Mul(quantity int64) Money {
+	return New(m.MinorUnits*quantity, m.Currency)
+}
+
+// Percent returns percent of the amount, the percent is taken to two decimals
+func (m Money) Percent(percent float64) Money {
+	basisPoints := int64(math.Round(percent * 100))
+	return New(divRound(m.MinorUnits*basisPoints, 100*100), m.Currency)
+}
+
+// IncludedPercent returns the part of the amount a percent added on top of a smaller amount makes up,
+// the tax included in a price taxed at percent. The percent is taken to two decimals.
+func (m Money) IncludedPercent(percent float64) Money {
+	basisPoints := int64(math.Round(percent * 100))
+	return New(divRound(m.MinorUnits*basisPoints, 100*100+basisPoints), m.Currency)
+}
+
+// Ratio returns the share of total m is, as a percent rounded to two decimals
+func (m Money) Ratio(total Money) float64 {
+	if total.IsZero() {
+		return 0
+	}
+
+	return float64(divRound(m.MinorUnits*100*100, total.MinorUnits)) / 100
+}
+
+// Cmp returns -1, 0 or 1 as m is less than, equal to or greater than other
+func (m Money) Cmp(other Money) int {
+	m.mustMatch(other)
+	switch {
+	case m.MinorUnits < other.MinorUnits:
+		return -1
+	case m.MinorUnits > other.MinorUnits:
+		return 1
+	}
+
+	return 0
+}
+
+// Min returns the smaller of the amounts
+func Min(a, b Money) Money {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+
+	return b
+}
+
+// Validate reports amounts of an unsupported currency
+func (m Money) Validate() error {
+	if !IsSupported(m.Currency) {
+		return fmt.Errorf("unsupported currency %q", m.Currency)
+	}
+
+	return nil
+}
+
+// String formats the amount in major units, e.g. INR 10.50
+func (m Money) String() string {
+	exponent := exponents[m.Currency]
+	sign := ""
+	minorUnits := m.MinorUnits
+	if minorUnits < 0 {
+		sign = "-"
+		minorUnits = -minorUnits
+	}
+
+	digits := strconv.FormatInt(minorUnits, 10)
+	if exponent == 0 {
+		return m.Currency + " " + sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent+1-len(digits)) + digits
+	}
+	return m.Currency + " " + sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// Rate converts amounts of From into To, Value being the amount of To one unit of From is worth,
+// {INR USD 0.012} pricing 100 rupees at 1.20 dollars. The value is taken to six decimals.
+type Rate struct {
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+	Value float64 `json:"rate"`
+}
+
+// Identity is the rate of amounts staying in currency
+func Identity(currency string) Rate {
+	return Rate{From: currency, To: currency, Value: 1}
+}
+
+// Convert returns the amount in the currency of the rate, rounded half away from zero to its minor unit.
+// It panics on amounts of another currency than From.
+func (r Rate) Convert(m Money) Money {
+	if m.Currency != r.From {
+		panic(fmt.Sprintf("money: converting a %s amount at a %s rate", m.Currency, r.From))
+	}
+
+	micros := int64(math.Round(r.Value * 1e6))
+	numerator := m.MinorUnits * micros
+	denominator := int64(1e6)
+
+	//scaling between the minor units of the currencies
+	exponentDiff := exponents[r.To] - exponents[r.From]
+	for ; exponentDiff > 0; exponentDiff-- {
+		numerator *= 10
+	}
+	for ; exponentDiff < 0; exponentDiff++ {
+		denominator *= 10
+	}
+
+	return New(divRound(numerator, denominator), r.To)
+}
+
+// mustMatch panics on amounts of different currencies, which callers keep apart
+func (m Money) mustMatch(other Money) {
+	if m.Currency != other.Currency {
+		panic(fmt.Sprintf("money: mixing %s and %s amounts", m.Currency, other.Currency))
+	}
+}
+
+// divRound divides rounding half away from zero
+func divRound(numerator, denominator int64) int64 {
+	negative := (numerator < 0) != (denominator < 0)
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if remainder < 0 {
+		remainder = -remainder
+	}
+
+	if denominator < 0 {
+		denominator = -denominator
+	}
+
+	if 2*remainder >= denominator {
+		if negative {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+
+	return quotient
+}
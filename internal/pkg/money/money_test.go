@@ -0,0 +1,129 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMajor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		amount         float64
+		currency       string
+		expectedOutput Money
+		expectedErr    bool
+	}{
+		{name: "Whole Amount", amount: 5000, currency: "INR", expectedOutput: New(500000, "INR")},
+		{name: "Amount With Paise", amount: 18.99, currency: "INR", expectedOutput: New(1899, "INR")},
+		{name: "Half Rounds Away From Zero", amount: 1.005, currency: "INR", expectedOutput: New(101, "INR")},
+		{name: "Below Half Rounds Down", amount: 1.004, currency: "INR", expectedOutput: New(100, "INR")},
+		{name: "Negative Half Rounds Away From Zero", amount: -1.005, currency: "INR", expectedOutput: New(-101, "INR")},
+		{name: "Currency Without Minor Units", amount: 1234.5, currency: "JPY", expectedOutput: New(1235, "JPY")},
+		{name: "Currency With Three Minor Digits", amount: 1.2345, currency: "KWD", expectedOutput: New(1235, "KWD")},
+		{name: "Unsupported Currency", amount: 1, currency: "XYZ", expectedErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			money, err := FromMajor(test.amount, test.currency)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, money)
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		amount         Money
+		percent        float64
+		expectedOutput Money
+	}{
+		{name: "Exact Percent", amount: New(15000, "INR"), percent: 10, expectedOutput: New(1500, "INR")},
+		{name: "Half Minor Unit Rounds Up", amount: New(5, "INR"), percent: 10, expectedOutput: New(1, "INR")},
+		{name: "Below Half Minor Unit Rounds Down", amount: New(4, "INR"), percent: 10, expectedOutput: New(0, "INR")},
+		{name: "Fractional Percent", amount: New(1999, "INR"), percent: 12.5, expectedOutput: New(250, "INR")},
+		{name: "Negative Amount Rounds Away From Zero", amount: New(-5, "INR"), percent: 10, expectedOutput: New(-1, "INR")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedOutput, test.amount.Percent(test.percent))
+		})
+	}
+}
+
+func TestIncludedPercent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		amount         Money
+		percent        float64
+		expectedOutput Money
+	}{
+		{name: "Exact Share", amount: New(11800, "INR"), percent: 18, expectedOutput: New(1800, "INR")},
+		{name: "Rounded To Minor Unit", amount: New(10000, "INR"), percent: 18, expectedOutput: New(1525, "INR")},
+		{name: "Fractional Percent", amount: New(10250, "INR"), percent: 2.5, expectedOutput: New(250, "INR")},
+		{name: "Zero Percent", amount: New(10000, "INR"), percent: 0, expectedOutput: New(0, "INR")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedOutput, test.amount.IncludedPercent(test.percent))
+		})
+	}
+}
+
+func TestRatio(t *testing.T) {
+	assert.Equal(t, 16.67, New(20, "INR").Ratio(New(120, "INR")))
+	assert.Equal(t, 0.0, New(20, "INR").Ratio(New(0, "INR")))
+}
+
+func TestArithmetic(t *testing.T) {
+	price := New(1050, "INR")
+
+	assert.Equal(t, New(3150, "INR"), price.Mul(3))
+	assert.Equal(t, New(2100, "INR"), price.Add(price))
+	assert.Equal(t, New(0, "INR"), price.Sub(price))
+	assert.Equal(t, price, Min(price, price.Mul(2)))
+	assert.Equal(t, -1, price.Cmp(price.Mul(2)))
+
+	assert.Panics(t, func() { price.Add(New(1050, "USD")) })
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "INR 10.50", New(1050, "INR").String())
+	assert.Equal(t, "INR 0.05", New(5, "INR").String())
+	assert.Equal(t, "INR -0.05", New(-5, "INR").String())
+	assert.Equal(t, "JPY 1200", New(1200, "JPY").String())
+	assert.Equal(t, "KWD 1.235", New(1235, "KWD").String())
+}
+
+func TestRateConvert(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rate           Rate
+		amount         Money
+		expectedOutput Money
+	}{
+		{name: "Identity", rate: Identity("INR"), amount: New(189999, "INR"), expectedOutput: New(189999, "INR")},
+		{name: "Rounded To Minor Unit", rate: Rate{From: "INR", To: "USD", Value: 0.012}, amount: New(189999, "INR"), expectedOutput: New(2280, "USD")},
+		{name: "Half Rounds Away From Zero", rate: Rate{From: "INR", To: "USD", Value: 0.5}, amount: New(1, "INR"), expectedOutput: New(1, "USD")},
+		{name: "Into Currency Without Minor Units", rate: Rate{From: "INR", To: "JPY", Value: 1.78}, amount: New(10050, "INR"), expectedOutput: New(179, "JPY")},
+		{name: "Into Currency With Three Minor Digits", rate: Rate{From: "INR", To: "KWD", Value: 0.0037}, amount: New(100000, "INR"), expectedOutput: New(3700, "KWD")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedOutput, test.rate.Convert(test.amount))
+		})
+	}
+
+	assert.Panics(t, func() { Identity("INR").Convert(New(100, "USD")) })
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type cartStore struct {
+	BaseRepository
+}
+
+func NewCartRepo(db *storm.DB) repository.CartStorer {
+	return &cartStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (cs *cartStore) CreateCart(ctx context.Context, tx repository.Transaction, cart repository.Cart) (repository.Cart, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	cart.CreatedAt = cs.TimeNow()
+	cart.UpdatedAt = cs.TimeNow()
+	err := queryExecutor.Save(&cart)
+	if err != nil {
+		return repository.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (cs *cartStore) GetCartByID(ctx context.Context, tx repository.Transaction, cartID int64) (repository.Cart, error) {
+	var cart repository.Cart
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", cartID, &cart)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (cs *cartStore) TransitionCartStatus(ctx context.Context, tx repository.Transaction, cartID int64, currentStatus, newStatus string, orderID int64) (bool, error) {
+	var cart repository.Cart
+
+	//bolt allows a single writer at a time, so reading and writing within
+	//the same transaction cannot interleave with another transition
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", cartID, &cart)
+	if err == storm.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if cart.Status != currentStatus {
+		return false, nil
+	}
+
+	cart.Status = newStatus
+	cart.OrderID = orderID
+	cart.UpdatedAt = cs.TimeNow()
+	err = queryExecutor.Save(&cart)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (cs *cartStore) GetCartItems(ctx context.Context, tx repository.Transaction, cartID int64) ([]repository.CartItem, error) {
+	cartItems := make([]repository.CartItem, 0)
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.Find("CartID", cartID, &cartItems)
+	if err != nil && err != storm.ErrNotFound {
+		return cartItems, err
+	}
+
+	return cartItems, nil
+}
+
+func (cs *cartStore) UpsertCartItem(ctx context.Context, tx repository.Transaction, cartItem repository.CartItem) error {
+	var existingItem repository.CartItem
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(q.Eq("CartID", cartItem.CartID), q.Eq("ProductID", cartItem.ProductID)).First(&existingItem)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	//keep the identity and creation time of an item already in the cart
+	cartItem.ID = existingItem.ID
+	cartItem.CreatedAt = existingItem.CreatedAt
+	if cartItem.CreatedAt.IsZero() {
+		cartItem.CreatedAt = cs.TimeNow()
+	}
+
+	cartItem.UpdatedAt = cs.TimeNow()
+	return queryExecutor.Save(&cartItem)
+}
+
+func (cs *cartStore) DeleteCartItem(ctx context.Context, tx repository.Transaction, cartID, productID int64) error {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(q.Eq("CartID", cartID), q.Eq("ProductID", productID)).Delete(&repository.CartItem{})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
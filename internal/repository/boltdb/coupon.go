@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type couponStore struct {
+	BaseRepository
+}
+
+func NewCouponRepo(db *storm.DB) repository.CouponStorer {
+	return &couponStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (cs *couponStore) CreateCoupon(ctx context.Context, tx repository.Transaction, coupon repository.Coupon) (repository.Coupon, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	coupon.CreatedAt = cs.TimeNow()
+	coupon.UpdatedAt = coupon.CreatedAt
+	err := queryExecutor.Save(&coupon)
+	if err != nil {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) GetCouponByID(ctx context.Context, tx repository.Transaction, couponID int64) (repository.Coupon, error) {
+	var coupon repository.Coupon
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", couponID, &coupon)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) GetCouponByCode(ctx context.Context, tx repository.Transaction, code string) (repository.Coupon, error) {
+	var coupon repository.Coupon
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("Code", code, &coupon)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) ListCoupons(ctx context.Context, tx repository.Transaction) ([]repository.Coupon, error) {
+	coupons := make([]repository.Coupon, 0)
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.All(&coupons)
+	if err != nil && err != storm.ErrNotFound {
+		return coupons, err
+	}
+
+	return coupons, nil
+}
+
+func (cs *couponStore) RedeemCoupon(ctx context.Context, tx repository.Transaction, redemption repository.CouponRedemption) (bool, error) {
+	var coupon repository.Coupon
+
+	//bolt allows a single writer at a time, so checking the limits and redeeming
+	//within the same transaction cannot interleave with another redemption
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", redemption.CouponID, &coupon)
+	if err == storm.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if coupon.UsageLimit > 0 && coupon.TimesUsed >= coupon.UsageLimit {
+		return false, nil
+	}
+
+	if coupon.PerCustomerLimit > 0 {
+		customerRedemptions, err := cs.CountCouponRedemptions(ctx, tx, redemption.CouponID, redemption.CustomerID)
+		if err != nil {
+			return false, err
+		}
+
+		if customerRedemptions >= coupon.PerCustomerLimit {
+			return false, nil
+		}
+	}
+
+	coupon.TimesUsed = coupon.TimesUsed + 1
+	coupon.UpdatedAt = cs.TimeNow()
+	err = queryExecutor.Save(&coupon)
+	if err != nil {
+		return false, err
+	}
+
+	redemption.CreatedAt = coupon.UpdatedAt
+	err = queryExecutor.Save(&redemption)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (cs *couponStore) CountCouponRedemptions(ctx context.Context, tx repository.Transaction, couponID, customerID int64) (int64, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	count, err := queryExecutor.Select(q.Eq("CouponID", couponID), q.Eq("CustomerID", customerID)).Count(&repository.CouponRedemption{})
+	if err != nil && err != storm.ErrNotFound {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type customerStore struct {
+	BaseRepository
+}
+
+func NewCustomerRepo(db *storm.DB) repository.CustomerStorer {
+	return &customerStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (cs *customerStore) CreateCustomer(ctx context.Context, tx repository.Transaction, customer repository.Customer) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	customer.CreatedAt = cs.TimeNow()
+	customer.UpdatedAt = cs.TimeNow()
+	err := queryExecutor.Save(&customer)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) GetCustomerByID(ctx context.Context, tx repository.Transaction, customerID int64) (repository.Customer, error) {
+	var customer repository.Customer
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", customerID, &customer)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) GetCustomerByEmail(ctx context.Context, tx repository.Transaction, email string) (repository.Customer, error) {
+	var customer repository.Customer
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.One("Email", email, &customer)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) UpdateCustomer(ctx context.Context, tx repository.Transaction, customer repository.Customer) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	customer.UpdatedAt = cs.TimeNow()
+	err := queryExecutor.Save(&customer)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type idempotencyStore struct {
+	BaseRepository
+}
+
+func NewIdempotencyRepo(db *storm.DB) repository.IdempotencyStorer {
+	return &idempotencyStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (is *idempotencyStore) GetIdempotencyKey(ctx context.Context, tx repository.Transaction, customerID int64, key string) (repository.IdempotencyKey, error) {
+	var idempotencyKey repository.IdempotencyKey
+
+	queryExecutor := is.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(q.Eq("CustomerID", customerID), q.Eq("Key", key)).First(&idempotencyKey)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.IdempotencyKey{}, err
+	}
+
+	return idempotencyKey, nil
+}
+
+func (is *idempotencyStore) CreateIdempotencyKey(ctx context.Context, tx repository.Transaction, idempotencyKey repository.IdempotencyKey) (repository.IdempotencyKey, error) {
+	queryExecutor := is.initiateQueryExecutor(tx)
+
+	idempotencyKey.CreatedAt = is.TimeNow()
+	err := queryExecutor.Save(&idempotencyKey)
+	if err != nil {
+		return repository.IdempotencyKey{}, err
+	}
+
+	return idempotencyKey, nil
+}
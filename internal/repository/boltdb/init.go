@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"log"
+
+	"github.com/asdine/storm/v3"
+)
+
+func InitializeDatabase(path string) (db *storm.DB, err error) {
+	db, err = storm.Open(path)
+	if err != nil {
+		log.Printf("error occured while creating database connection: %v", err.Error())
+		return nil, err
+	}
+
+	return db, nil
+}
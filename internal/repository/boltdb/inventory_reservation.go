@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type inventoryReservationStore struct {
+	BaseRepository
+}
+
+func NewInventoryReservationRepo(db *storm.DB) repository.InventoryReservationStorer {
+	return &inventoryReservationStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (irs *inventoryReservationStore) CreateReservations(ctx context.Context, tx repository.Transaction, reservations []repository.InventoryReservation) error {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	for _, reservation := range reservations {
+
+		//setting time fields
+		reservation.CreatedAt = irs.TimeNow()
+		reservation.UpdatedAt = reservation.CreatedAt
+
+		err := queryExecutor.Save(&reservation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (irs *inventoryReservationStore) ListReservationsByOrderID(ctx context.Context, tx repository.Transaction, orderID int64) ([]repository.InventoryReservation, error) {
+	reservations := make([]repository.InventoryReservation, 0)
+
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	err := queryExecutor.Find("OrderID", orderID, &reservations)
+	if err != nil && err != storm.ErrNotFound {
+		return reservations, err
+	}
+
+	return reservations, nil
+}
+
+func (irs *inventoryReservationStore) GetReservedQuantities(ctx context.Context, tx repository.Transaction, productIDs []int64, at time.Time) (map[int64]int64, error) {
+	reserved := make(map[int64]int64)
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+
+	reservations := make([]repository.InventoryReservation, 0)
+
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(
+		q.Eq("Status", repository.ReservationActive),
+		q.In("ProductID", productIDs),
+		q.Gt("ExpiresAt", at),
+	).Find(&reservations)
+	if err != nil && err != storm.ErrNotFound {
+		return reserved, err
+	}
+
+	for _, reservation := range reservations {
+		reserved[reservation.ProductID] += reservation.Quantity
+	}
+
+	return reserved, nil
+}
+
+func (irs *inventoryReservationStore) ListExpiredReservations(ctx context.Context, tx repository.Transaction, at time.Time, limit int) ([]repository.InventoryReservation, error) {
+	reservations := make([]repository.InventoryReservation, 0)
+
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(
+		q.Eq("Status", repository.ReservationActive),
+		q.Lte("ExpiresAt", at),
+	).OrderBy("ID").Limit(limit).Find(&reservations)
+	if err != nil && err != storm.ErrNotFound {
+		return reservations, err
+	}
+
+	return reservations, nil
+}
+
+func (irs *inventoryReservationStore) UpdateReservationStatus(ctx context.Context, tx repository.Transaction, reservationIDs []int64, status string) error {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	for _, reservationID := range reservationIDs {
+		err := queryExecutor.Update(&repository.InventoryReservation{ID: uint(reservationID), Status: status, UpdatedAt: irs.TimeNow()})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,488 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"go.uber.org/zap"
+)
+
+type migration struct {
+	version     int
+	description string
+	up          func(tx storm.Node, currency string) error
+}
+
+// migrations lists every schema change of the bolt database, new steps are appended with the next version
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create order, product and order item buckets",
+		up:          initBuckets(&repository.Order{}, &repository.Product{}, &repository.OrderItem{}),
+	},
+	{
+		version:     2,
+		description: "seed product catalog",
+		up:          seedProducts,
+	},
+	{
+		version:     3,
+		description: "create cart and cart item buckets",
+		up:          initBuckets(&repository.Cart{}, &repository.CartItem{}),
+	},
+	{
+		version:     4,
+		description: "create customer bucket and index orders by customer",
+		up:          initBuckets(&repository.Customer{}, &repository.Order{}),
+	},
+	{
+		version:     5,
+		description: "index products by category",
+		up:          reIndex(&repository.Product{}),
+	},
+	{
+		version:     6,
+		description: "create idempotency key bucket",
+		up:          initBuckets(&repository.IdempotencyKey{}),
+	},
+	{
+		version:     7,
+		description: "create order status event bucket",
+		up:          initBuckets(&repository.OrderStatusEvent{}),
+	},
+	{
+		version:     8,
+		description: "create outbox event bucket",
+		up:          initBuckets(&repository.OutboxEvent{}),
+	},
+	{
+		version:     9,
+		description: "create webhook subscription and delivery buckets",
+		up:          initBuckets(&repository.WebhookSubscription{}, &repository.WebhookDelivery{}),
+	},
+	{
+		version:     10,
+		description: "create inventory reservation bucket",
+		up:          initBuckets(&repository.InventoryReservation{}),
+	},
+	{
+		version:     11,
+		description: "create stock movement bucket and record opening balances",
+		up:          steps(initBuckets(&repository.StockMovement{}), recordOpeningBalances),
+	},
+	{
+		version:     12,
+		description: "create coupon and coupon redemption buckets",
+		up:          initBuckets(&repository.Coupon{}, &repository.CouponRedemption{}),
+	},
+	{
+		version:     13,
+		description: "store money amounts as integer minor units with their currency",
+		//the float amounts carry no currency and are converted in the configured one, a database priced before
+		//must be migrated with ORDER_CURRENCY set to the currency its amounts were priced in
+		up: steps(
+			convertToMinorUnits("Product", "Price"),
+			convertToMinorUnits("Order", "Amount", "FinalAmount", "Discounts.Amount"),
+			splitCouponValues,
+			convertToMinorUnits("CouponRedemption", "Amount"),
+			convertIdempotentResponses,
+		),
+	},
+	{
+		version:     14,
+		description: "add the exchange rate to orders",
+		up:          recordExchangeRates,
+	},
+}
+
+type migrator struct {
+	db *storm.DB
+	//currency of the catalog, stamped on the seeded products and the amounts stored before they carried one
+	currency string
+}
+
+// NewMigrator migrates the database for a catalog priced in currency
+func NewMigrator(db *storm.DB, currency string) repository.Migrator {
+	return &migrator{db: db, currency: currency}
+}
+
+func (m *migrator) Migrate(ctx context.Context, dryRun bool) ([]repository.SchemaMigration, error) {
+	applied := make([]repository.SchemaMigration, 0)
+
+	appliedVersions, err := m.appliedVersions()
+	if err != nil {
+		return applied, err
+	}
+
+	steps := make([]migration, len(migrations))
+	copy(steps, migrations)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].version < steps[j].version })
+
+	for _, step := range steps {
+		if appliedVersions[step.version] {
+			continue
+		}
+
+		record := repository.SchemaMigration{
+			Version:     step.version,
+			Description: step.description,
+			AppliedAt:   time.Now(),
+		}
+
+		if dryRun {
+			applied = append(applied, record)
+			continue
+		}
+
+		err = m.apply(step, record)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while applying migration",
+				zap.Error(err),
+				zap.Int("version", step.version),
+			)
+			return applied, err
+		}
+
+		logger.Infow(ctx, "applied migration",
+			zap.Int("version", step.version),
+			zap.String("description", step.description),
+		)
+		applied = append(applied, record)
+	}
+
+	return applied, nil
+}
+
+func (m *migrator) CheckCurrency(ctx context.Context) error {
+	var product repository.Product
+	err := m.db.Select(q.Not(q.Eq("Currency", m.currency))).First(&product)
+	if err == nil {
+		return currencyMismatch(product.Currency, m.currency)
+	}
+	if err != storm.ErrNotFound {
+		return err
+	}
+
+	var coupon repository.Coupon
+	err = m.db.Select(q.Eq("DiscountType", "fixed"), q.Not(q.Eq("Currency", m.currency))).First(&coupon)
+	if err == nil {
+		return currencyMismatch(coupon.Currency, m.currency)
+	}
+	if err != storm.ErrNotFound {
+		return err
+	}
+
+	var order repository.Order
+	err = m.db.Select(q.Not(q.Eq("BaseCurrency", m.currency))).First(&order)
+	if err == nil {
+		return currencyMismatch(order.BaseCurrency, m.currency)
+	}
+	if err != storm.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func currencyMismatch(stored, catalog string) error {
+	return fmt.Errorf("database holds amounts in %s while the catalog currency is %s", stored, catalog)
+}
+
+func (m *migrator) appliedVersions() (map[int]bool, error) {
+	records := make([]repository.SchemaMigration, 0)
+	err := m.db.All(&records)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int]bool)
+	for _, record := range records {
+		versions[record.Version] = true
+	}
+
+	return versions, nil
+}
+
+// apply runs the step and records its version in a single transaction
+func (m *migrator) apply(step migration, record repository.SchemaMigration) (err error) {
+	tx, err := m.db.Begin(true)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = step.up(tx, m.currency)
+	if err != nil {
+		return err
+	}
+
+	return tx.Save(&record)
+}
+
+// steps combines several migration steps into one
+func steps(ups ...func(tx storm.Node, currency string) error) func(tx storm.Node, currency string) error {
+	return func(tx storm.Node, currency string) error {
+		for _, up := range ups {
+			err := up(tx, currency)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// initBuckets builds a migration step creating the buckets and indexes of the given types
+func initBuckets(buckets ...interface{}) func(tx storm.Node, currency string) error {
+	return func(tx storm.Node, currency string) error {
+		for _, bucket := range buckets {
+			err := tx.Init(bucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// reIndex builds a migration step rebuilding the indexes of the given types,
+// needed when an index is added to a type which already has records
+func reIndex(buckets ...interface{}) func(tx storm.Node, currency string) error {
+	return func(tx storm.Node, currency string) error {
+		for _, bucket := range buckets {
+			err := tx.ReIndex(bucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func seedProducts(tx storm.Node, currency string) (err error) {
+	products := make([]repository.Product, 0)
+	err = tx.All(&products)
+	if err != nil {
+		return err
+	}
+
+	//database already has some products, so not adding products again
+	if len(products) > 0 {
+		return nil
+	}
+
+	for _, product := range repository.SeedProducts(time.Now(), currency) {
+		err = tx.Save(&product)
+		if err != nil {
+			logger.Errorw(context.Background(), "error occured while seeding product in database",
+				zap.Error(err),
+				zap.String("product_name", product.Name),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordOpeningBalances starts the stock ledger of the existing products with their quantity on hand
+func recordOpeningBalances(tx storm.Node, currency string) error {
+	products := make([]repository.Product, 0)
+	err := tx.All(&products)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		if product.Quantity == 0 {
+			continue
+		}
+
+		err = tx.Save(&repository.StockMovement{
+			ProductID:     int64(product.ID),
+			Delta:         product.Quantity,
+			QuantityAfter: product.Quantity,
+			Reason:        repository.StockReasonInitial,
+			CreatedAt:     time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertToMinorUnits builds a migration step replacing the amounts in major units of the fields of the bucket records
+// by integer minor units, a "List.Field" path converting the field of every element of a list. The records are stamped
+// with their currency, those already carrying one, such as the seeded products, were written in minor units.
+// Amounts were stored in the catalog currency until they carried one.
+func convertToMinorUnits(bucket string, fields ...string) func(tx storm.Node, currency string) error {
+	return func(tx storm.Node, currency string) error {
+		return rewriteRecords(tx, bucket, func(record map[string]interface{}) error {
+			if _, ok := record["Currency"]; ok {
+				return nil
+			}
+
+			for _, field := range fields {
+				list, elementField, isList := strings.Cut(field, ".")
+				if !isList {
+					err := toMinorUnits(record, field, currency)
+					if err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				elements, _ := record[list].([]interface{})
+				for _, element := range elements {
+					err := toMinorUnits(element.(map[string]interface{}), elementField, currency)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			record["Currency"] = currency
+			return nil
+		})
+	}
+}
+
+// splitCouponValues replaces the value of the coupons, a percent or an amount in major units as per their type,
+// by a percent and an amount in minor units with its currency
+func splitCouponValues(tx storm.Node, currency string) error {
+	return rewriteRecords(tx, "Coupon", func(record map[string]interface{}) error {
+		if _, ok := record["Currency"]; ok {
+			return nil
+		}
+
+		value, _ := record["Value"].(float64)
+		delete(record, "Value")
+		record["Currency"] = currency
+
+		if record["DiscountType"] == "percentage" {
+			record["Percent"] = value
+			return nil
+		}
+
+		amount, err := money.FromMajor(value, currency)
+		if err != nil {
+			return err
+		}
+
+		record["Amount"] = amount.MinorUnits
+		return nil
+	})
+}
+
+// convertIdempotentResponses converts the amounts of the order responses replayed for idempotency keys to money,
+// the responses are stored base64 encoded
+func convertIdempotentResponses(tx storm.Node, currency string) error {
+	return rewriteRecords(tx, "IdempotencyKey", func(record map[string]interface{}) error {
+		encoded, _ := record["Response"].(string)
+		response, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return err
+		}
+
+		converted, err := repository.ConvertLegacyResponse(response, currency)
+		if err != nil {
+			return err
+		}
+
+		record["Response"] = base64.StdEncoding.EncodeToString(converted)
+		return nil
+	})
+}
+
+// recordExchangeRates stamps the orders placed before they carried a rate, they were placed in the catalog currency
+func recordExchangeRates(tx storm.Node, currency string) error {
+	return rewriteRecords(tx, "Order", func(record map[string]interface{}) error {
+		if _, ok := record["ExchangeRate"]; ok {
+			return nil
+		}
+
+		record["BaseCurrency"] = record["Currency"]
+		record["ExchangeRate"] = 1
+		return nil
+	})
+}
+
+// rewriteRecords decodes every record of the bucket, lets rewrite change it and stores it back.
+// The records are changed in place so the indexes, which are not rewritten, must not cover the changed fields.
+func rewriteRecords(tx storm.Node, bucket string, rewrite func(record map[string]interface{}) error) error {
+	type rawRecord struct {
+		key   []byte
+		value []byte
+	}
+
+	records := make([]rawRecord, 0)
+	err := tx.Select().Bucket(bucket).RawEach(func(key, value []byte) error {
+		records = append(records, rawRecord{
+			key:   append([]byte(nil), key...),
+			value: append([]byte(nil), value...),
+		})
+		return nil
+	})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	for _, raw := range records {
+		record := make(map[string]interface{})
+		err = tx.Codec().Unmarshal(raw.value, &record)
+		if err != nil {
+			return err
+		}
+
+		err = rewrite(record)
+		if err != nil {
+			return fmt.Errorf("error converting %s record: %w", bucket, err)
+		}
+
+		value, err := tx.Codec().Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		err = tx.SetBytes(bucket, raw.key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toMinorUnits converts the float amount of the record field from major to minor units of currency
+func toMinorUnits(record map[string]interface{}, field, currency string) error {
+	amount, _ := record[field].(float64)
+	minorUnits, err := money.FromMajor(amount, currency)
+	if err != nil {
+		return err
+	}
+
+	record[field] = minorUnits.MinorUnits
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
@@ -64,14 +65,79 @@ func (os *orderStore) UpdateOrderDispatchDate(ctx context.Context, tx repository
 	return nil
 }
 
-func (os *orderStore) ListOrders(ctx context.Context, tx repository.Transaction) ([]repository.Order, error) {
+func (os *orderStore) ListOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) ([]repository.Order, error) {
 	orderList := make([]repository.Order, 0)
 
 	queryExecutor := os.initiateQueryExecutor(tx)
-	err := queryExecutor.All(&orderList)
-	if err != nil {
+	query := queryExecutor.Select(orderMatchers(filter)...).OrderBy(orderSortFields[filter.SortBy]...)
+	if filter.SortDesc {
+		query = query.Reverse()
+	}
+
+	if filter.Offset > 0 {
+		query = query.Skip(filter.Offset)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	err := query.Find(&orderList)
+	if err != nil && err != storm.ErrNotFound {
 		return orderList, err
 	}
 
 	return orderList, nil
 }
+
+func (os *orderStore) CountOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) (int64, error) {
+	queryExecutor := os.initiateQueryExecutor(tx)
+	count, err := queryExecutor.Select(orderMatchers(filter)...).Count(&repository.Order{})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+// orderSortFields maps the sort columns to the struct fields storm sorts on, id breaks ties
+var orderSortFields = map[string][]string{
+	"":                                {"ID"},
+	repository.OrderSortByID:          {"ID"},
+	repository.OrderSortByCreatedAt:   {"CreatedAt", "ID"},
+	repository.OrderSortByFinalAmount: {"FinalAmount", "ID"},
+}
+
+func orderMatchers(filter repository.OrderFilter) []q.Matcher {
+	matchers := make([]q.Matcher, 0)
+
+	if filter.CustomerID != 0 {
+		matchers = append(matchers, q.Eq("CustomerID", filter.CustomerID))
+	}
+
+	if len(filter.Statuses) > 0 {
+		matchers = append(matchers, q.In("Status", filter.Statuses))
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		matchers = append(matchers, q.Gte("CreatedAt", filter.CreatedFrom))
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		matchers = append(matchers, q.Lt("CreatedAt", filter.CreatedTo))
+	}
+
+	if filter.Currency != "" {
+		matchers = append(matchers, q.Eq("Currency", filter.Currency))
+	}
+
+	if filter.MinFinalAmount != nil {
+		matchers = append(matchers, q.Gte("FinalAmount", *filter.MinFinalAmount))
+	}
+
+	if filter.MaxFinalAmount != nil {
+		matchers = append(matchers, q.Lte("FinalAmount", *filter.MaxFinalAmount))
+	}
+
+	return matchers
+}
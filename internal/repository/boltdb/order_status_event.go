@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/asdine/storm/v3"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type orderStatusEventStore struct {
+	BaseRepository
+}
+
+func NewOrderStatusEventRepo(db *storm.DB) repository.OrderStatusEventStorer {
+	return &orderStatusEventStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (es *orderStatusEventStore) CreateOrderStatusEvent(ctx context.Context, tx repository.Transaction, event repository.OrderStatusEvent) (repository.OrderStatusEvent, error) {
+	queryExecutor := es.initiateQueryExecutor(tx)
+
+	event.CreatedAt = es.TimeNow()
+	err := queryExecutor.Save(&event)
+	if err != nil {
+		return repository.OrderStatusEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (es *orderStatusEventStore) ListOrderStatusEvents(ctx context.Context, tx repository.Transaction, orderID int64) ([]repository.OrderStatusEvent, error) {
+	events := make([]repository.OrderStatusEvent, 0)
+
+	queryExecutor := es.initiateQueryExecutor(tx)
+	err := queryExecutor.Find("OrderID", orderID, &events)
+	if err != nil && err != storm.ErrNotFound {
+		return events, err
+	}
+
+	//ids increase with every event, so they keep the order of the transitions
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type outboxStore struct {
+	BaseRepository
+}
+
+func NewOutboxRepo(db *storm.DB) repository.OutboxStorer {
+	return &outboxStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (obs *outboxStore) CreateOutboxEvent(ctx context.Context, tx repository.Transaction, event repository.OutboxEvent) (repository.OutboxEvent, error) {
+	queryExecutor := obs.initiateQueryExecutor(tx)
+
+	event.CreatedAt = obs.TimeNow()
+	event.Status = repository.OutboxStatusPending
+	event.NextAttemptAt = event.CreatedAt
+	err := queryExecutor.Save(&event)
+	if err != nil {
+		return repository.OutboxEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (obs *outboxStore) ListPendingOutboxEvents(ctx context.Context, tx repository.Transaction, dueAt time.Time, limit int) ([]repository.OutboxEvent, error) {
+	events := make([]repository.OutboxEvent, 0)
+
+	queryExecutor := obs.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(
+		q.Eq("Status", repository.OutboxStatusPending),
+		q.Lte("NextAttemptAt", dueAt),
+	).OrderBy("ID").Limit(limit).Find(&events)
+	if err != nil && err != storm.ErrNotFound {
+		return events, err
+	}
+
+	return events, nil
+}
+
+func (obs *outboxStore) UpdateOutboxEvent(ctx context.Context, tx repository.Transaction, event repository.OutboxEvent) error {
+	queryExecutor := obs.initiateQueryExecutor(tx)
+	return queryExecutor.Save(&event)
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
 	"github.com/sagar23sj/go-ecommerce/internal/repository"
 )
 
@@ -29,29 +33,276 @@ func (ps *productStore) GetProductByID(ctx context.Context, tx repository.Transa
 	return product, nil
 }
 
-func (ps *productStore) ListProducts(ctx context.Context, tx repository.Transaction) ([]repository.Product, error) {
+func (ps *productStore) CreateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+
+	product.CreatedAt = ps.TimeNow()
+	product.UpdatedAt = ps.TimeNow()
+	err := queryExecutor.Save(&product)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) UpdateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+
+	var current repository.Product
+	err := queryExecutor.One("ID", product.ID, &current)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	//Save replaces the whole record, so the quantity on hand is carried over
+	product.Quantity = current.Quantity
+	product.UpdatedAt = ps.TimeNow()
+	err = queryExecutor.Save(&product)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) ArchiveProduct(ctx context.Context, tx repository.Transaction, productID int64, archivedAt time.Time) error {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	err := queryExecutor.Update(&repository.Product{ID: uint(productID), Archived: true, ArchivedAt: archivedAt, UpdatedAt: ps.TimeNow()})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LockProducts has nothing to lock, bolt runs one writable transaction at a time
+func (ps *productStore) LockProducts(ctx context.Context, tx repository.Transaction, productIDs []int64) error {
+	return nil
+}
+
+func (ps *productStore) AdjustProductQuantity(ctx context.Context, tx repository.Transaction, productID int64, delta int64) (repository.Product, error) {
+	var product repository.Product
+
+	//bolt runs one writable transaction at a time, so the read and the update cannot interleave with another adjustment
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", productID, &product)
+	if err == storm.ErrNotFound {
+		return repository.Product{}, nil
+	}
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	if product.Quantity+delta < 0 {
+		return repository.Product{}, nil
+	}
+
+	//UpdateField persists zero values, Update would skip a quantity of 0
+	product.Quantity += delta
+	product.UpdatedAt = ps.TimeNow()
+	err = queryExecutor.UpdateField(&repository.Product{ID: product.ID}, "Quantity", product.Quantity)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	err = queryExecutor.UpdateField(&repository.Product{ID: product.ID}, "UpdatedAt", product.UpdatedAt)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) ListProductQuantities(ctx context.Context, tx repository.Transaction) (map[int64]int64, error) {
+	quantities := make(map[int64]int64)
+
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	err := queryExecutor.Select().Each(new(repository.Product), func(record interface{}) error {
+		product := record.(*repository.Product)
+		quantities[int64(product.ID)] = product.Quantity
+		return nil
+	})
+	if err != nil && err != storm.ErrNotFound {
+		return quantities, err
+	}
+
+	return quantities, nil
+}
+
+func (ps *productStore) ListReorderProducts(ctx context.Context, tx repository.Transaction) ([]repository.Product, error) {
 	productList := make([]repository.Product, 0)
 
 	queryExecutor := ps.initiateQueryExecutor(tx)
-	err := queryExecutor.All(&productList)
+	err := queryExecutor.Select(q.Eq("Archived", false), q.Gt("ReorderThreshold", 0)).OrderBy("ID").Find(&productList)
+	if err != nil && err != storm.ErrNotFound {
+		return productList, err
+	}
+
+	return productList, nil
+}
+
+func (ps *productStore) SearchProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) ([]repository.Product, error) {
+	productList, err := ps.matchingProducts(tx, filter)
 	if err != nil {
 		return productList, err
 	}
 
+	less := productSortLess[filter.SortBy]
+	sort.SliceStable(productList, func(i, j int) bool {
+		if filter.SortDesc {
+			return less(productList[j], productList[i])
+		}
+		return less(productList[i], productList[j])
+	})
+
+	if filter.Offset >= len(productList) {
+		return make([]repository.Product, 0), nil
+	}
+
+	productList = productList[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(productList) {
+		productList = productList[:filter.Limit]
+	}
+
 	return productList, nil
 }
 
-func (ps *productStore) UpdateProductQuantity(ctx context.Context, tx repository.Transaction, productsQuantityMap map[int64]int64) error {
+func (ps *productStore) CountProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) (int64, error) {
+	productList, err := ps.matchingProducts(tx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(productList)), nil
+}
+
+// matchingProducts loads the products matching the filter, a category lookup goes through the Category index
+// while the remaining filters are checked on the loaded records
+func (ps *productStore) matchingProducts(tx repository.Transaction, filter repository.ProductFilter) ([]repository.Product, error) {
+	candidates := make([]repository.Product, 0)
+
 	queryExecutor := ps.initiateQueryExecutor(tx)
+	var err error
+	if filter.Category != "" {
+		err = queryExecutor.Find("Category", filter.Category, &candidates)
+	} else {
+		err = queryExecutor.All(&candidates)
+	}
+	if err != nil && err != storm.ErrNotFound {
+		return candidates, err
+	}
+
+	reserved, err := ps.reservedQuantities(tx, filter.InStockAt)
+	if err != nil {
+		return nil, err
+	}
 
-	// Iterate over the map to set the quantity for each product ID
-	for productID, quantity := range productsQuantityMap {
-		// Update the records with the given product ID
-		err := queryExecutor.UpdateField(&repository.Product{ID: uint(productID)}, "Quantity", quantity)
+	matcher := q.And(productMatchers(filter)...)
+	productList := make([]repository.Product, 0, len(candidates))
+	for _, product := range candidates {
+		ok, err := matcher.Match(&product)
 		if err != nil {
-			return err
+			return productList, err
+		}
+
+		//stock held by active reservations is not available to sell
+		if !filter.InStockAt.IsZero() && product.Quantity <= reserved[int64(product.ID)] {
+			ok = false
+		}
+
+		if ok {
+			productList = append(productList, product)
 		}
 	}
 
-	return nil
+	return productList, nil
+}
+
+// reservedQuantities sums the reservations of every product active at `at`, none are loaded when it is zero
+func (ps *productStore) reservedQuantities(tx repository.Transaction, at time.Time) (map[int64]int64, error) {
+	reserved := make(map[int64]int64)
+	if at.IsZero() {
+		return reserved, nil
+	}
+
+	reservations := make([]repository.InventoryReservation, 0)
+
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(
+		q.Eq("Status", repository.ReservationActive),
+		q.Gt("ExpiresAt", at),
+	).Find(&reservations)
+	if err != nil && err != storm.ErrNotFound {
+		return reserved, err
+	}
+
+	for _, reservation := range reservations {
+		reserved[reservation.ProductID] += reservation.Quantity
+	}
+
+	return reserved, nil
+}
+
+func productMatchers(filter repository.ProductFilter) []q.Matcher {
+	//archived products are retired from the catalog
+	matchers := []q.Matcher{q.Eq("Archived", false)}
+
+	if filter.MinPrice != nil {
+		matchers = append(matchers, q.Gte("Price", *filter.MinPrice))
+	}
+
+	if filter.MaxPrice != nil {
+		matchers = append(matchers, q.Lte("Price", *filter.MaxPrice))
+	}
+
+	if filter.NameContains != "" {
+		matchers = append(matchers, q.NewFieldMatcher("Name", nameMatcher(func(name string) bool {
+			return strings.Contains(strings.ToLower(name), strings.ToLower(filter.NameContains))
+		})))
+	}
+
+	if filter.NamePrefix != "" {
+		matchers = append(matchers, q.NewFieldMatcher("Name", nameMatcher(func(name string) bool {
+			return strings.HasPrefix(strings.ToLower(name), strings.ToLower(filter.NamePrefix))
+		})))
+	}
+
+	return matchers
+}
+
+// nameMatcher adapts a string predicate to a storm field matcher
+type nameMatcher func(name string) bool
+
+func (m nameMatcher) MatchField(v interface{}) (bool, error) {
+	name, ok := v.(string)
+	return ok && m(name), nil
+}
+
+// productSortLess orders products by the sort columns, id breaks ties
+var productSortLess = map[string]func(a, b repository.Product) bool{
+	"":                         lessProductID,
+	repository.ProductSortByID: lessProductID,
+	repository.ProductSortByName: func(a, b repository.Product) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return lessProductID(a, b)
+	},
+	repository.ProductSortByPrice: func(a, b repository.Product) bool {
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		}
+		return lessProductID(a, b)
+	},
+	repository.ProductSortByCreatedAt: func(a, b repository.Product) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return lessProductID(a, b)
+	},
+}
+
+func lessProductID(a, b repository.Product) bool {
+	return a.ID < b.ID
 }
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type stockMovementStore struct {
+	BaseRepository
+}
+
+func NewStockMovementRepo(db *storm.DB) repository.StockMovementStorer {
+	return &stockMovementStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (ms *stockMovementStore) CreateStockMovement(ctx context.Context, tx repository.Transaction, movement repository.StockMovement) (repository.StockMovement, error) {
+	queryExecutor := ms.initiateQueryExecutor(tx)
+
+	movement.CreatedAt = ms.TimeNow()
+	err := queryExecutor.Save(&movement)
+	if err != nil {
+		return repository.StockMovement{}, err
+	}
+
+	return movement, nil
+}
+
+func (ms *stockMovementStore) ListStockMovements(ctx context.Context, tx repository.Transaction, productID int64, limit, offset int) ([]repository.StockMovement, error) {
+	movements := make([]repository.StockMovement, 0)
+
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	query := queryExecutor.Select(q.Eq("ProductID", productID)).OrderBy("ID").Reverse()
+
+	if offset > 0 {
+		query = query.Skip(offset)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&movements)
+	if err != nil && err != storm.ErrNotFound {
+		return movements, err
+	}
+
+	return movements, nil
+}
+
+func (ms *stockMovementStore) CountStockMovements(ctx context.Context, tx repository.Transaction, productID int64) (int64, error) {
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	count, err := queryExecutor.Select(q.Eq("ProductID", productID)).Count(&repository.StockMovement{})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func (ms *stockMovementStore) SumStockMovements(ctx context.Context, tx repository.Transaction) (map[int64]int64, error) {
+	sums := make(map[int64]int64)
+
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	err := queryExecutor.Select().Each(new(repository.StockMovement), func(record interface{}) error {
+		movement := record.(*repository.StockMovement)
+		sums[movement.ProductID] += movement.Delta
+		return nil
+	})
+	if err != nil && err != storm.ErrNotFound {
+		return sums, err
+	}
+
+	return sums, nil
+}
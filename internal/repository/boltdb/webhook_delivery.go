@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type webhookDeliveryStore struct {
+	BaseRepository
+}
+
+func NewWebhookDeliveryRepo(db *storm.DB) repository.WebhookDeliveryStorer {
+	return &webhookDeliveryStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (ws *webhookDeliveryStore) CreateWebhookDelivery(ctx context.Context, tx repository.Transaction, delivery repository.WebhookDelivery) (repository.WebhookDelivery, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	delivery.CreatedAt = ws.TimeNow()
+	delivery.UpdatedAt = delivery.CreatedAt
+	err := queryExecutor.Save(&delivery)
+	if err != nil {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) GetWebhookDeliveryByID(ctx context.Context, tx repository.Transaction, deliveryID int64) (repository.WebhookDelivery, error) {
+	var delivery repository.WebhookDelivery
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", deliveryID, &delivery)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) GetWebhookDeliveryByEvent(ctx context.Context, tx repository.Transaction, subscriptionID, eventID int64) (repository.WebhookDelivery, error) {
+	var delivery repository.WebhookDelivery
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(q.Eq("SubscriptionID", subscriptionID), q.Eq("EventID", eventID)).First(&delivery)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) ListWebhookDeliveries(ctx context.Context, tx repository.Transaction, filter repository.WebhookDeliveryFilter) ([]repository.WebhookDelivery, error) {
+	deliveries := make([]repository.WebhookDelivery, 0)
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	query := queryExecutor.Select(webhookDeliveryMatchers(filter)...).OrderBy("ID").Reverse()
+
+	if filter.Offset > 0 {
+		query = query.Skip(filter.Offset)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	err := query.Find(&deliveries)
+	if err != nil && err != storm.ErrNotFound {
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+func (ws *webhookDeliveryStore) CountWebhookDeliveries(ctx context.Context, tx repository.Transaction, filter repository.WebhookDeliveryFilter) (int64, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	count, err := queryExecutor.Select(webhookDeliveryMatchers(filter)...).Count(&repository.WebhookDelivery{})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(count), nil
+}
+
+func (ws *webhookDeliveryStore) ListDueWebhookDeliveries(ctx context.Context, tx repository.Transaction, dueAt time.Time, limit int) ([]repository.WebhookDelivery, error) {
+	deliveries := make([]repository.WebhookDelivery, 0)
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(
+		q.Eq("Status", repository.WebhookDeliveryPending),
+		q.Lte("NextAttemptAt", dueAt),
+	).OrderBy("ID").Limit(limit).Find(&deliveries)
+	if err != nil && err != storm.ErrNotFound {
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+func (ws *webhookDeliveryStore) UpdateWebhookDelivery(ctx context.Context, tx repository.Transaction, delivery repository.WebhookDelivery) error {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	delivery.UpdatedAt = ws.TimeNow()
+	return queryExecutor.Save(&delivery)
+}
+
+func webhookDeliveryMatchers(filter repository.WebhookDeliveryFilter) []q.Matcher {
+	matchers := make([]q.Matcher, 0)
+
+	if filter.SubscriptionID != 0 {
+		matchers = append(matchers, q.Eq("SubscriptionID", filter.SubscriptionID))
+	}
+
+	if filter.Status != "" {
+		matchers = append(matchers, q.Eq("Status", filter.Status))
+	}
+
+	return matchers
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type webhookSubscriptionStore struct {
+	BaseRepository
+}
+
+func NewWebhookSubscriptionRepo(db *storm.DB) repository.WebhookSubscriptionStorer {
+	return &webhookSubscriptionStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (ws *webhookSubscriptionStore) CreateWebhookSubscription(ctx context.Context, tx repository.Transaction, subscription repository.WebhookSubscription) (repository.WebhookSubscription, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	subscription.CreatedAt = ws.TimeNow()
+	subscription.UpdatedAt = subscription.CreatedAt
+	err := queryExecutor.Save(&subscription)
+	if err != nil {
+		return repository.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (ws *webhookSubscriptionStore) GetWebhookSubscriptionByID(ctx context.Context, tx repository.Transaction, subscriptionID int64) (repository.WebhookSubscription, error) {
+	var subscription repository.WebhookSubscription
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.One("ID", subscriptionID, &subscription)
+	if err != nil && err != storm.ErrNotFound {
+		return repository.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (ws *webhookSubscriptionStore) ListWebhookSubscriptions(ctx context.Context, tx repository.Transaction) ([]repository.WebhookSubscription, error) {
+	subscriptions := make([]repository.WebhookSubscription, 0)
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.All(&subscriptions)
+	if err != nil && err != storm.ErrNotFound {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+func (ws *webhookSubscriptionStore) DeleteWebhookSubscription(ctx context.Context, tx repository.Transaction, subscriptionID int64) error {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.Select(q.Eq("ID", uint(subscriptionID))).Delete(&repository.WebhookSubscription{})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type CartStorer interface {
+	RepositoryTransaction
+
+	CreateCart(ctx context.Context, tx Transaction, cart Cart) (Cart, error)
+	GetCartByID(ctx context.Context, tx Transaction, cartID int64) (Cart, error)
+	// TransitionCartStatus moves the cart to newStatus only if it is still in currentStatus,
+	// updated is false when another request changed the cart first
+	TransitionCartStatus(ctx context.Context, tx Transaction, cartID int64, currentStatus, newStatus string, orderID int64) (updated bool, err error)
+	GetCartItems(ctx context.Context, tx Transaction, cartID int64) ([]CartItem, error)
+	UpsertCartItem(ctx context.Context, tx Transaction, cartItem CartItem) error
+	DeleteCartItem(ctx context.Context, tx Transaction, cartID, productID int64) error
+}
+
+type Cart struct {
+	ID         uint `storm:"id,increment"`
+	CustomerID int64
+	Status     string
+	OrderID    int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CartItem struct {
+	ID        uint  `storm:"id,increment"`
+	CartID    int64 `storm:"index"`
+	ProductID int64
+	Quantity  int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type CouponStorer interface {
+	RepositoryTransaction
+
+	CreateCoupon(ctx context.Context, tx Transaction, coupon Coupon) (Coupon, error)
+	GetCouponByID(ctx context.Context, tx Transaction, couponID int64) (Coupon, error)
+	GetCouponByCode(ctx context.Context, tx Transaction, code string) (Coupon, error)
+	ListCoupons(ctx context.Context, tx Transaction) ([]Coupon, error)
+	// RedeemCoupon records the redemption only while the coupon is under its overall and per customer usage limits,
+	// redeemed is false when a limit is reached. Concurrent redemptions of a coupon within transactions are
+	// serialized on the coupon, so the limits hold across them.
+	RedeemCoupon(ctx context.Context, tx Transaction, redemption CouponRedemption) (redeemed bool, err error)
+	CountCouponRedemptions(ctx context.Context, tx Transaction, couponID, customerID int64) (int64, error)
+}
+
+// Coupon takes a discount off the eligible products of an order redeeming its Code, a zero limit is unlimited
+type Coupon struct {
+	ID   uint   `storm:"id,increment"`
+	Code string `storm:"unique"`
+	//DiscountType is percentage, taking Percent off, or fixed, taking Amount minor units of Currency off
+	DiscountType string
+	Percent      float64
+	Amount       int64
+	Currency     string
+	//Categories the coupon applies to, every product when empty
+	Categories       []string
+	ValidFrom        time.Time
+	ValidUntil       time.Time
+	UsageLimit       int64
+	PerCustomerLimit int64
+	TimesUsed        int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type CouponRedemption struct {
+	ID         uint  `storm:"id,increment"`
+	CouponID   int64 `storm:"index"`
+	CustomerID int64
+	OrderID    int64
+	//Amount taken off the order, in minor units of Currency
+	Amount    int64
+	Currency  string
+	CreatedAt time.Time
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type CustomerStorer interface {
+	RepositoryTransaction
+
+	CreateCustomer(ctx context.Context, tx Transaction, customer Customer) (Customer, error)
+	GetCustomerByID(ctx context.Context, tx Transaction, customerID int64) (Customer, error)
+	GetCustomerByEmail(ctx context.Context, tx Transaction, email string) (Customer, error)
+	// UpdateCustomer saves the name and replaces the address list of the customer
+	UpdateCustomer(ctx context.Context, tx Transaction, customer Customer) (Customer, error)
+}
+
+type Customer struct {
+	ID        uint `storm:"id,increment"`
+	Name      string
+	Email     string `storm:"unique"`
+	Addresses []Address
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Address struct {
+	Label      string
+	Line1      string
+	Line2      string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
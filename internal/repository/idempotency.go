@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+)
+
+// IdempotencyStorer keeps the responses of requests sent with an Idempotency-Key header,
+// keys are scoped to the customer who sent them
+type IdempotencyStorer interface {
+	RepositoryTransaction
+
+	GetIdempotencyKey(ctx context.Context, tx Transaction, customerID int64, key string) (IdempotencyKey, error)
+	CreateIdempotencyKey(ctx context.Context, tx Transaction, idempotencyKey IdempotencyKey) (IdempotencyKey, error)
+}
+
+type IdempotencyKey struct {
+	ID         uint  `storm:"id,increment"`
+	CustomerID int64 `storm:"index"`
+	Key        string
+	//RequestHash fingerprints the request body so a reused key with a different body is detected
+	RequestHash string
+	//Response is the JSON encoded response replayed for repeats of the request
+	Response  []byte
+	CreatedAt time.Time
+}
+
+// ConvertLegacyResponse rewrites an order response stored while amounts were floats in major units of currency,
+// so replays return the amounts as money. Amounts already held as money are kept.
+func ConvertLegacyResponse(response []byte, currency string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(response))
+	decoder.UseNumber()
+
+	order := make(map[string]interface{})
+	err := decoder.Decode(&order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = convertLegacyAmount(order, "amount", currency)
+	if err != nil {
+		return nil, err
+	}
+
+	err = convertLegacyAmount(order, "final_amount", currency)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, _ := order["discounts"].([]interface{})
+	for _, discount := range discounts {
+		if discountFields, ok := discount.(map[string]interface{}); ok {
+			err = convertLegacyAmount(discountFields, "amount", currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return json.Marshal(order)
+}
+
+// convertLegacyAmount turns the number of the field into money, amounts already converted are objects
+func convertLegacyAmount(fields map[string]interface{}, field, currency string) error {
+	amount, ok := fields[field].(json.Number)
+	if !ok {
+		return nil
+	}
+
+	major, err := amount.Float64()
+	if err != nil {
+		return err
+	}
+
+	fields[field], err = money.FromMajor(major, currency)
+	return err
+}
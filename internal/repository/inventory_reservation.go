@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	//ReservationActive holds stock for an order until it expires
+	ReservationActive = "active"
+	//ReservationCommitted marks holds whose stock was taken from the product quantity
+	ReservationCommitted = "committed"
+	//ReservationReleased marks holds given back, by a cancelled order or by the sweeper once expired
+	ReservationReleased = "released"
+)
+
+// InventoryReservationStorer keeps the stock held for orders. A product can be sold up to its
+// quantity minus the active reservations which have not expired yet.
+type InventoryReservationStorer interface {
+	RepositoryTransaction
+
+	CreateReservations(ctx context.Context, tx Transaction, reservations []InventoryReservation) error
+	ListReservationsByOrderID(ctx context.Context, tx Transaction, orderID int64) ([]InventoryReservation, error)
+	// GetReservedQuantities sums the active reservations of the products which have not expired at `at`,
+	// products without such reservations are left out of the map
+	GetReservedQuantities(ctx context.Context, tx Transaction, productIDs []int64, at time.Time) (map[int64]int64, error)
+	// ListExpiredReservations returns at most limit active reservations which expired at or before `at`, oldest first
+	ListExpiredReservations(ctx context.Context, tx Transaction, at time.Time, limit int) ([]InventoryReservation, error)
+	UpdateReservationStatus(ctx context.Context, tx Transaction, reservationIDs []int64, status string) error
+}
+
+type InventoryReservation struct {
+	ID        uint  `storm:"id,increment"`
+	OrderID   int64 `storm:"index"`
+	ProductID int64 `storm:"index"`
+	Quantity  int64
+	Status    string `storm:"index"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Migrator applies the numbered schema migrations of a storage backend.
+// Applied versions are recorded in the database so every step runs once,
+// and each step is idempotent so it can be re-run after a partial failure.
+type Migrator interface {
+	// Migrate applies all pending migrations in version order and returns them.
+	// With dryRun set nothing is written and the pending migrations are only reported.
+	Migrate(ctx context.Context, dryRun bool) ([]SchemaMigration, error)
+	// CheckCurrency fails with the database migrated when it holds products, fixed coupons or orders
+	// priced in another currency than the catalog the migrator was built for.
+	CheckCurrency(ctx context.Context) error
+}
+
+type SchemaMigration struct {
+	Version     int `storm:"id"`
+	Description string
+	AppliedAt   time.Time
+}
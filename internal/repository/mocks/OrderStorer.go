@@ -42,6 +42,30 @@ func (_m *OrderStorer) BeginTx(ctx context.Context) (repository.Transaction, err
 	return r0, r1
 }
 
+// CountOrders provides a mock function with given fields: ctx, tx, filter
+func (_m *OrderStorer) CountOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) (int64, error) {
+	ret := _m.Called(ctx, tx, filter)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.OrderFilter) (int64, error)); ok {
+		return rf(ctx, tx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.OrderFilter) int64); ok {
+		r0 = rf(ctx, tx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.OrderFilter) error); ok {
+		r1 = rf(ctx, tx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateOrder provides a mock function with given fields: ctx, tx, order
 func (_m *OrderStorer) CreateOrder(ctx context.Context, tx repository.Transaction, order repository.Order) (repository.Order, error) {
 	ret := _m.Called(ctx, tx, order)
@@ -104,25 +128,25 @@ func (_m *OrderStorer) HandleTransaction(ctx context.Context, tx repository.Tran
 	return r0
 }
 
-// ListOrders provides a mock function with given fields: ctx, tx
-func (_m *OrderStorer) ListOrders(ctx context.Context, tx repository.Transaction) ([]repository.Order, error) {
-	ret := _m.Called(ctx, tx)
+// ListOrders provides a mock function with given fields: ctx, tx, filter
+func (_m *OrderStorer) ListOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) ([]repository.Order, error) {
+	ret := _m.Called(ctx, tx, filter)
 
 	var r0 []repository.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction) ([]repository.Order, error)); ok {
-		return rf(ctx, tx)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.OrderFilter) ([]repository.Order, error)); ok {
+		return rf(ctx, tx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction) []repository.Order); ok {
-		r0 = rf(ctx, tx)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.OrderFilter) []repository.Order); ok {
+		r0 = rf(ctx, tx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repository.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction) error); ok {
-		r1 = rf(ctx, tx)
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.OrderFilter) error); ok {
+		r1 = rf(ctx, tx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
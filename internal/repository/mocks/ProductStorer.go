@@ -7,6 +7,8 @@ import (
 
 	repository "github.com/sagar23sj/go-ecommerce/internal/repository"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ProductStorer is an autogenerated mock type for the ProductStorer type
@@ -14,6 +16,44 @@ type ProductStorer struct {
 	mock.Mock
 }
 
+// AdjustProductQuantity provides a mock function with given fields: ctx, tx, productID, delta
+func (_m *ProductStorer) AdjustProductQuantity(ctx context.Context, tx repository.Transaction, productID int64, delta int64) (repository.Product, error) {
+	ret := _m.Called(ctx, tx, productID, delta)
+
+	var r0 repository.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64) (repository.Product, error)); ok {
+		return rf(ctx, tx, productID, delta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64) repository.Product); ok {
+		r0 = rf(ctx, tx, productID, delta)
+	} else {
+		r0 = ret.Get(0).(repository.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64, int64) error); ok {
+		r1 = rf(ctx, tx, productID, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchiveProduct provides a mock function with given fields: ctx, tx, productID, archivedAt
+func (_m *ProductStorer) ArchiveProduct(ctx context.Context, tx repository.Transaction, productID int64, archivedAt time.Time) error {
+	ret := _m.Called(ctx, tx, productID, archivedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, time.Time) error); ok {
+		r0 = rf(ctx, tx, productID, archivedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BeginTx provides a mock function with given fields: ctx
 func (_m *ProductStorer) BeginTx(ctx context.Context) (repository.Transaction, error) {
 	ret := _m.Called(ctx)
@@ -40,6 +80,54 @@ func (_m *ProductStorer) BeginTx(ctx context.Context) (repository.Transaction, e
 	return r0, r1
 }
 
+// CountProducts provides a mock function with given fields: ctx, tx, filter
+func (_m *ProductStorer) CountProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) (int64, error) {
+	ret := _m.Called(ctx, tx, filter)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.ProductFilter) (int64, error)); ok {
+		return rf(ctx, tx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.ProductFilter) int64); ok {
+		r0 = rf(ctx, tx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.ProductFilter) error); ok {
+		r1 = rf(ctx, tx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProduct provides a mock function with given fields: ctx, tx, product
+func (_m *ProductStorer) CreateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	ret := _m.Called(ctx, tx, product)
+
+	var r0 repository.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.Product) (repository.Product, error)); ok {
+		return rf(ctx, tx, product)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.Product) repository.Product); ok {
+		r0 = rf(ctx, tx, product)
+	} else {
+		r0 = ret.Get(0).(repository.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.Product) error); ok {
+		r1 = rf(ctx, tx, product)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductByID provides a mock function with given fields: ctx, tx, productID
 func (_m *ProductStorer) GetProductByID(ctx context.Context, tx repository.Transaction, productID int64) (repository.Product, error) {
 	ret := _m.Called(ctx, tx, productID)
@@ -78,8 +166,34 @@ func (_m *ProductStorer) HandleTransaction(ctx context.Context, tx repository.Tr
 	return r0
 }
 
-// ListProducts provides a mock function with given fields: ctx, tx
-func (_m *ProductStorer) ListProducts(ctx context.Context, tx repository.Transaction) ([]repository.Product, error) {
+// ListProductQuantities provides a mock function with given fields: ctx, tx
+func (_m *ProductStorer) ListProductQuantities(ctx context.Context, tx repository.Transaction) (map[int64]int64, error) {
+	ret := _m.Called(ctx, tx)
+
+	var r0 map[int64]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction) (map[int64]int64, error)); ok {
+		return rf(ctx, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction) map[int64]int64); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction) error); ok {
+		r1 = rf(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReorderProducts provides a mock function with given fields: ctx, tx
+func (_m *ProductStorer) ListReorderProducts(ctx context.Context, tx repository.Transaction) ([]repository.Product, error) {
 	ret := _m.Called(ctx, tx)
 
 	var r0 []repository.Product
@@ -104,13 +218,13 @@ func (_m *ProductStorer) ListProducts(ctx context.Context, tx repository.Transac
 	return r0, r1
 }
 
-// UpdateProductQuantity provides a mock function with given fields: ctx, tx, productsQuantityMap
-func (_m *ProductStorer) UpdateProductQuantity(ctx context.Context, tx repository.Transaction, productsQuantityMap map[int64]int64) error {
-	ret := _m.Called(ctx, tx, productsQuantityMap)
+// LockProducts provides a mock function with given fields: ctx, tx, productIDs
+func (_m *ProductStorer) LockProducts(ctx context.Context, tx repository.Transaction, productIDs []int64) error {
+	ret := _m.Called(ctx, tx, productIDs)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, map[int64]int64) error); ok {
-		r0 = rf(ctx, tx, productsQuantityMap)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, []int64) error); ok {
+		r0 = rf(ctx, tx, productIDs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -118,6 +232,56 @@ func (_m *ProductStorer) UpdateProductQuantity(ctx context.Context, tx repositor
 	return r0
 }
 
+// SearchProducts provides a mock function with given fields: ctx, tx, filter
+func (_m *ProductStorer) SearchProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) ([]repository.Product, error) {
+	ret := _m.Called(ctx, tx, filter)
+
+	var r0 []repository.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.ProductFilter) ([]repository.Product, error)); ok {
+		return rf(ctx, tx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.ProductFilter) []repository.Product); ok {
+		r0 = rf(ctx, tx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.ProductFilter) error); ok {
+		r1 = rf(ctx, tx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: ctx, tx, product
+func (_m *ProductStorer) UpdateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	ret := _m.Called(ctx, tx, product)
+
+	var r0 repository.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.Product) (repository.Product, error)); ok {
+		return rf(ctx, tx, product)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, repository.Product) repository.Product); ok {
+		r0 = rf(ctx, tx, product)
+	} else {
+		r0 = ret.Get(0).(repository.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, repository.Product) error); ok {
+		r1 = rf(ctx, tx, product)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewProductStorer interface {
 	mock.TestingT
 	Cleanup(func())
@@ -12,16 +12,72 @@ type OrderStorer interface {
 	CreateOrder(ctx context.Context, tx Transaction, order Order) (Order, error)
 	UpdateOrderStatus(ctx context.Context, tx Transaction, orderID int64, status string) error
 	UpdateOrderDispatchDate(ctx context.Context, tx Transaction, orderID int64, dispatchedAt time.Time) error
-	ListOrders(ctx context.Context, tx Transaction) ([]Order, error)
+	// ListOrders returns one page of the orders matching the filter, sorted as the filter asks
+	ListOrders(ctx context.Context, tx Transaction, filter OrderFilter) ([]Order, error)
+	// CountOrders returns the number of orders matching the filter, ignoring its sort and page
+	CountOrders(ctx context.Context, tx Transaction, filter OrderFilter) (int64, error)
 }
 
+// columns the orders can be sorted by
+const (
+	OrderSortByID          = "id"
+	OrderSortByCreatedAt   = "created_at"
+	OrderSortByFinalAmount = "final_amount"
+)
+
+// OrderFilter narrows down the orders returned by ListOrders, zero values leave a filter unset
+type OrderFilter struct {
+	//CustomerID limits the orders to one customer, 0 matches every customer
+	CustomerID int64
+	Statuses   []string
+	//CreatedFrom is inclusive and CreatedTo is exclusive
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	//Currency limits the orders to the ones placed in it, empty matches every currency
+	Currency string
+	//MinFinalAmount and MaxFinalAmount are in minor units of the order currency
+	MinFinalAmount *int64
+	MaxFinalAmount *int64
+
+	//SortBy is one of the OrderSortBy columns, orders are sorted by id when empty
+	SortBy   string
+	SortDesc bool
+
+	//Limit of 0 returns every matching order
+	Limit  int
+	Offset int
+}
+
+// Order amounts are in minor units of Currency, the catalog prices in BaseCurrency were converted into it
+// at ExchangeRate when the order was placed
 type Order struct {
-	ID                 uint `storm:"id,increment"`
-	Amount             float64
+	ID                 uint  `storm:"id,increment"`
+	CustomerID         int64 `storm:"index"`
+	Currency           string
+	BaseCurrency       string
+	ExchangeRate       float64
+	Amount             int64
 	DiscountPercentage float64
-	FinalAmount        float64
-	Status             string
-	DispatchedAt       time.Time
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	//Discounts break the difference between Amount and FinalAmount down by promotion
+	Discounts []OrderDiscount
+	//TaxAmount is charged in TaxRegion, the region of ShippingAddress, FinalAmount holds it either way and Amount too when TaxInclusive
+	TaxAmount    int64
+	TaxRegion    string
+	TaxInclusive bool
+	//ShippingAmount is charged by ShippingMethod and included untaxed in FinalAmount, orders without a method are not shipped
+	ShippingMethod  string
+	ShippingAmount  int64
+	ShippingAddress Address
+	FinalAmount     int64
+	Status          string
+	DispatchedAt    time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// OrderDiscount is the Amount a promotion took off the order when it was placed
+type OrderDiscount struct {
+	Promotion string
+	Type      string
+	Amount    int64
 }
@@ -12,11 +12,20 @@ type OrderItemStorer interface {
 	StoreOrderItems(ctx context.Context, tx Transaction, orderItems []OrderItem) error
 }
 
+// OrderItem keeps the product as it was sold, UnitPrice and Discount are in minor units of the order currency.
+// Discount is the share of the order discounts taken off the line, items stored before they were snapshotted have no ProductName.
+// Tax is charged on the line at TaxRate percent.
 type OrderItem struct {
-	ID        uint `storm:"id,increment"`
-	OrderID   int64
-	ProductID int64
-	Quantity  int64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uint `storm:"id,increment"`
+	OrderID     int64
+	ProductID   int64
+	ProductName string
+	Category    string
+	UnitPrice   int64
+	Quantity    int64
+	Discount    int64
+	TaxRate     float64
+	Tax         int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type OrderStatusEventStorer interface {
+	RepositoryTransaction
+
+	CreateOrderStatusEvent(ctx context.Context, tx Transaction, event OrderStatusEvent) (OrderStatusEvent, error)
+	// ListOrderStatusEvents returns the status changes of the order, oldest first
+	ListOrderStatusEvents(ctx context.Context, tx Transaction, orderID int64) ([]OrderStatusEvent, error)
+}
+
+// OrderStatusEvent records one status transition of an order
+type OrderStatusEvent struct {
+	ID         uint  `storm:"id,increment"`
+	OrderID    int64 `storm:"index"`
+	FromStatus string
+	ToStatus   string
+	//Actor is the subject of the principal who requested the transition
+	Actor     string
+	ActorRole string
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	//OutboxStatusFailed marks events which ran out of delivery attempts
+	OutboxStatusFailed = "failed"
+)
+
+// OutboxStorer keeps domain events until the relay delivers them. Events are created
+// in the transaction of the change they describe, so neither is stored without the other.
+type OutboxStorer interface {
+	RepositoryTransaction
+
+	CreateOutboxEvent(ctx context.Context, tx Transaction, event OutboxEvent) (OutboxEvent, error)
+	// ListPendingOutboxEvents returns at most limit pending events due at or before dueAt, oldest first
+	ListPendingOutboxEvents(ctx context.Context, tx Transaction, dueAt time.Time, limit int) ([]OutboxEvent, error)
+	// UpdateOutboxEvent stores the delivery state of the event
+	UpdateOutboxEvent(ctx context.Context, tx Transaction, event OutboxEvent) error
+}
+
+type OutboxEvent struct {
+	ID          uint `storm:"id,increment"`
+	Type        string
+	AggregateID int64
+	//Payload is the JSON encoded event body
+	Payload       []byte
+	Status        string `storm:"index"`
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DeliveredAt   time.Time
+	CreatedAt     time.Time
+}
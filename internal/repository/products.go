@@ -9,16 +9,78 @@ type ProductStorer interface {
 	RepositoryTransaction
 
 	GetProductByID(ctx context.Context, tx Transaction, productID int64) (Product, error)
-	ListProducts(ctx context.Context, tx Transaction) ([]Product, error)
-	UpdateProductQuantity(ctx context.Context, tx Transaction, productsQuantityMap map[int64]int64) error
+	// SearchProducts returns one page of the catalog products matching the filter, sorted as the filter asks
+	SearchProducts(ctx context.Context, tx Transaction, filter ProductFilter) ([]Product, error)
+	// CountProducts returns the number of catalog products matching the filter, ignoring its sort and page
+	CountProducts(ctx context.Context, tx Transaction, filter ProductFilter) (int64, error)
+	CreateProduct(ctx context.Context, tx Transaction, product Product) (Product, error)
+	// UpdateProduct saves the product details, its quantity only changes through AdjustProductQuantity
+	UpdateProduct(ctx context.Context, tx Transaction, product Product) (Product, error)
+	ArchiveProduct(ctx context.Context, tx Transaction, productID int64, archivedAt time.Time) error
+	// AdjustProductQuantity adds delta to the quantity on hand and returns the adjusted product,
+	// the product is zero when it does not exist or holds fewer than -delta units
+	AdjustProductQuantity(ctx context.Context, tx Transaction, productID int64, delta int64) (Product, error)
+	// ListProductQuantities returns the quantity on hand of every product, archived ones included
+	ListProductQuantities(ctx context.Context, tx Transaction) (map[int64]int64, error)
+	// ListReorderProducts returns the catalog products having a reorder threshold, sorted by id
+	ListReorderProducts(ctx context.Context, tx Transaction) ([]Product, error)
+	// LockProducts holds the products until the transaction ends, so concurrent transactions checking
+	// and reserving the stock of the same products do so one after the other
+	LockProducts(ctx context.Context, tx Transaction, productIDs []int64) error
+}
+
+// columns the products can be sorted by
+const (
+	ProductSortByID        = "id"
+	ProductSortByName      = "name"
+	ProductSortByPrice     = "price"
+	ProductSortByCreatedAt = "created_at"
+)
+
+// ProductFilter narrows down the catalog returned by SearchProducts, zero values leave a filter unset.
+// Archived products are never matched.
+type ProductFilter struct {
+	Category string
+	//MinPrice and MaxPrice are in minor units of the catalog currency
+	MinPrice *int64
+	MaxPrice *int64
+	//InStockAt matches the products with stock on hand beyond the reservations active at that time
+	InStockAt time.Time
+	//NameContains and NamePrefix match the product name case insensitively
+	NameContains string
+	NamePrefix   string
+
+	//SortBy is one of the ProductSortBy columns, products are sorted by id when empty
+	SortBy   string
+	SortDesc bool
+
+	//Limit of 0 returns every matching product
+	Limit  int
+	Offset int
 }
 
 type Product struct {
-	ID        uint `storm:"id,increment"`
-	Name      string
-	Price     float64
-	Category  string
-	Quantity  int64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID   uint `storm:"id,increment"`
+	Name string
+	//Price is in minor units of Currency
+	Price    int64
+	Currency string
+	//Prices set the price in other currencies, in their minor units by currency code,
+	//orders placed in a currency without a price of its own convert Price
+	Prices   map[string]int64
+	Category string `storm:"index"`
+	Quantity int64
+	//ReorderThreshold is the stock available to sell at or below which the product needs reordering,
+	//0 never reports it, ReorderQuantity is the amount suggested to reorder
+	ReorderThreshold int64
+	ReorderQuantity  int64
+	//WeightGrams and the dimensions in millimetres are 0 when unknown
+	WeightGrams int64
+	LengthMM    int64
+	WidthMM     int64
+	HeightMM    int64
+	Archived    bool
+	ArchivedAt  time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
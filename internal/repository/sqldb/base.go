@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+// queryExecutor is implemented by both *sql.DB and *sql.Tx
+type queryExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type BaseRepository struct {
+	DB *sql.DB
+}
+
+type BaseTransaction struct {
+	tx *sql.Tx
+}
+
+func (repo *BaseRepository) TimeNow() time.Time {
+	return time.Now().UTC()
+}
+
+func (repo *BaseRepository) BeginTx(ctx context.Context) (repository.Transaction, error) {
+
+	txObj, err := repo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("error occured while initiating database transaction: %v", err.Error())
+		return nil, err
+	}
+
+	return &BaseTransaction{
+		tx: txObj,
+	}, nil
+}
+
+func (repo *BaseRepository) HandleTransaction(ctx context.Context, tx repository.Transaction, incomingErr error) (err error) {
+	if incomingErr != nil {
+		err = tx.Rollback()
+		if err != nil {
+			log.Printf("error occured while rollback database transaction: %v", err.Error())
+			return
+		}
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("error occured while commit database transaction: %v", err.Error())
+		return
+	}
+	return
+}
+
+func (repo *BaseTransaction) Commit() error {
+	return repo.tx.Commit()
+}
+
+func (repo *BaseTransaction) Rollback() error {
+	return repo.tx.Rollback()
+}
+
+func (repo *BaseRepository) initiateQueryExecutor(tx repository.Transaction) (executor queryExecutor) {
+	//Populate the query executor so we can use a transaction if one is present.
+	//If we are not running inside a transaction then the plain *sql.DB object is used.
+	executor = repo.DB
+	if tx != nil {
+		txObj := tx.(*BaseTransaction)
+		executor = txObj.tx
+	}
+
+	return executor
+}
+
+// nullTime stores zero time values as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDatabase opens an empty sqlite database and migrates it to the latest version
+func newTestDatabase(t *testing.T) *sql.DB {
+	db, err := InitializeDatabase(DriverSQLite, "file:"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = NewMigrator(db, DriverSQLite, "INR").Migrate(context.Background(), false)
+	require.NoError(t, err)
+
+	return db
+}
+
+// newPostgresTestDatabase opens the postgres database of TEST_POSTGRES_DSN and migrates it to the latest version,
+// the test is skipped without one
+func newPostgresTestDatabase(t *testing.T) *sql.DB {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := InitializeDatabase(DriverPostgres, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = NewMigrator(db, DriverPostgres, "INR").Migrate(context.Background(), false)
+	require.NoError(t, err)
+
+	return db
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type cartStore struct {
+	BaseRepository
+}
+
+func NewCartRepo(db *sql.DB) repository.CartStorer {
+	return &cartStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (cs *cartStore) CreateCart(ctx context.Context, tx repository.Transaction, cart repository.Cart) (repository.Cart, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	cart.CreatedAt = cs.TimeNow()
+	cart.UpdatedAt = cs.TimeNow()
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO carts (customer_id, status, order_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		cart.CustomerID, cart.Status, cart.OrderID, cart.CreatedAt, cart.UpdatedAt,
+	).Scan(&cart.ID)
+	if err != nil {
+		return repository.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (cs *cartStore) GetCartByID(ctx context.Context, tx repository.Transaction, cartID int64) (repository.Cart, error) {
+	var cart repository.Cart
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx,
+		`SELECT id, customer_id, status, order_id, created_at, updated_at FROM carts WHERE id = $1`, cartID,
+	).Scan(&cart.ID, &cart.CustomerID, &cart.Status, &cart.OrderID, &cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (cs *cartStore) TransitionCartStatus(ctx context.Context, tx repository.Transaction, cartID int64, currentStatus, newStatus string, orderID int64) (bool, error) {
+	//the status condition makes the update a compare and swap across concurrent transactions
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	result, err := queryExecutor.ExecContext(ctx,
+		`UPDATE carts SET status = $1, order_id = $2, updated_at = $3 WHERE id = $4 AND status = $5`,
+		newStatus, orderID, cs.TimeNow(), cartID, currentStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (cs *cartStore) GetCartItems(ctx context.Context, tx repository.Transaction, cartID int64) ([]repository.CartItem, error) {
+	cartItems := make([]repository.CartItem, 0)
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT id, cart_id, product_id, quantity, created_at, updated_at FROM cart_items WHERE cart_id = $1 ORDER BY id`,
+		cartID)
+	if err != nil {
+		return cartItems, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cartItem repository.CartItem
+		err = rows.Scan(&cartItem.ID, &cartItem.CartID, &cartItem.ProductID, &cartItem.Quantity,
+			&cartItem.CreatedAt, &cartItem.UpdatedAt)
+		if err != nil {
+			return cartItems, err
+		}
+
+		cartItems = append(cartItems, cartItem)
+	}
+
+	return cartItems, rows.Err()
+}
+
+func (cs *cartStore) UpsertCartItem(ctx context.Context, tx repository.Transaction, cartItem repository.CartItem) error {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	cartItem.CreatedAt = cs.TimeNow()
+	cartItem.UpdatedAt = cs.TimeNow()
+	_, err := queryExecutor.ExecContext(ctx,
+		`INSERT INTO cart_items (cart_id, product_id, quantity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = excluded.quantity, updated_at = excluded.updated_at`,
+		cartItem.CartID, cartItem.ProductID, cartItem.Quantity, cartItem.CreatedAt, cartItem.UpdatedAt)
+	return err
+}
+
+func (cs *cartStore) DeleteCartItem(ctx context.Context, tx repository.Transaction, cartID, productID int64) error {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx, `DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2`, cartID, productID)
+	return err
+}
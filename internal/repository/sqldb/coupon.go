@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const couponColumns = `id, code, discount_type, percent, amount, currency, categories, valid_from, valid_until, usage_limit, per_customer_limit,
+	times_used, created_at, updated_at`
+
+type couponStore struct {
+	BaseRepository
+}
+
+func NewCouponRepo(db *sql.DB) repository.CouponStorer {
+	return &couponStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+// categories are stored comma separated, they never contain commas
+func scanCoupon(row rowScanner) (repository.Coupon, error) {
+	var coupon repository.Coupon
+	var categories string
+	var validFrom, validUntil sql.NullTime
+
+	err := row.Scan(&coupon.ID, &coupon.Code, &coupon.DiscountType, &coupon.Percent, &coupon.Amount, &coupon.Currency, &categories, &validFrom, &validUntil,
+		&coupon.UsageLimit, &coupon.PerCustomerLimit, &coupon.TimesUsed, &coupon.CreatedAt, &coupon.UpdatedAt)
+	if err != nil {
+		return repository.Coupon{}, err
+	}
+
+	coupon.Categories = make([]string, 0)
+	if categories != "" {
+		coupon.Categories = strings.Split(categories, ",")
+	}
+
+	coupon.ValidFrom = validFrom.Time
+	coupon.ValidUntil = validUntil.Time
+	return coupon, nil
+}
+
+func (cs *couponStore) CreateCoupon(ctx context.Context, tx repository.Transaction, coupon repository.Coupon) (repository.Coupon, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	coupon.CreatedAt = cs.TimeNow()
+	coupon.UpdatedAt = coupon.CreatedAt
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO coupons (code, discount_type, percent, amount, currency, categories, valid_from, valid_until, usage_limit,
+		per_customer_limit, times_used, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
+		coupon.Code, coupon.DiscountType, coupon.Percent, coupon.Amount, coupon.Currency, strings.Join(coupon.Categories, ","),
+		nullTime(coupon.ValidFrom), nullTime(coupon.ValidUntil), coupon.UsageLimit, coupon.PerCustomerLimit,
+		coupon.TimesUsed, coupon.CreatedAt, coupon.UpdatedAt,
+	).Scan(&coupon.ID)
+	if err != nil {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) GetCouponByID(ctx context.Context, tx repository.Transaction, couponID int64) (repository.Coupon, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+couponColumns+` FROM coupons WHERE id = $1`, couponID)
+
+	coupon, err := scanCoupon(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) GetCouponByCode(ctx context.Context, tx repository.Transaction, code string) (repository.Coupon, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+couponColumns+` FROM coupons WHERE code = $1`, code)
+
+	coupon, err := scanCoupon(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (cs *couponStore) ListCoupons(ctx context.Context, tx repository.Transaction) ([]repository.Coupon, error) {
+	coupons := make([]repository.Coupon, 0)
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, `SELECT `+couponColumns+` FROM coupons ORDER BY id`)
+	if err != nil {
+		return coupons, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			return coupons, err
+		}
+
+		coupons = append(coupons, coupon)
+	}
+
+	return coupons, rows.Err()
+}
+
+func (cs *couponStore) RedeemCoupon(ctx context.Context, tx repository.Transaction, redemption repository.CouponRedemption) (bool, error) {
+	//touching the coupon locks its row until the transaction ends, so concurrent
+	//redemptions of the coupon check and update its usage one after the other
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	now := cs.TimeNow()
+	result, err := queryExecutor.ExecContext(ctx, `UPDATE coupons SET updated_at = $1 WHERE id = $2`, now, redemption.CouponID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	var usageLimit, perCustomerLimit, timesUsed int64
+	err = queryExecutor.QueryRowContext(ctx, `SELECT usage_limit, per_customer_limit, times_used FROM coupons WHERE id = $1`,
+		redemption.CouponID).Scan(&usageLimit, &perCustomerLimit, &timesUsed)
+	if err != nil {
+		return false, err
+	}
+
+	if usageLimit > 0 && timesUsed >= usageLimit {
+		return false, nil
+	}
+
+	if perCustomerLimit > 0 {
+		customerRedemptions, err := cs.CountCouponRedemptions(ctx, tx, redemption.CouponID, redemption.CustomerID)
+		if err != nil {
+			return false, err
+		}
+
+		if customerRedemptions >= perCustomerLimit {
+			return false, nil
+		}
+	}
+
+	_, err = queryExecutor.ExecContext(ctx, `UPDATE coupons SET times_used = times_used + 1 WHERE id = $1`, redemption.CouponID)
+	if err != nil {
+		return false, err
+	}
+
+	redemption.CreatedAt = now
+	_, err = queryExecutor.ExecContext(ctx,
+		`INSERT INTO coupon_redemptions (coupon_id, customer_id, order_id, amount, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		redemption.CouponID, redemption.CustomerID, redemption.OrderID, redemption.Amount, redemption.Currency, redemption.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (cs *couponStore) CountCouponRedemptions(ctx context.Context, tx repository.Transaction, couponID, customerID int64) (int64, error) {
+	var count int64
+
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM coupon_redemptions WHERE coupon_id = $1 AND customer_id = $2`, couponID, customerID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
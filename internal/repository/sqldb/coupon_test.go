@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCouponStore(t *testing.T) {
+	ctx := context.Background()
+	couponRepo := NewCouponRepo(newTestDatabase(t))
+
+	validUntil := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	created, err := couponRepo.CreateCoupon(ctx, nil, repository.Coupon{
+		Code:             "WELCOME10",
+		DiscountType:     "percentage",
+		Percent:          10,
+		Currency:         "INR",
+		Categories:       []string{"Premium", "Regular"},
+		ValidUntil:       validUntil,
+		UsageLimit:       2,
+		PerCustomerLimit: 1,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+
+	//codes are unique
+	_, err = couponRepo.CreateCoupon(ctx, nil, repository.Coupon{Code: "WELCOME10", DiscountType: "fixed", Amount: 500, Currency: "INR"})
+	assert.Error(t, err)
+
+	found, err := couponRepo.GetCouponByCode(ctx, nil, "WELCOME10")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.Equal(t, []string{"Premium", "Regular"}, found.Categories)
+	assert.Equal(t, 10.0, found.Percent)
+	assert.True(t, found.ValidFrom.IsZero())
+	assert.True(t, validUntil.Equal(found.ValidUntil))
+
+	//not found returns a zero coupon
+	found, err = couponRepo.GetCouponByCode(ctx, nil, "UNKNOWN")
+	require.NoError(t, err)
+	assert.Zero(t, found.ID)
+
+	couponID := int64(created.ID)
+	redeemed, err := couponRepo.RedeemCoupon(ctx, nil, repository.CouponRedemption{CouponID: couponID, CustomerID: 1, OrderID: 1, Amount: 1200, Currency: "INR"})
+	require.NoError(t, err)
+	assert.True(t, redeemed)
+
+	//the per customer limit is reached
+	redeemed, err = couponRepo.RedeemCoupon(ctx, nil, repository.CouponRedemption{CouponID: couponID, CustomerID: 1, OrderID: 2, Amount: 1200, Currency: "INR"})
+	require.NoError(t, err)
+	assert.False(t, redeemed)
+
+	redeemed, err = couponRepo.RedeemCoupon(ctx, nil, repository.CouponRedemption{CouponID: couponID, CustomerID: 2, OrderID: 3, Amount: 1200, Currency: "INR"})
+	require.NoError(t, err)
+	assert.True(t, redeemed)
+
+	//the overall limit is reached
+	redeemed, err = couponRepo.RedeemCoupon(ctx, nil, repository.CouponRedemption{CouponID: couponID, CustomerID: 3, OrderID: 4, Amount: 1200, Currency: "INR"})
+	require.NoError(t, err)
+	assert.False(t, redeemed)
+
+	count, err := couponRepo.CountCouponRedemptions(ctx, nil, couponID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	coupons, err := couponRepo.ListCoupons(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, coupons, 1)
+	assert.Equal(t, []string{"Premium", "Regular"}, coupons[0].Categories)
+}
+
+func TestCouponStoreConcurrentRedemptions(t *testing.T) {
+	ctx := context.Background()
+	couponRepo := NewCouponRepo(newTestDatabase(t))
+
+	created, err := couponRepo.CreateCoupon(ctx, nil, repository.Coupon{Code: "FIRST3", DiscountType: "fixed", Amount: 500, Currency: "INR", UsageLimit: 3})
+	require.NoError(t, err)
+
+	//every redemption runs in a transaction of its own, as orders redeem coupons
+	var redeemedCount atomic.Int64
+	var wg sync.WaitGroup
+	for customerID := int64(1); customerID <= 10; customerID++ {
+		wg.Add(1)
+		go func(customerID int64) {
+			defer wg.Done()
+
+			tx, err := couponRepo.BeginTx(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			redeemed, err := couponRepo.RedeemCoupon(ctx, tx, repository.CouponRedemption{
+				CouponID: int64(created.ID), CustomerID: customerID, OrderID: customerID, Amount: 500, Currency: "INR",
+			})
+			assert.NoError(t, couponRepo.HandleTransaction(ctx, tx, err))
+			if redeemed {
+				redeemedCount.Add(1)
+			}
+		}(customerID)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(3), redeemedCount.Load())
+
+	found, err := couponRepo.GetCouponByID(ctx, nil, int64(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), found.TimesUsed)
+}
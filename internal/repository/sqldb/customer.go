@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const customerColumns = `id, name, email, created_at, updated_at`
+
+type customerStore struct {
+	BaseRepository
+}
+
+func NewCustomerRepo(db *sql.DB) repository.CustomerStorer {
+	return &customerStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (cs *customerStore) CreateCustomer(ctx context.Context, tx repository.Transaction, customer repository.Customer) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	customer.CreatedAt = cs.TimeNow()
+	customer.UpdatedAt = cs.TimeNow()
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO customers (name, email, created_at, updated_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+		customer.Name, customer.Email, customer.CreatedAt, customer.UpdatedAt,
+	).Scan(&customer.ID)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	err = cs.insertAddresses(ctx, queryExecutor, int64(customer.ID), customer.Addresses)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) GetCustomerByID(ctx context.Context, tx repository.Transaction, customerID int64) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+customerColumns+` FROM customers WHERE id = $1`, customerID)
+
+	return cs.scanCustomerWithAddresses(ctx, queryExecutor, row)
+}
+
+func (cs *customerStore) GetCustomerByEmail(ctx context.Context, tx repository.Transaction, email string) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+customerColumns+` FROM customers WHERE email = $1`, email)
+
+	return cs.scanCustomerWithAddresses(ctx, queryExecutor, row)
+}
+
+func (cs *customerStore) UpdateCustomer(ctx context.Context, tx repository.Transaction, customer repository.Customer) (repository.Customer, error) {
+	queryExecutor := cs.initiateQueryExecutor(tx)
+
+	customer.UpdatedAt = cs.TimeNow()
+	_, err := queryExecutor.ExecContext(ctx, `UPDATE customers SET name = $1, email = $2, updated_at = $3 WHERE id = $4`,
+		customer.Name, customer.Email, customer.UpdatedAt, customer.ID)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	//addresses have no identity of their own, so the list is replaced as a whole
+	_, err = queryExecutor.ExecContext(ctx, `DELETE FROM customer_addresses WHERE customer_id = $1`, customer.ID)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	err = cs.insertAddresses(ctx, queryExecutor, int64(customer.ID), customer.Addresses)
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) scanCustomerWithAddresses(ctx context.Context, queryExecutor queryExecutor, row rowScanner) (repository.Customer, error) {
+	var customer repository.Customer
+
+	err := row.Scan(&customer.ID, &customer.Name, &customer.Email, &customer.CreatedAt, &customer.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return repository.Customer{}, nil
+	}
+
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	customer.Addresses, err = cs.getAddresses(ctx, queryExecutor, int64(customer.ID))
+	if err != nil {
+		return repository.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (cs *customerStore) getAddresses(ctx context.Context, queryExecutor queryExecutor, customerID int64) ([]repository.Address, error) {
+	addresses := make([]repository.Address, 0)
+
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT label, line1, line2, city, state, postal_code, country FROM customer_addresses
+		WHERE customer_id = $1 ORDER BY id`, customerID)
+	if err != nil {
+		return addresses, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address repository.Address
+		err = rows.Scan(&address.Label, &address.Line1, &address.Line2, &address.City,
+			&address.State, &address.PostalCode, &address.Country)
+		if err != nil {
+			return addresses, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, rows.Err()
+}
+
+func (cs *customerStore) insertAddresses(ctx context.Context, queryExecutor queryExecutor, customerID int64, addresses []repository.Address) error {
+	for _, address := range addresses {
+		_, err := queryExecutor.ExecContext(ctx,
+			`INSERT INTO customer_addresses (customer_id, label, line1, line2, city, state, postal_code, country)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			customerID, address.Label, address.Line1, address.Line2, address.City,
+			address.State, address.PostalCode, address.Country)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type idempotencyStore struct {
+	BaseRepository
+}
+
+func NewIdempotencyRepo(db *sql.DB) repository.IdempotencyStorer {
+	return &idempotencyStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (is *idempotencyStore) GetIdempotencyKey(ctx context.Context, tx repository.Transaction, customerID int64, key string) (repository.IdempotencyKey, error) {
+	var idempotencyKey repository.IdempotencyKey
+	var response string
+
+	queryExecutor := is.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx,
+		`SELECT id, customer_id, idempotency_key, request_hash, response, created_at FROM idempotency_keys
+		WHERE customer_id = $1 AND idempotency_key = $2`, customerID, key,
+	).Scan(&idempotencyKey.ID, &idempotencyKey.CustomerID, &idempotencyKey.Key, &idempotencyKey.RequestHash,
+		&response, &idempotencyKey.CreatedAt)
+	if err == sql.ErrNoRows {
+		return repository.IdempotencyKey{}, nil
+	}
+
+	if err != nil {
+		return repository.IdempotencyKey{}, err
+	}
+
+	idempotencyKey.Response = []byte(response)
+	return idempotencyKey, nil
+}
+
+func (is *idempotencyStore) CreateIdempotencyKey(ctx context.Context, tx repository.Transaction, idempotencyKey repository.IdempotencyKey) (repository.IdempotencyKey, error) {
+	queryExecutor := is.initiateQueryExecutor(tx)
+
+	//the unique (customer_id, idempotency_key) constraint fails the second of two concurrent requests
+	idempotencyKey.CreatedAt = is.TimeNow()
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO idempotency_keys (customer_id, idempotency_key, request_hash, response, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		idempotencyKey.CustomerID, idempotencyKey.Key, idempotencyKey.RequestHash,
+		string(idempotencyKey.Response), idempotencyKey.CreatedAt,
+	).Scan(&idempotencyKey.ID)
+	if err != nil {
+		return repository.IdempotencyKey{}, err
+	}
+
+	return idempotencyKey, nil
+}
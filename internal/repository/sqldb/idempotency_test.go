@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	idempotencyRepo := NewIdempotencyRepo(newTestDatabase(t))
+
+	created, err := idempotencyRepo.CreateIdempotencyKey(ctx, nil, repository.IdempotencyKey{
+		CustomerID: 1, Key: "order-attempt-1", RequestHash: "hash", Response: []byte(`{"id":1}`),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+
+	stored, err := idempotencyRepo.GetIdempotencyKey(ctx, nil, 1, "order-attempt-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, stored.ID)
+	assert.Equal(t, "hash", stored.RequestHash)
+	assert.JSONEq(t, `{"id":1}`, string(stored.Response))
+
+	//keys are scoped to the customer who sent them
+	missing, err := idempotencyRepo.GetIdempotencyKey(ctx, nil, 2, "order-attempt-1")
+	require.NoError(t, err)
+	assert.Zero(t, missing.ID)
+
+	_, err = idempotencyRepo.CreateIdempotencyKey(ctx, nil, repository.IdempotencyKey{
+		CustomerID: 1, Key: "order-attempt-1", RequestHash: "other", Response: []byte(`{"id":2}`),
+	})
+	assert.Error(t, err)
+}
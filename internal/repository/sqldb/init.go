@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	//registering database/sql drivers
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// sqliteBusyTimeout is how long, in milliseconds, a sqlite connection waits for a lock before failing with SQLITE_BUSY
+const sqliteBusyTimeout = 5000
+
+// dialect holds the column definitions and catalog queries which differ between the supported databases
+type dialect struct {
+	primaryKey string
+	timestamp  string
+	//columnCount counts the columns of table $1 named $2
+	columnCount string
+}
+
+var dialects = map[string]dialect{
+	DriverSQLite: {
+		primaryKey:  "INTEGER PRIMARY KEY AUTOINCREMENT",
+		timestamp:   "TIMESTAMP",
+		columnCount: `SELECT COUNT(*) FROM pragma_table_info($1) WHERE name = $2`,
+	},
+	DriverPostgres: {
+		primaryKey: "BIGSERIAL PRIMARY KEY",
+		timestamp:  "TIMESTAMP WITH TIME ZONE",
+		columnCount: `SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2`,
+	},
+}
+
+func InitializeDatabase(driver, dsn string) (db *sql.DB, err error) {
+	if _, ok := dialects[driver]; !ok {
+		return nil, fmt.Errorf("unsupported sql driver: %s", driver)
+	}
+
+	if driver == DriverSQLite {
+		dsn = sqliteDSN(dsn)
+	}
+
+	db, err = sql.Open(driver, dsn)
+	if err != nil {
+		log.Printf("error occured while creating database connection: %v", err.Error())
+		return nil, err
+	}
+
+	//sqlite allows a single writer, one connection queues the handlers and the background workers
+	//writing alongside them instead of failing them with SQLITE_BUSY
+	if driver == DriverSQLite {
+		db.SetMaxOpenConns(1)
+	}
+
+	err = db.Ping()
+	if err != nil {
+		log.Printf("error occured while connecting to database: %v", err.Error())
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// sqliteDSN sets the busy timeout of every connection opened with dsn, unless dsn sets one already
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "busy_timeout") {
+		return dsn
+	}
+
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+
+	return dsn + separator + "_pragma=busy_timeout(" + strconv.Itoa(sqliteBusyTimeout) + ")"
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitializeDatabaseSQLite(t *testing.T) {
+	testCases := []struct {
+		name                string
+		dsn                 string
+		expectedBusyTimeout int
+	}{
+		{
+			name:                "Busy Timeout Set Without Pragma",
+			dsn:                 "file:" + filepath.Join(t.TempDir(), "test.db"),
+			expectedBusyTimeout: sqliteBusyTimeout,
+		},
+		{
+			name:                "Busy Timeout Set Along Other Params",
+			dsn:                 "file:" + filepath.Join(t.TempDir(), "test.db") + "?_pragma=foreign_keys(1)",
+			expectedBusyTimeout: sqliteBusyTimeout,
+		},
+		{
+			name:                "Busy Timeout Of DSN Kept",
+			dsn:                 "file:" + filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(1000)",
+			expectedBusyTimeout: 1000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, err := InitializeDatabase(DriverSQLite, test.dsn)
+			require.NoError(t, err)
+			defer db.Close()
+
+			assert.Equal(t, 1, db.Stats().MaxOpenConnections)
+
+			var busyTimeout int
+			require.NoError(t, db.QueryRow(`PRAGMA busy_timeout`).Scan(&busyTimeout))
+			assert.Equal(t, test.expectedBusyTimeout, busyTimeout)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const inventoryReservationColumns = `id, order_id, product_id, quantity, status, expires_at, created_at, updated_at`
+
+type inventoryReservationStore struct {
+	BaseRepository
+}
+
+func NewInventoryReservationRepo(db *sql.DB) repository.InventoryReservationStorer {
+	return &inventoryReservationStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func scanInventoryReservations(rows *sql.Rows) ([]repository.InventoryReservation, error) {
+	reservations := make([]repository.InventoryReservation, 0)
+	defer rows.Close()
+
+	for rows.Next() {
+		var reservation repository.InventoryReservation
+		err := rows.Scan(&reservation.ID, &reservation.OrderID, &reservation.ProductID, &reservation.Quantity,
+			&reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt)
+		if err != nil {
+			return reservations, err
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+func (irs *inventoryReservationStore) CreateReservations(ctx context.Context, tx repository.Transaction, reservations []repository.InventoryReservation) error {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	for _, reservation := range reservations {
+
+		//setting time fields
+		reservation.CreatedAt = irs.TimeNow()
+		reservation.UpdatedAt = reservation.CreatedAt
+
+		_, err := queryExecutor.ExecContext(ctx,
+			`INSERT INTO inventory_reservations (order_id, product_id, quantity, status, expires_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			reservation.OrderID, reservation.ProductID, reservation.Quantity, reservation.Status,
+			reservation.ExpiresAt.UTC(), reservation.CreatedAt, reservation.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (irs *inventoryReservationStore) ListReservationsByOrderID(ctx context.Context, tx repository.Transaction, orderID int64) ([]repository.InventoryReservation, error) {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT `+inventoryReservationColumns+` FROM inventory_reservations WHERE order_id = $1 ORDER BY id`, orderID)
+	if err != nil {
+		return make([]repository.InventoryReservation, 0), err
+	}
+
+	return scanInventoryReservations(rows)
+}
+
+func (irs *inventoryReservationStore) GetReservedQuantities(ctx context.Context, tx repository.Transaction, productIDs []int64, at time.Time) (map[int64]int64, error) {
+	reserved := make(map[int64]int64)
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+
+	//timestamps are stored in UTC, sqlite compares them as text
+	args := []interface{}{repository.ReservationActive, at.UTC()}
+	placeholders := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		args = append(args, productID)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT product_id, SUM(quantity) FROM inventory_reservations
+		WHERE status = $1 AND expires_at > $2 AND product_id IN (`+strings.Join(placeholders, ", ")+`)
+		GROUP BY product_id`, args...)
+	if err != nil {
+		return reserved, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, quantity int64
+		err = rows.Scan(&productID, &quantity)
+		if err != nil {
+			return reserved, err
+		}
+
+		reserved[productID] = quantity
+	}
+
+	return reserved, rows.Err()
+}
+
+func (irs *inventoryReservationStore) ListExpiredReservations(ctx context.Context, tx repository.Transaction, at time.Time, limit int) ([]repository.InventoryReservation, error) {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT `+inventoryReservationColumns+` FROM inventory_reservations
+		WHERE status = $1 AND expires_at <= $2 ORDER BY id LIMIT $3`,
+		repository.ReservationActive, at.UTC(), limit)
+	if err != nil {
+		return make([]repository.InventoryReservation, 0), err
+	}
+
+	return scanInventoryReservations(rows)
+}
+
+func (irs *inventoryReservationStore) UpdateReservationStatus(ctx context.Context, tx repository.Transaction, reservationIDs []int64, status string) error {
+	queryExecutor := irs.initiateQueryExecutor(tx)
+	for _, reservationID := range reservationIDs {
+		_, err := queryExecutor.ExecContext(ctx,
+			`UPDATE inventory_reservations SET status = $1, updated_at = $2 WHERE id = $3`,
+			status, irs.TimeNow(), reservationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryReservationStore(t *testing.T) {
+	ctx := context.Background()
+	reservationRepo := NewInventoryReservationRepo(newTestDatabase(t))
+
+	//times of any zone compare by instant
+	at := time.Now().In(time.FixedZone("IST", 5*60*60+30*60))
+
+	err := reservationRepo.CreateReservations(ctx, nil, []repository.InventoryReservation{
+		{OrderID: 1, ProductID: 1, Quantity: 2, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+		{OrderID: 1, ProductID: 2, Quantity: 1, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+		{OrderID: 2, ProductID: 1, Quantity: 3, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+		{OrderID: 3, ProductID: 1, Quantity: 5, Status: repository.ReservationActive, ExpiresAt: at.Add(-time.Minute)},
+	})
+	require.NoError(t, err)
+
+	reservations, err := reservationRepo.ListReservationsByOrderID(ctx, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, reservations, 2)
+	assert.Equal(t, int64(1), reservations[0].ProductID)
+	assert.Equal(t, int64(2), reservations[0].Quantity)
+
+	//expired holds no longer count against stock
+	reserved, err := reservationRepo.GetReservedQuantities(ctx, nil, []int64{1, 2, 3}, at)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{1: 5, 2: 1}, reserved)
+
+	expired, err := reservationRepo.ListExpiredReservations(ctx, nil, at, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, int64(3), expired[0].OrderID)
+
+	err = reservationRepo.UpdateReservationStatus(ctx, nil, []int64{int64(expired[0].ID), int64(reservations[0].ID)}, repository.ReservationReleased)
+	require.NoError(t, err)
+
+	reserved, err = reservationRepo.GetReservedQuantities(ctx, nil, []int64{1, 2}, at)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{1: 3, 2: 1}, reserved)
+
+	expired, err = reservationRepo.ListExpiredReservations(ctx, nil, at, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+}
+
+func TestLockProductsSerializesReservations(t *testing.T) {
+	testCases := []struct {
+		name string
+		open func(t *testing.T) *sql.DB
+	}{
+		//sqlite runs with its production pool of one connection, the second order waits for it
+		{name: "SQLite", open: newTestDatabase},
+		//postgres runs both transactions at once, the second order waits for the row lock
+		{name: "Postgres", open: newPostgresTestDatabase},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			testLockProductsSerializesReservations(t, test.open(t))
+		})
+	}
+}
+
+func testLockProductsSerializesReservations(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+	productRepo := NewProductRepo(db)
+	reservationRepo := NewInventoryReservationRepo(db)
+	at := time.Now()
+
+	//a database kept between runs may hold reservations already, the order id is unique to this run
+	reservedBefore, err := reservationRepo.GetReservedQuantities(ctx, nil, []int64{1}, at)
+	require.NoError(t, err)
+	orderID := at.UnixNano()
+	t.Cleanup(func() {
+		_, err := db.ExecContext(ctx, `DELETE FROM inventory_reservations WHERE order_id = $1`, orderID)
+		assert.NoError(t, err)
+	})
+
+	//the first order locks product 1 and holds 15 of its units
+	first, err := productRepo.BeginTx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, productRepo.LockProducts(ctx, first, []int64{1}))
+	err = reservationRepo.CreateReservations(ctx, first, []repository.InventoryReservation{
+		{OrderID: orderID, ProductID: 1, Quantity: 15, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+
+	//the second order checks the stock of product 1 meanwhile
+	reservedBySecond := make(chan map[int64]int64)
+	go func() {
+		second, err := productRepo.BeginTx(ctx)
+		if !assert.NoError(t, err) {
+			close(reservedBySecond)
+			return
+		}
+
+		err = productRepo.LockProducts(ctx, second, []int64{1})
+		assert.NoError(t, err)
+
+		reserved, err := reservationRepo.GetReservedQuantities(ctx, second, []int64{1}, at)
+		assert.NoError(t, err)
+		assert.NoError(t, productRepo.HandleTransaction(ctx, second, nil))
+		reservedBySecond <- reserved
+	}()
+
+	select {
+	case <-reservedBySecond:
+		t.Fatal("second transaction checked the stock while the first held the product lock")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	//once the first order commits, the second sees its hold and cannot over reserve
+	require.NoError(t, productRepo.HandleTransaction(ctx, first, nil))
+	assert.Equal(t, map[int64]int64{1: reservedBefore[1] + 15}, <-reservedBySecond)
+}
@@ -0,0 +1,833 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/logger"
+	"github.com/sagar23sj/go-ecommerce/internal/pkg/money"
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"go.uber.org/zap"
+)
+
+type migration struct {
+	version     int
+	description string
+	up          func(ctx context.Context, tx *sql.Tx, env migrationEnv) error
+}
+
+// migrations lists every schema change of the sql database, new steps are appended with the next version
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create orders, products and order_items tables",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS orders (
+				id {{primary_key}},
+				amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+				discount_percentage DOUBLE PRECISION NOT NULL DEFAULT 0,
+				final_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+				status TEXT NOT NULL,
+				dispatched_at {{timestamp}} NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS products (
+				id {{primary_key}},
+				name TEXT NOT NULL,
+				price DOUBLE PRECISION NOT NULL,
+				category TEXT NOT NULL,
+				quantity BIGINT NOT NULL DEFAULT 0,
+				archived BOOLEAN NOT NULL DEFAULT FALSE,
+				archived_at {{timestamp}} NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS order_items (
+				id {{primary_key}},
+				order_id BIGINT NOT NULL REFERENCES orders (id),
+				product_id BIGINT NOT NULL REFERENCES products (id),
+				quantity BIGINT NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id)`,
+		),
+	},
+	{
+		version:     2,
+		description: "seed product catalog",
+		up:          seedProducts,
+	},
+	{
+		version:     3,
+		description: "create carts and cart_items tables",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS carts (
+				id {{primary_key}},
+				status TEXT NOT NULL,
+				order_id BIGINT NOT NULL DEFAULT 0,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS cart_items (
+				id {{primary_key}},
+				cart_id BIGINT NOT NULL REFERENCES carts (id),
+				product_id BIGINT NOT NULL REFERENCES products (id),
+				quantity BIGINT NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL,
+				UNIQUE (cart_id, product_id)
+			)`,
+		),
+	},
+	{
+		version:     4,
+		description: "create customers and customer_addresses tables, add customer_id to orders and carts",
+		up: steps(
+			execStatements(
+				`CREATE TABLE IF NOT EXISTS customers (
+					id {{primary_key}},
+					name TEXT NOT NULL,
+					email TEXT NOT NULL UNIQUE,
+					created_at {{timestamp}} NOT NULL,
+					updated_at {{timestamp}} NOT NULL
+				)`,
+				`CREATE TABLE IF NOT EXISTS customer_addresses (
+					id {{primary_key}},
+					customer_id BIGINT NOT NULL REFERENCES customers (id),
+					label TEXT NOT NULL,
+					line1 TEXT NOT NULL,
+					line2 TEXT NOT NULL,
+					city TEXT NOT NULL,
+					state TEXT NOT NULL,
+					postal_code TEXT NOT NULL,
+					country TEXT NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_customer_addresses_customer_id ON customer_addresses (customer_id)`,
+			),
+			addColumn("orders", "customer_id", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("carts", "customer_id", "BIGINT NOT NULL DEFAULT 0"),
+			execStatements(`CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id)`),
+		),
+	},
+	{
+		version:     5,
+		description: "index orders by created_at and status for filtered order listings",
+		up: execStatements(
+			`CREATE INDEX IF NOT EXISTS idx_orders_customer_id_created_at ON orders (customer_id, created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status)`,
+		),
+	},
+	{
+		version:     6,
+		description: "index products by category and price for catalog search",
+		up: execStatements(
+			`CREATE INDEX IF NOT EXISTS idx_products_category_price ON products (category, price)`,
+			`CREATE INDEX IF NOT EXISTS idx_products_price ON products (price)`,
+		),
+	},
+	{
+		version:     7,
+		description: "create idempotency_keys table",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS idempotency_keys (
+				id {{primary_key}},
+				customer_id BIGINT NOT NULL,
+				idempotency_key TEXT NOT NULL,
+				request_hash TEXT NOT NULL,
+				response TEXT NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				UNIQUE (customer_id, idempotency_key)
+			)`,
+		),
+	},
+	{
+		version:     8,
+		description: "create order_status_events table",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS order_status_events (
+				id {{primary_key}},
+				order_id BIGINT NOT NULL REFERENCES orders (id),
+				from_status TEXT NOT NULL,
+				to_status TEXT NOT NULL,
+				actor TEXT NOT NULL,
+				actor_role TEXT NOT NULL,
+				reason TEXT NOT NULL,
+				created_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_order_status_events_order_id ON order_status_events (order_id)`,
+		),
+	},
+	{
+		version:     9,
+		description: "create outbox_events table",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS outbox_events (
+				id {{primary_key}},
+				event_type TEXT NOT NULL,
+				aggregate_id BIGINT NOT NULL,
+				payload TEXT NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL,
+				last_error TEXT NOT NULL,
+				next_attempt_at {{timestamp}} NOT NULL,
+				delivered_at {{timestamp}} NULL,
+				created_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_outbox_events_status_next_attempt_at ON outbox_events (status, next_attempt_at)`,
+		),
+	},
+	{
+		version:     10,
+		description: "create webhook_subscriptions and webhook_deliveries tables",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+				id {{primary_key}},
+				url TEXT NOT NULL,
+				secret TEXT NOT NULL,
+				event_types TEXT NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id {{primary_key}},
+				subscription_id BIGINT NOT NULL,
+				event_id BIGINT NOT NULL,
+				event_type TEXT NOT NULL,
+				payload TEXT NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL,
+				last_error TEXT NOT NULL,
+				response_status INTEGER NOT NULL,
+				next_attempt_at {{timestamp}} NOT NULL,
+				delivered_at {{timestamp}} NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL,
+				UNIQUE (subscription_id, event_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status_next_attempt_at ON webhook_deliveries (status, next_attempt_at)`,
+		),
+	},
+	{
+		version:     11,
+		description: "create inventory_reservations table",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS inventory_reservations (
+				id {{primary_key}},
+				order_id BIGINT NOT NULL,
+				product_id BIGINT NOT NULL,
+				quantity BIGINT NOT NULL,
+				status TEXT NOT NULL,
+				expires_at {{timestamp}} NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_inventory_reservations_order_id ON inventory_reservations (order_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_inventory_reservations_status_product_id ON inventory_reservations (status, product_id)`,
+		),
+	},
+	{
+		version:     12,
+		description: "create stock_movements table and record opening balances",
+		up: steps(
+			execStatements(
+				`CREATE TABLE IF NOT EXISTS stock_movements (
+					id {{primary_key}},
+					product_id BIGINT NOT NULL,
+					delta BIGINT NOT NULL,
+					quantity_after BIGINT NOT NULL,
+					reason TEXT NOT NULL,
+					order_id BIGINT NOT NULL DEFAULT 0,
+					created_at {{timestamp}} NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id ON stock_movements (product_id)`,
+			),
+			recordOpeningBalances,
+		),
+	},
+	{
+		version:     13,
+		description: "add reorder_threshold and reorder_quantity to products",
+		up: steps(
+			addColumn("products", "reorder_threshold", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("products", "reorder_quantity", "BIGINT NOT NULL DEFAULT 0"),
+		),
+	},
+	{
+		version:     14,
+		description: "add discounts breakdown to orders",
+		up:          addColumn("orders", "discounts", "TEXT NOT NULL DEFAULT '[]'"),
+	},
+	{
+		version:     15,
+		description: "create coupons and coupon_redemptions tables",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS coupons (
+				id {{primary_key}},
+				code TEXT NOT NULL UNIQUE,
+				discount_type TEXT NOT NULL,
+				value DOUBLE PRECISION NOT NULL,
+				categories TEXT NOT NULL,
+				valid_from {{timestamp}} NULL,
+				valid_until {{timestamp}} NULL,
+				usage_limit BIGINT NOT NULL,
+				per_customer_limit BIGINT NOT NULL,
+				times_used BIGINT NOT NULL,
+				created_at {{timestamp}} NOT NULL,
+				updated_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS coupon_redemptions (
+				id {{primary_key}},
+				coupon_id BIGINT NOT NULL,
+				customer_id BIGINT NOT NULL,
+				order_id BIGINT NOT NULL,
+				amount DOUBLE PRECISION NOT NULL,
+				created_at {{timestamp}} NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_coupon_id_customer_id ON coupon_redemptions (coupon_id, customer_id)`,
+		),
+	},
+	{
+		version:     16,
+		description: "store money amounts as integer minor units with their currency",
+		//the float amounts carry no currency and are converted in the configured one, a database priced before
+		//must be migrated with ORDER_CURRENCY set to the currency its amounts were priced in
+		up: steps(
+			//sqlite cannot drop an indexed column
+			execStatements(
+				`DROP INDEX IF EXISTS idx_products_category_price`,
+				`DROP INDEX IF EXISTS idx_products_price`,
+			),
+			convertToMinorUnits("products", "price"),
+			addCurrencyColumn("products", "currency"),
+			execStatements(
+				`CREATE INDEX IF NOT EXISTS idx_products_category_price ON products (category, price)`,
+				`CREATE INDEX IF NOT EXISTS idx_products_price ON products (price)`,
+			),
+			convertToMinorUnits("orders", "amount"),
+			convertToMinorUnits("orders", "final_amount"),
+			convertOrderDiscounts,
+			addCurrencyColumn("orders", "currency"),
+			splitCouponValues,
+			convertToMinorUnits("coupon_redemptions", "amount"),
+			addCurrencyColumn("coupon_redemptions", "currency"),
+			convertIdempotentResponses,
+		),
+	},
+	{
+		version:     17,
+		description: "add prices in other currencies to products and the exchange rate to orders",
+		up: steps(
+			addColumn("products", "prices", "TEXT NOT NULL DEFAULT '{}'"),
+			//orders were placed in the catalog currency until they carried a rate
+			addCurrencyColumn("orders", "base_currency"),
+			addColumn("orders", "exchange_rate", "DOUBLE PRECISION NOT NULL DEFAULT 1"),
+			execStatements(`UPDATE orders SET base_currency = currency`),
+		),
+	},
+	{
+		version:     18,
+		description: "snapshot the sold product on order items",
+		up: steps(
+			addColumn("order_items", "product_name", "TEXT NOT NULL DEFAULT ''"),
+			addColumn("order_items", "category", "TEXT NOT NULL DEFAULT ''"),
+			addColumn("order_items", "unit_price", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("order_items", "discount", "BIGINT NOT NULL DEFAULT 0"),
+		),
+	},
+	{
+		version:     19,
+		description: "add the tax charged to orders and order items",
+		up: steps(
+			addColumn("orders", "tax_amount", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("orders", "tax_region", "TEXT NOT NULL DEFAULT ''"),
+			addColumn("orders", "tax_inclusive", "BOOLEAN NOT NULL DEFAULT FALSE"),
+			addColumn("order_items", "tax_rate", "DOUBLE PRECISION NOT NULL DEFAULT 0"),
+			addColumn("order_items", "tax", "BIGINT NOT NULL DEFAULT 0"),
+		),
+	},
+	{
+		version:     20,
+		description: "add the weight and dimensions of products and the shipping of orders",
+		up: steps(
+			addColumn("products", "weight_grams", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("products", "length_mm", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("products", "width_mm", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("products", "height_mm", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("orders", "shipping_method", "TEXT NOT NULL DEFAULT ''"),
+			addColumn("orders", "shipping_amount", "BIGINT NOT NULL DEFAULT 0"),
+			addColumn("orders", "shipping_address", "TEXT NOT NULL DEFAULT '{}'"),
+		),
+	},
+}
+
+type migrator struct {
+	db  *sql.DB
+	env migrationEnv
+}
+
+// migrationEnv is what every migration step runs with, the SQL dialect of the database
+// and the catalog currency stamped on the seeded products and the amounts stored before they carried one
+type migrationEnv struct {
+	dialect
+	currency string
+}
+
+// NewMigrator migrates the database of the driver for a catalog priced in currency
+func NewMigrator(db *sql.DB, driver, currency string) repository.Migrator {
+	return &migrator{
+		db:  db,
+		env: migrationEnv{dialect: dialects[driver], currency: currency},
+	}
+}
+
+func (m *migrator) Migrate(ctx context.Context, dryRun bool) ([]repository.SchemaMigration, error) {
+	applied := make([]repository.SchemaMigration, 0)
+
+	appliedVersions, err := m.appliedVersions(ctx, dryRun)
+	if err != nil {
+		return applied, err
+	}
+
+	steps := make([]migration, len(migrations))
+	copy(steps, migrations)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].version < steps[j].version })
+
+	for _, step := range steps {
+		if appliedVersions[step.version] {
+			continue
+		}
+
+		record := repository.SchemaMigration{
+			Version:     step.version,
+			Description: step.description,
+			AppliedAt:   time.Now().UTC(),
+		}
+
+		if dryRun {
+			applied = append(applied, record)
+			continue
+		}
+
+		err = m.apply(ctx, step, record)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while applying migration",
+				zap.Error(err),
+				zap.Int("version", step.version),
+			)
+			return applied, err
+		}
+
+		logger.Infow(ctx, "applied migration",
+			zap.Int("version", step.version),
+			zap.String("description", step.description),
+		)
+		applied = append(applied, record)
+	}
+
+	return applied, nil
+}
+
+func (m *migrator) CheckCurrency(ctx context.Context) error {
+	var currency string
+	err := m.db.QueryRowContext(ctx, `SELECT currency FROM products WHERE currency <> $1
+		UNION SELECT currency FROM coupons WHERE discount_type = 'fixed' AND currency <> $1
+		UNION SELECT base_currency FROM orders WHERE base_currency <> $1
+		LIMIT 1`, m.env.currency).Scan(&currency)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("database holds amounts in %s while the catalog currency is %s", currency, m.env.currency)
+}
+
+func (m *migrator) appliedVersions(ctx context.Context, dryRun bool) (versions map[int]bool, err error) {
+	//the metadata table is created outside of the numbered migrations,
+	//on dry runs the transaction is rolled back so nothing is written
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil || dryRun {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.ExecContext(ctx, m.env.rewrite(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at {{timestamp}} NOT NULL
+	)`))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions = make(map[int]bool)
+	for rows.Next() {
+		var version int
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+// apply runs the step and records its version in a single transaction
+func (m *migrator) apply(ctx context.Context, step migration, record repository.SchemaMigration) (err error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = step.up(ctx, tx, m.env)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, description, applied_at) VALUES ($1, $2, $3)`,
+		record.Version, record.Description, record.AppliedAt)
+	return err
+}
+
+// execStatements builds a migration step running the given statements in order
+func execStatements(statements ...string) func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	return func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+		for _, statement := range statements {
+			_, err := tx.ExecContext(ctx, env.rewrite(statement))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// steps combines several migration steps into one
+func steps(ups ...func(ctx context.Context, tx *sql.Tx, env migrationEnv) error) func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	return func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+		for _, up := range ups {
+			err := up(ctx, tx, env)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// addColumn builds a migration step adding the column unless the table already has it,
+// sqlite has no ADD COLUMN IF NOT EXISTS
+func addColumn(table, column, definition string) func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	return func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+		var columnCount int64
+		err := tx.QueryRowContext(ctx, env.columnCount, table, column).Scan(&columnCount)
+		if err != nil {
+			return err
+		}
+
+		if columnCount > 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, env.rewrite(`ALTER TABLE `+table+` ADD COLUMN `+column+` `+definition))
+		return err
+	}
+}
+
+// addCurrencyColumn builds a migration step adding the currency column to the table,
+// the rows stored before it are in the catalog currency
+func addCurrencyColumn(table, column string) func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	return func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+		return addColumn(table, column, "TEXT NOT NULL DEFAULT '"+env.currency+"'")(ctx, tx, env)
+	}
+}
+
+func seedProducts(ctx context.Context, tx *sql.Tx, env migrationEnv) (err error) {
+	var productCount int64
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM products`).Scan(&productCount)
+	if err != nil {
+		return err
+	}
+
+	//database already has some products, so not adding products again
+	if productCount > 0 {
+		return nil
+	}
+
+	//the insert names the columns products have at this version, later migrations add the others
+	//and convert the prices, stored in major units until then
+	for _, product := range repository.SeedProducts(time.Now().UTC(), env.currency) {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO products (name, price, category, quantity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			product.Name, money.New(product.Price, product.Currency).MajorFloat(), product.Category, product.Quantity,
+			product.CreatedAt, product.UpdatedAt)
+		if err != nil {
+			logger.Errorw(ctx, "error occured while seeding product in database",
+				zap.Error(err),
+				zap.String("product_name", product.Name),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordOpeningBalances starts the stock ledger of the existing products with their quantity on hand
+func recordOpeningBalances(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	quantities, err := (&productStore{}).ListProductQuantities(ctx, &BaseTransaction{tx: tx})
+	if err != nil {
+		return err
+	}
+
+	movementRepo := &stockMovementStore{}
+	for productID, quantity := range quantities {
+		if quantity == 0 {
+			continue
+		}
+
+		_, err = movementRepo.CreateStockMovement(ctx, &BaseTransaction{tx: tx}, repository.StockMovement{
+			ProductID:     productID,
+			Delta:         quantity,
+			QuantityAfter: quantity,
+			Reason:        repository.StockReasonInitial,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertToMinorUnits builds a migration step replacing the amounts in major units of the float column
+// by integer minor units of the configured currency, which must be the one the float amounts were priced in.
+func convertToMinorUnits(table, column string) func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	minorColumn := column + "_minor"
+
+	return steps(
+		addColumn(table, minorColumn, "BIGINT NOT NULL DEFAULT 0"),
+		func(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+			amounts, err := readAmounts(ctx, tx, `SELECT id, `+column+` FROM `+table)
+			if err != nil {
+				return err
+			}
+
+			for id, amount := range amounts {
+				minorUnits, err := money.FromMajor(amount, env.currency)
+				if err != nil {
+					return fmt.Errorf("error converting %s.%s of row %d: %w", table, column, id, err)
+				}
+
+				_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET `+minorColumn+` = $1 WHERE id = $2`, minorUnits.MinorUnits, id)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		execStatements(
+			`ALTER TABLE `+table+` DROP COLUMN `+column,
+			`ALTER TABLE `+table+` RENAME COLUMN `+minorColumn+` TO `+column,
+		),
+	)
+}
+
+// readAmounts returns the float amount by row id selected by the query, the rows are read
+// in full before they are updated as the postgres driver cannot run queries while rows are open
+func readAmounts(ctx context.Context, tx *sql.Tx, query string) (map[int64]float64, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amounts := make(map[int64]float64)
+	for rows.Next() {
+		var id int64
+		var amount float64
+		err = rows.Scan(&id, &amount)
+		if err != nil {
+			return nil, err
+		}
+
+		amounts[id] = amount
+	}
+
+	return amounts, rows.Err()
+}
+
+// convertOrderDiscounts converts the amounts of the discount breakdowns stored with the orders to minor units
+func convertOrderDiscounts(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id, discounts FROM orders`)
+	if err != nil {
+		return err
+	}
+
+	breakdowns := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var discounts string
+		err = rows.Scan(&id, &discounts)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		breakdowns[id] = discounts
+	}
+
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	for id, breakdown := range breakdowns {
+		var legacyDiscounts []struct {
+			Promotion string
+			Type      string
+			Amount    float64
+		}
+
+		err = json.Unmarshal([]byte(breakdown), &legacyDiscounts)
+		if err != nil {
+			return fmt.Errorf("error decoding discounts of order %d: %w", id, err)
+		}
+
+		discounts := make([]repository.OrderDiscount, 0, len(legacyDiscounts))
+		for _, legacy := range legacyDiscounts {
+			amount, err := money.FromMajor(legacy.Amount, env.currency)
+			if err != nil {
+				return fmt.Errorf("error converting discounts of order %d: %w", id, err)
+			}
+
+			discounts = append(discounts, repository.OrderDiscount{Promotion: legacy.Promotion, Type: legacy.Type, Amount: amount.MinorUnits})
+		}
+
+		encoded, err := json.Marshal(discounts)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE orders SET discounts = $1 WHERE id = $2`, string(encoded), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitCouponValues replaces the value of the coupons, a percent or an amount in major units as per their type,
+// by a percent column and an amount column in minor units with its currency
+func splitCouponValues(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	err := steps(
+		addColumn("coupons", "percent", "DOUBLE PRECISION NOT NULL DEFAULT 0"),
+		addColumn("coupons", "amount", "BIGINT NOT NULL DEFAULT 0"),
+		addCurrencyColumn("coupons", "currency"),
+		execStatements(`UPDATE coupons SET percent = value WHERE discount_type = 'percentage'`),
+	)(ctx, tx, env)
+	if err != nil {
+		return err
+	}
+
+	values, err := readAmounts(ctx, tx, `SELECT id, value FROM coupons WHERE discount_type = 'fixed'`)
+	if err != nil {
+		return err
+	}
+
+	for id, value := range values {
+		amount, err := money.FromMajor(value, env.currency)
+		if err != nil {
+			return fmt.Errorf("error converting value of coupon %d: %w", id, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE coupons SET amount = $1 WHERE id = $2`, amount.MinorUnits, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return execStatements(`ALTER TABLE coupons DROP COLUMN value`)(ctx, tx, env)
+}
+
+// convertIdempotentResponses converts the amounts of the order responses replayed for idempotency keys to money
+func convertIdempotentResponses(ctx context.Context, tx *sql.Tx, env migrationEnv) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id, response FROM idempotency_keys`)
+	if err != nil {
+		return err
+	}
+
+	responses := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var response string
+		err = rows.Scan(&id, &response)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		responses[id] = response
+	}
+
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	for id, response := range responses {
+		converted, err := repository.ConvertLegacyResponse([]byte(response), env.currency)
+		if err != nil {
+			return fmt.Errorf("error converting response of idempotency key %d: %w", id, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE idempotency_keys SET response = $1 WHERE id = $2`, string(converted), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d dialect) rewrite(statement string) string {
+	return strings.NewReplacer(
+		"{{primary_key}}", d.primaryKey,
+		"{{timestamp}}", d.timestamp,
+	).Replace(statement)
+}
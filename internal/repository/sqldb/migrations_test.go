@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateFromEmpty(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+
+	products, err := NewProductRepo(db).SearchProducts(ctx, nil, repository.ProductFilter{})
+	require.NoError(t, err)
+	seeded := repository.SeedProducts(products[0].CreatedAt, "INR")
+	require.Len(t, products, len(seeded))
+	for i, product := range products {
+		assert.Equal(t, seeded[i].Name, product.Name)
+		assert.Equal(t, seeded[i].Price, product.Price)
+		assert.Equal(t, seeded[i].Currency, product.Currency)
+		assert.Equal(t, seeded[i].Quantity, product.Quantity)
+	}
+
+	//every migration was recorded, running them again applies none
+	applied, err := NewMigrator(db, DriverSQLite, "INR").Migrate(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrateCatalogCurrency(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeDatabase(DriverSQLite, "file:"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = NewMigrator(db, DriverSQLite, "USD").Migrate(ctx, false)
+	require.NoError(t, err)
+
+	//products are seeded in the catalog currency
+	products, err := NewProductRepo(db).SearchProducts(ctx, nil, repository.ProductFilter{})
+	require.NoError(t, err)
+	for _, product := range products {
+		assert.Equal(t, "USD", product.Currency)
+	}
+	assert.NoError(t, NewMigrator(db, DriverSQLite, "USD").CheckCurrency(ctx))
+
+	//serving the database with a catalog in another currency is rejected
+	assert.EqualError(t, NewMigrator(db, DriverSQLite, "INR").CheckCurrency(ctx),
+		"database holds amounts in USD while the catalog currency is INR")
+}
+
+func TestMigrateLegacyAmounts(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeDatabase(DriverSQLite, "file:"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	//migrate to the last version storing amounts as floats, then store some
+	allMigrations := migrations
+	migrations = migrations[:15]
+	_, err = NewMigrator(db, DriverSQLite, "INR").Migrate(ctx, false)
+	migrations = allMigrations
+	require.NoError(t, err)
+
+	statements := []string{
+		`UPDATE products SET price = 1899.99 WHERE id = 1`,
+		`INSERT INTO orders (customer_id, amount, discount_percentage, discounts, final_amount, status, created_at, updated_at)
+		VALUES (1, 100.5, 10.05, '[{"Promotion":"welcome","Type":"coupon","Amount":10.1}]', 90.4, 'Placed', '2023-05-18', '2023-05-18')`,
+		`INSERT INTO coupons (code, discount_type, value, categories, usage_limit, per_customer_limit, times_used, created_at, updated_at)
+		VALUES ('WELCOME', 'fixed', 10.1, '', 0, 0, 1, '2023-05-18', '2023-05-18'),
+		('SAVE5', 'percentage', 5.5, '', 0, 0, 0, '2023-05-18', '2023-05-18')`,
+		`INSERT INTO coupon_redemptions (coupon_id, customer_id, order_id, amount, created_at) VALUES (1, 1, 1, 10.1, '2023-05-18')`,
+		`INSERT INTO idempotency_keys (customer_id, idempotency_key, request_hash, response, created_at)
+		VALUES (1, 'order-1', 'hash', '{"id":1,"amount":100.5,"discounts":[{"promotion":"welcome","type":"coupon","amount":10.1}],"final_amount":90.4}', '2023-05-18')`,
+	}
+	for _, statement := range statements {
+		_, err = db.Exec(statement)
+		require.NoError(t, err)
+	}
+
+	applied, err := NewMigrator(db, DriverSQLite, "INR").Migrate(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, applied, len(migrations)-15)
+
+	product, err := NewProductRepo(db).GetProductByID(ctx, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(189999), product.Price)
+	assert.Equal(t, "INR", product.Currency)
+
+	//seeded products were converted along with the others
+	product, err = NewProductRepo(db).GetProductByID(ctx, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300000), product.Price)
+
+	order, err := NewOrderRepo(db).GetOrderByID(ctx, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "INR", order.Currency)
+	assert.Equal(t, int64(10050), order.Amount)
+	assert.Equal(t, int64(9040), order.FinalAmount)
+	assert.Equal(t, 10.05, order.DiscountPercentage)
+	assert.Equal(t, []repository.OrderDiscount{{Promotion: "welcome", Type: "coupon", Amount: 1010}}, order.Discounts)
+	assert.Equal(t, "INR", order.BaseCurrency)
+	assert.Equal(t, 1.0, order.ExchangeRate)
+
+	coupons, err := NewCouponRepo(db).ListCoupons(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, coupons, 2)
+	assert.Equal(t, int64(1010), coupons[0].Amount)
+	assert.Equal(t, "INR", coupons[0].Currency)
+	assert.Equal(t, 5.5, coupons[1].Percent)
+	assert.Zero(t, coupons[1].Amount)
+
+	var redeemedAmount int64
+	require.NoError(t, db.QueryRow(`SELECT amount FROM coupon_redemptions WHERE id = 1`).Scan(&redeemedAmount))
+	assert.Equal(t, int64(1010), redeemedAmount)
+
+	//replayed order responses carry money
+	idempotencyKey, err := NewIdempotencyRepo(db).GetIdempotencyKey(ctx, nil, 1, "order-1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"amount":{"minor_units":10050,"currency":"INR"},
+		"discounts":[{"promotion":"welcome","type":"coupon","amount":{"minor_units":1010,"currency":"INR"}}],
+		"final_amount":{"minor_units":9040,"currency":"INR"}}`, string(idempotencyKey.Response))
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeDatabase(DriverSQLite, "file:"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	pending, err := NewMigrator(db, DriverSQLite, "INR").Migrate(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, pending, len(migrations))
+	assert.Equal(t, 1, pending[0].Version)
+
+	//nothing was written, so the same migrations are still pending
+	pending, err = NewMigrator(db, DriverSQLite, "INR").Migrate(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, pending, len(migrations))
+
+	var tables int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'products'`).Scan(&tables))
+	assert.Equal(t, 0, tables)
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const orderColumns = `id, customer_id, currency, base_currency, exchange_rate, amount, discount_percentage, discounts, tax_amount,
+	tax_region, tax_inclusive, shipping_method, shipping_amount, shipping_address, final_amount, status, dispatched_at, created_at, updated_at`
+
+type orderStore struct {
+	BaseRepository
+}
+
+func NewOrderRepo(db *sql.DB) repository.OrderStorer {
+	return &orderStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func scanOrder(row rowScanner) (repository.Order, error) {
+	var order repository.Order
+	var dispatchedAt sql.NullTime
+	var discounts, shippingAddress string
+
+	err := row.Scan(&order.ID, &order.CustomerID, &order.Currency, &order.BaseCurrency, &order.ExchangeRate, &order.Amount,
+		&order.DiscountPercentage, &discounts, &order.TaxAmount, &order.TaxRegion, &order.TaxInclusive,
+		&order.ShippingMethod, &order.ShippingAmount, &shippingAddress, &order.FinalAmount, &order.Status, &dispatchedAt, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return repository.Order{}, err
+	}
+
+	err = json.Unmarshal([]byte(discounts), &order.Discounts)
+	if err != nil {
+		return repository.Order{}, fmt.Errorf("error decoding discounts of order %d: %w", order.ID, err)
+	}
+
+	err = json.Unmarshal([]byte(shippingAddress), &order.ShippingAddress)
+	if err != nil {
+		return repository.Order{}, fmt.Errorf("error decoding shipping address of order %d: %w", order.ID, err)
+	}
+
+	order.DispatchedAt = dispatchedAt.Time
+	return order, nil
+}
+
+func (os *orderStore) GetOrderByID(ctx context.Context, tx repository.Transaction, orderID int64) (repository.Order, error) {
+	queryExecutor := os.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID)
+
+	order, err := scanOrder(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Order{}, err
+	}
+
+	return order, nil
+}
+
+func (os *orderStore) CreateOrder(ctx context.Context, tx repository.Transaction, order repository.Order) (repository.Order, error) {
+
+	queryExecutor := os.initiateQueryExecutor(tx)
+
+	//the discount breakdown is stored as a JSON array, it is only read back along with the order
+	if order.Discounts == nil {
+		order.Discounts = make([]repository.OrderDiscount, 0)
+	}
+
+	discounts, err := json.Marshal(order.Discounts)
+	if err != nil {
+		return repository.Order{}, err
+	}
+
+	//the shipping address is a snapshot stored as a JSON object, later changes of the customer addresses leave it untouched
+	shippingAddress, err := json.Marshal(order.ShippingAddress)
+	if err != nil {
+		return repository.Order{}, err
+	}
+
+	order.CreatedAt = os.TimeNow()
+	order.UpdatedAt = os.TimeNow()
+	err = queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO orders (customer_id, currency, base_currency, exchange_rate, amount, discount_percentage, discounts, tax_amount,
+		tax_region, tax_inclusive, shipping_method, shipping_amount, shipping_address, final_amount, status, dispatched_at, created_at,
+		updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id`,
+		order.CustomerID, order.Currency, order.BaseCurrency, order.ExchangeRate, order.Amount, order.DiscountPercentage, string(discounts),
+		order.TaxAmount, order.TaxRegion, order.TaxInclusive, order.ShippingMethod, order.ShippingAmount, string(shippingAddress),
+		order.FinalAmount, order.Status, nullTime(order.DispatchedAt), order.CreatedAt, order.UpdatedAt,
+	).Scan(&order.ID)
+	if err != nil {
+		return repository.Order{}, err
+	}
+
+	return order, nil
+}
+
+func (os *orderStore) UpdateOrderStatus(ctx context.Context, tx repository.Transaction, orderID int64, status string) error {
+	queryExecutor := os.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx, `UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3`,
+		status, os.TimeNow(), orderID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (os *orderStore) UpdateOrderDispatchDate(ctx context.Context, tx repository.Transaction, orderID int64, dispatchedAt time.Time) error {
+	queryExecutor := os.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx, `UPDATE orders SET dispatched_at = $1, updated_at = $2 WHERE id = $3`,
+		nullTime(dispatchedAt), os.TimeNow(), orderID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (os *orderStore) ListOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) ([]repository.Order, error) {
+	orderList := make([]repository.Order, 0)
+
+	where, args := orderFilterClause(filter)
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	//the sort column comes from a fixed list, id breaks ties so pages never overlap
+	query := fmt.Sprintf(`SELECT `+orderColumns+` FROM orders %s ORDER BY %s %s, id %s`,
+		where, orderSortColumns[filter.SortBy], direction, direction)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	queryExecutor := os.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return orderList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return orderList, err
+		}
+
+		orderList = append(orderList, order)
+	}
+
+	return orderList, rows.Err()
+}
+
+func (os *orderStore) CountOrders(ctx context.Context, tx repository.Transaction, filter repository.OrderFilter) (int64, error) {
+	where, args := orderFilterClause(filter)
+
+	var count int64
+	queryExecutor := os.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders `+where, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+var orderSortColumns = map[string]string{
+	"":                                "id",
+	repository.OrderSortByID:          "id",
+	repository.OrderSortByCreatedAt:   "created_at",
+	repository.OrderSortByFinalAmount: "final_amount",
+}
+
+// orderFilterClause builds the WHERE clause of the filter along with its positional arguments
+func orderFilterClause(filter repository.OrderFilter) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.CustomerID != 0 {
+		addCondition("customer_id = $%d", filter.CustomerID)
+	}
+
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	//timestamps are stored in UTC, sqlite compares them as text
+	if !filter.CreatedFrom.IsZero() {
+		addCondition("created_at >= $%d", filter.CreatedFrom.UTC())
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		addCondition("created_at < $%d", filter.CreatedTo.UTC())
+	}
+
+	if filter.Currency != "" {
+		addCondition("currency = $%d", filter.Currency)
+	}
+
+	if filter.MinFinalAmount != nil {
+		addCondition("final_amount >= $%d", *filter.MinFinalAmount)
+	}
+
+	if filter.MaxFinalAmount != nil {
+		addCondition("final_amount <= $%d", *filter.MaxFinalAmount)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type orderItemStore struct {
+	BaseRepository
+}
+
+func NewOrderItemRepo(db *sql.DB) repository.OrderItemStorer {
+	return &orderItemStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (ods *orderItemStore) GetOrderItemsByOrderID(ctx context.Context, tx repository.Transaction, orderID int64) ([]repository.OrderItem, error) {
+	orderItemList := make([]repository.OrderItem, 0)
+
+	queryExecutor := ods.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT id, order_id, product_id, product_name, category, unit_price, quantity, discount, tax_rate, tax,
+		created_at, updated_at FROM order_items WHERE order_id = $1 ORDER BY id`,
+		orderID)
+	if err != nil {
+		return orderItemList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderItem repository.OrderItem
+		err = rows.Scan(&orderItem.ID, &orderItem.OrderID, &orderItem.ProductID, &orderItem.ProductName, &orderItem.Category,
+			&orderItem.UnitPrice, &orderItem.Quantity, &orderItem.Discount, &orderItem.TaxRate, &orderItem.Tax, &orderItem.CreatedAt, &orderItem.UpdatedAt)
+		if err != nil {
+			return orderItemList, err
+		}
+
+		orderItemList = append(orderItemList, orderItem)
+	}
+
+	return orderItemList, rows.Err()
+}
+
+func (ods *orderItemStore) StoreOrderItems(ctx context.Context, tx repository.Transaction, orderItems []repository.OrderItem) error {
+	queryExecutor := ods.initiateQueryExecutor(tx)
+	for _, orderItem := range orderItems {
+
+		//setting time fields
+		orderItem.CreatedAt = ods.TimeNow()
+		orderItem.UpdatedAt = ods.TimeNow()
+
+		_, err := queryExecutor.ExecContext(ctx,
+			`INSERT INTO order_items (order_id, product_id, product_name, category, unit_price, quantity, discount, tax_rate, tax,
+			created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			orderItem.OrderID, orderItem.ProductID, orderItem.ProductName, orderItem.Category, orderItem.UnitPrice,
+			orderItem.Quantity, orderItem.Discount, orderItem.TaxRate, orderItem.Tax, orderItem.CreatedAt, orderItem.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
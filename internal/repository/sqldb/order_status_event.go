@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type orderStatusEventStore struct {
+	BaseRepository
+}
+
+func NewOrderStatusEventRepo(db *sql.DB) repository.OrderStatusEventStorer {
+	return &orderStatusEventStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (es *orderStatusEventStore) CreateOrderStatusEvent(ctx context.Context, tx repository.Transaction, event repository.OrderStatusEvent) (repository.OrderStatusEvent, error) {
+	queryExecutor := es.initiateQueryExecutor(tx)
+
+	event.CreatedAt = es.TimeNow()
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO order_status_events (order_id, from_status, to_status, actor, actor_role, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		event.OrderID, event.FromStatus, event.ToStatus, event.Actor, event.ActorRole, event.Reason, event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return repository.OrderStatusEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (es *orderStatusEventStore) ListOrderStatusEvents(ctx context.Context, tx repository.Transaction, orderID int64) ([]repository.OrderStatusEvent, error) {
+	events := make([]repository.OrderStatusEvent, 0)
+
+	queryExecutor := es.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT id, order_id, from_status, to_status, actor, actor_role, reason, created_at FROM order_status_events
+		WHERE order_id = $1 ORDER BY id`, orderID)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event repository.OrderStatusEvent
+		err = rows.Scan(&event.ID, &event.OrderID, &event.FromStatus, &event.ToStatus,
+			&event.Actor, &event.ActorRole, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			return events, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
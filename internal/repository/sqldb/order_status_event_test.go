@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatusEventStore(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	orderRepo := NewOrderRepo(db)
+	eventRepo := NewOrderStatusEventRepo(db)
+
+	order, err := orderRepo.CreateOrder(ctx, nil, repository.Order{CustomerID: 1, Amount: 10000, FinalAmount: 10000, Status: "Placed"})
+	require.NoError(t, err)
+
+	for _, event := range []repository.OrderStatusEvent{
+		{OrderID: int64(order.ID), FromStatus: "Placed", ToStatus: "Dispatched", Actor: "ops", ActorRole: "ops"},
+		{OrderID: int64(order.ID), FromStatus: "Dispatched", ToStatus: "Cancelled", Actor: "1", ActorRole: "customer", Reason: "delivery delayed"},
+	} {
+		created, err := eventRepo.CreateOrderStatusEvent(ctx, nil, event)
+		require.NoError(t, err)
+		require.NotZero(t, created.ID)
+		require.False(t, created.CreatedAt.IsZero())
+	}
+
+	events, err := eventRepo.ListOrderStatusEvents(ctx, nil, int64(order.ID))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Dispatched", events[0].ToStatus)
+	assert.Equal(t, "Cancelled", events[1].ToStatus)
+	assert.Equal(t, "delivery delayed", events[1].Reason)
+
+	events, err = eventRepo.ListOrderStatusEvents(ctx, nil, int64(order.ID)+1)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
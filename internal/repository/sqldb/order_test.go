@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStore(t *testing.T) {
+	ctx := context.Background()
+	orderRepo := NewOrderRepo(newTestDatabase(t))
+
+	order, err := orderRepo.CreateOrder(ctx, nil, repository.Order{
+		CustomerID: 1, Currency: "USD", BaseCurrency: "INR", ExchangeRate: 0.012, Status: "Placed",
+		Amount: 10000, DiscountPercentage: 10, TaxAmount: 1373, TaxRegion: "MH", TaxInclusive: true, FinalAmount: 13900,
+		ShippingMethod: "standard", ShippingAmount: 4900, ShippingAddress: repository.Address{Line1: "12 MG Road", City: "Pune", PostalCode: "411001", Country: "IN"},
+		Discounts: []repository.OrderDiscount{{Promotion: "premium_products", Type: "percentage", Amount: 1000}},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, order.ID)
+
+	stored, err := orderRepo.GetOrderByID(ctx, nil, int64(order.ID))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stored.CustomerID)
+	assert.Equal(t, "USD", stored.Currency)
+	assert.Equal(t, "INR", stored.BaseCurrency)
+	assert.Equal(t, 0.012, stored.ExchangeRate)
+	assert.Equal(t, int64(1373), stored.TaxAmount)
+	assert.Equal(t, "MH", stored.TaxRegion)
+	assert.True(t, stored.TaxInclusive)
+	assert.Equal(t, "standard", stored.ShippingMethod)
+	assert.Equal(t, int64(4900), stored.ShippingAmount)
+	assert.Equal(t, "Pune", stored.ShippingAddress.City)
+	assert.Equal(t, int64(13900), stored.FinalAmount)
+	assert.Equal(t, []repository.OrderDiscount{{Promotion: "premium_products", Type: "percentage", Amount: 1000}}, stored.Discounts)
+	assert.Equal(t, "Placed", stored.Status)
+	assert.True(t, stored.DispatchedAt.IsZero())
+
+	dispatchedAt := time.Date(2023, 05, 18, 10, 30, 0, 0, time.UTC)
+	require.NoError(t, orderRepo.UpdateOrderStatus(ctx, nil, int64(order.ID), "Dispatched"))
+	require.NoError(t, orderRepo.UpdateOrderDispatchDate(ctx, nil, int64(order.ID), dispatchedAt))
+
+	stored, err = orderRepo.GetOrderByID(ctx, nil, int64(order.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Dispatched", stored.Status)
+	assert.True(t, dispatchedAt.Equal(stored.DispatchedAt))
+
+	missing, err := orderRepo.GetOrderByID(ctx, nil, 999)
+	require.NoError(t, err)
+	assert.Zero(t, missing.ID)
+
+	for _, other := range []repository.Order{
+		{CustomerID: 1, Currency: "INR", Amount: 120, FinalAmount: 120, Status: "Placed"},
+		{CustomerID: 1, Currency: "INR", Amount: 3000, FinalAmount: 3000, Status: "Cancelled"},
+		{CustomerID: 2, Currency: "INR", Amount: 5000, FinalAmount: 5000, Status: "Placed"},
+	} {
+		_, err = orderRepo.CreateOrder(ctx, nil, other)
+		require.NoError(t, err)
+	}
+
+	minAmount := int64(2000)
+	//the USD order of the customer is above the bound too, but not in the currency of the filter
+	filter := repository.OrderFilter{CustomerID: 1, Currency: "INR", MinFinalAmount: &minAmount, SortBy: repository.OrderSortByFinalAmount, Limit: 1}
+	orders, err := orderRepo.ListOrders(ctx, nil, filter)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, int64(3000), orders[0].FinalAmount)
+
+	count, err := orderRepo.CountOrders(ctx, nil, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	orders, err = orderRepo.ListOrders(ctx, nil, repository.OrderFilter{Statuses: []string{"Placed"}, SortDesc: true})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, int64(2), orders[0].CustomerID)
+	assert.Equal(t, int64(120), orders[1].FinalAmount)
+}
+
+func TestOrderItemStore(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	orderRepo := NewOrderRepo(db)
+	orderItemRepo := NewOrderItemRepo(db)
+
+	order, err := orderRepo.CreateOrder(ctx, nil, repository.Order{CustomerID: 1, Amount: 10000, FinalAmount: 10000, Status: "Placed"})
+	require.NoError(t, err)
+
+	err = orderItemRepo.StoreOrderItems(ctx, nil, []repository.OrderItem{
+		{OrderID: int64(order.ID), ProductID: 1, Quantity: 1},
+		{OrderID: int64(order.ID), ProductID: 2, ProductName: "Trail Shoe", Category: "Premium", UnitPrice: 3000, Quantity: 3, Discount: 900,
+			TaxRate: 18, Tax: 1458},
+	})
+	require.NoError(t, err)
+
+	items, err := orderItemRepo.GetOrderItemsByOrderID(ctx, nil, int64(order.ID))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(2), items[1].ProductID)
+	assert.Equal(t, int64(3), items[1].Quantity)
+	assert.Equal(t, "Trail Shoe", items[1].ProductName)
+	assert.Equal(t, "Premium", items[1].Category)
+	assert.Equal(t, int64(3000), items[1].UnitPrice)
+	assert.Equal(t, int64(900), items[1].Discount)
+	assert.Equal(t, 18.0, items[1].TaxRate)
+	assert.Equal(t, int64(1458), items[1].Tax)
+	assert.Empty(t, items[0].ProductName)
+
+	items, err = orderItemRepo.GetOrderItemsByOrderID(ctx, nil, int64(order.ID)+1)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
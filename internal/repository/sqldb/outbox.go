@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type outboxStore struct {
+	BaseRepository
+}
+
+func NewOutboxRepo(db *sql.DB) repository.OutboxStorer {
+	return &outboxStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (obs *outboxStore) CreateOutboxEvent(ctx context.Context, tx repository.Transaction, event repository.OutboxEvent) (repository.OutboxEvent, error) {
+	queryExecutor := obs.initiateQueryExecutor(tx)
+
+	event.CreatedAt = obs.TimeNow()
+	event.Status = repository.OutboxStatusPending
+	event.NextAttemptAt = event.CreatedAt
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO outbox_events (event_type, aggregate_id, payload, status, attempts, last_error, next_attempt_at, delivered_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		event.Type, event.AggregateID, string(event.Payload), event.Status, event.Attempts, event.LastError,
+		event.NextAttemptAt, nullTime(event.DeliveredAt), event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return repository.OutboxEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (obs *outboxStore) ListPendingOutboxEvents(ctx context.Context, tx repository.Transaction, dueAt time.Time, limit int) ([]repository.OutboxEvent, error) {
+	events := make([]repository.OutboxEvent, 0)
+
+	//timestamps are stored in UTC, sqlite compares them as text
+	queryExecutor := obs.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT id, event_type, aggregate_id, payload, status, attempts, last_error, next_attempt_at, delivered_at, created_at
+		FROM outbox_events WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id LIMIT $3`,
+		repository.OutboxStatusPending, dueAt.UTC(), limit)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event repository.OutboxEvent
+		var payload string
+		var deliveredAt sql.NullTime
+		err = rows.Scan(&event.ID, &event.Type, &event.AggregateID, &payload, &event.Status, &event.Attempts,
+			&event.LastError, &event.NextAttemptAt, &deliveredAt, &event.CreatedAt)
+		if err != nil {
+			return events, err
+		}
+
+		event.Payload = []byte(payload)
+		event.DeliveredAt = deliveredAt.Time
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (obs *outboxStore) UpdateOutboxEvent(ctx context.Context, tx repository.Transaction, event repository.OutboxEvent) error {
+	queryExecutor := obs.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx,
+		`UPDATE outbox_events SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, delivered_at = $5
+		WHERE id = $6`,
+		event.Status, event.Attempts, event.LastError, event.NextAttemptAt.UTC(), nullTime(event.DeliveredAt.UTC()), event.ID)
+	return err
+}
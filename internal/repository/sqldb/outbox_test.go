@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxStore(t *testing.T) {
+	ctx := context.Background()
+	outboxRepo := NewOutboxRepo(newTestDatabase(t))
+
+	first, err := outboxRepo.CreateOutboxEvent(ctx, nil, repository.OutboxEvent{Type: "OrderPlaced", AggregateID: 1, Payload: []byte(`{"order_id":1}`)})
+	require.NoError(t, err)
+	require.NotZero(t, first.ID)
+	assert.Equal(t, repository.OutboxStatusPending, first.Status)
+
+	second, err := outboxRepo.CreateOutboxEvent(ctx, nil, repository.OutboxEvent{Type: "StockAdjusted", AggregateID: 3, Payload: []byte(`{"product_id":3}`)})
+	require.NoError(t, err)
+
+	//times of any zone compare by instant
+	dueAt := time.Now().In(time.FixedZone("IST", 5*60*60+30*60)).Add(time.Minute)
+	pending, err := outboxRepo.ListPendingOutboxEvents(ctx, nil, dueAt, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, first.ID, pending[0].ID)
+	assert.JSONEq(t, `{"order_id":1}`, string(pending[0].Payload))
+
+	//delivered events and events waiting for a retry are not listed
+	first.Status = repository.OutboxStatusDelivered
+	first.Attempts = 1
+	first.DeliveredAt = time.Now()
+	require.NoError(t, outboxRepo.UpdateOutboxEvent(ctx, nil, first))
+
+	second.Attempts = 1
+	second.LastError = "connection refused"
+	second.NextAttemptAt = dueAt.Add(time.Minute)
+	require.NoError(t, outboxRepo.UpdateOutboxEvent(ctx, nil, second))
+
+	pending, err = outboxRepo.ListPendingOutboxEvents(ctx, nil, dueAt, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	pending, err = outboxRepo.ListPendingOutboxEvents(ctx, nil, dueAt.Add(time.Hour), 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "connection refused", pending[0].LastError)
+}
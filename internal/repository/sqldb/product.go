@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const productColumns = `id, name, price, currency, prices, category, quantity, reorder_threshold, reorder_quantity, weight_grams,
+	length_mm, width_mm, height_mm, archived, archived_at, created_at, updated_at`
+
+type productStore struct {
+	BaseRepository
+}
+
+func NewProductRepo(db *sql.DB) repository.ProductStorer {
+	return &productStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func scanProduct(row rowScanner) (repository.Product, error) {
+	var product repository.Product
+	var archivedAt sql.NullTime
+	var prices string
+
+	err := row.Scan(&product.ID, &product.Name, &product.Price, &product.Currency, &prices, &product.Category, &product.Quantity,
+		&product.ReorderThreshold, &product.ReorderQuantity, &product.WeightGrams, &product.LengthMM, &product.WidthMM, &product.HeightMM,
+		&product.Archived, &archivedAt, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	err = json.Unmarshal([]byte(prices), &product.Prices)
+	if err != nil {
+		return repository.Product{}, fmt.Errorf("error decoding prices of product %d: %w", product.ID, err)
+	}
+
+	product.ArchivedAt = archivedAt.Time
+	return product, nil
+}
+
+func (ps *productStore) GetProductByID(ctx context.Context, tx repository.Transaction, productID int64) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = $1`, productID)
+
+	product, err := scanProduct(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) CreateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+
+	prices, err := encodePrices(product.Prices)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	product.CreatedAt = ps.TimeNow()
+	product.UpdatedAt = ps.TimeNow()
+	err = queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO products (name, price, currency, prices, category, quantity, reorder_threshold, reorder_quantity, weight_grams,
+		length_mm, width_mm, height_mm, archived, archived_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) RETURNING id`,
+		product.Name, product.Price, product.Currency, prices, product.Category, product.Quantity, product.ReorderThreshold,
+		product.ReorderQuantity, product.WeightGrams, product.LengthMM, product.WidthMM, product.HeightMM, product.Archived, nullTime(product.ArchivedAt), product.CreatedAt, product.UpdatedAt,
+	).Scan(&product.ID)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) UpdateProduct(ctx context.Context, tx repository.Transaction, product repository.Product) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+
+	prices, err := encodePrices(product.Prices)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	product.UpdatedAt = ps.TimeNow()
+	_, err = queryExecutor.ExecContext(ctx,
+		`UPDATE products SET name = $1, price = $2, currency = $3, prices = $4, category = $5, reorder_threshold = $6,
+		reorder_quantity = $7, weight_grams = $8, length_mm = $9, width_mm = $10, height_mm = $11, updated_at = $12 WHERE id = $13`,
+		product.Name, product.Price, product.Currency, prices, product.Category, product.ReorderThreshold, product.ReorderQuantity,
+		product.WeightGrams, product.LengthMM, product.WidthMM, product.HeightMM, product.UpdatedAt, product.ID)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) ArchiveProduct(ctx context.Context, tx repository.Transaction, productID int64, archivedAt time.Time) error {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx,
+		`UPDATE products SET archived = $1, archived_at = $2, updated_at = $3 WHERE id = $4`,
+		true, nullTime(archivedAt), ps.TimeNow(), productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ps *productStore) LockProducts(ctx context.Context, tx repository.Transaction, productIDs []int64) error {
+	sortedIDs := append([]int64(nil), productIDs...)
+	sort.Slice(sortedIDs, func(i, j int) bool { return sortedIDs[i] < sortedIDs[j] })
+
+	//touching the rows locks them until the transaction ends, taking the locks in id order
+	//keeps transactions locking the same products from deadlocking
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	for _, productID := range sortedIDs {
+		_, err := queryExecutor.ExecContext(ctx, `UPDATE products SET quantity = quantity WHERE id = $1`, productID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ps *productStore) AdjustProductQuantity(ctx context.Context, tx repository.Transaction, productID int64, delta int64) (repository.Product, error) {
+	queryExecutor := ps.initiateQueryExecutor(tx)
+
+	//adding the delta in the statement keeps concurrent adjustments from overwriting each other
+	row := queryExecutor.QueryRowContext(ctx,
+		`UPDATE products SET quantity = quantity + $1, updated_at = $2
+		WHERE id = $3 AND quantity + $1 >= 0 RETURNING `+productColumns,
+		delta, ps.TimeNow(), productID)
+
+	product, err := scanProduct(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (ps *productStore) ListProductQuantities(ctx context.Context, tx repository.Transaction) (map[int64]int64, error) {
+	quantities := make(map[int64]int64)
+
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, `SELECT id, quantity FROM products`)
+	if err != nil {
+		return quantities, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, quantity int64
+		err = rows.Scan(&productID, &quantity)
+		if err != nil {
+			return quantities, err
+		}
+
+		quantities[productID] = quantity
+	}
+
+	return quantities, rows.Err()
+}
+
+func (ps *productStore) ListReorderProducts(ctx context.Context, tx repository.Transaction) ([]repository.Product, error) {
+	productList := make([]repository.Product, 0)
+
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx,
+		`SELECT `+productColumns+` FROM products WHERE archived = $1 AND reorder_threshold > $2 ORDER BY id`, false, 0)
+	if err != nil {
+		return productList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return productList, err
+		}
+
+		productList = append(productList, product)
+	}
+
+	return productList, rows.Err()
+}
+
+func (ps *productStore) SearchProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) ([]repository.Product, error) {
+	productList := make([]repository.Product, 0)
+
+	where, args := productFilterClause(filter)
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	//the sort column comes from a fixed list, id breaks ties so pages never overlap
+	query := fmt.Sprintf(`SELECT `+productColumns+` FROM products %s ORDER BY %s %s, id %s`,
+		where, productSortColumns[filter.SortBy], direction, direction)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return productList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return productList, err
+		}
+
+		productList = append(productList, product)
+	}
+
+	return productList, rows.Err()
+}
+
+func (ps *productStore) CountProducts(ctx context.Context, tx repository.Transaction, filter repository.ProductFilter) (int64, error) {
+	where, args := productFilterClause(filter)
+
+	var count int64
+	queryExecutor := ps.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx, `SELECT COUNT(*) FROM products `+where, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+var productSortColumns = map[string]string{
+	"":                                "id",
+	repository.ProductSortByID:        "id",
+	repository.ProductSortByName:      "name",
+	repository.ProductSortByPrice:     "price",
+	repository.ProductSortByCreatedAt: "created_at",
+}
+
+// productFilterClause builds the WHERE clause of the filter along with its positional arguments
+func productFilterClause(filter repository.ProductFilter) (string, []interface{}) {
+	//archived products are retired from the catalog
+	conditions := []string{"archived = $1"}
+	args := []interface{}{false}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Category != "" {
+		addCondition("category = $%d", filter.Category)
+	}
+
+	if filter.MinPrice != nil {
+		addCondition("price >= $%d", *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		addCondition("price <= $%d", *filter.MaxPrice)
+	}
+
+	//stock held by active reservations is not available to sell, timestamps are stored in UTC
+	if !filter.InStockAt.IsZero() {
+		args = append(args, repository.ReservationActive, filter.InStockAt.UTC())
+		conditions = append(conditions, fmt.Sprintf(`quantity > COALESCE((SELECT SUM(r.quantity) FROM inventory_reservations r
+			WHERE r.product_id = products.id AND r.status = $%d AND r.expires_at > $%d), 0)`, len(args)-1, len(args)))
+	}
+
+	if filter.NameContains != "" {
+		addCondition(`LOWER(name) LIKE $%d ESCAPE '\'`, "%"+escapeLike(strings.ToLower(filter.NameContains))+"%")
+	}
+
+	if filter.NamePrefix != "" {
+		addCondition(`LOWER(name) LIKE $%d ESCAPE '\'`, escapeLike(strings.ToLower(filter.NamePrefix))+"%")
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes the LIKE wildcards of a user supplied search term
+var escapeLike = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace
+
+// encodePrices stores the prices in other currencies as a JSON object, they are only read back along with the product
+func encodePrices(prices map[string]int64) (string, error) {
+	if prices == nil {
+		prices = make(map[string]int64)
+	}
+
+	encoded, err := json.Marshal(prices)
+	return string(encoded), err
+}
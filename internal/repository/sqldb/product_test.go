@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductStore(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	productRepo := NewProductRepo(db)
+	reservationRepo := NewInventoryReservationRepo(db)
+
+	product, err := productRepo.CreateProduct(ctx, nil, repository.Product{
+		Name: "Trail Shoe", Price: 799900, Currency: "INR", Prices: map[string]int64{"USD": 9999}, Category: "Premium", Quantity: 5,
+		WeightGrams: 850, LengthMM: 320, WidthMM: 200, HeightMM: 120,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, product.ID)
+
+	stored, err := productRepo.GetProductByID(ctx, nil, int64(product.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Trail Shoe", stored.Name)
+	assert.Equal(t, int64(799900), stored.Price)
+	assert.Equal(t, "INR", stored.Currency)
+	assert.Equal(t, map[string]int64{"USD": 9999}, stored.Prices)
+	assert.Equal(t, int64(5), stored.Quantity)
+	assert.Equal(t, int64(850), stored.WeightGrams)
+	assert.Equal(t, []int64{320, 200, 120}, []int64{stored.LengthMM, stored.WidthMM, stored.HeightMM})
+
+	//the quantity only changes through AdjustProductQuantity
+	stored.Name = "Trail Runner"
+	stored.Prices = nil
+	stored.Quantity = 50
+	stored.ReorderThreshold = 3
+	stored.ReorderQuantity = 10
+	stored.WeightGrams = 900
+	_, err = productRepo.UpdateProduct(ctx, nil, stored)
+	require.NoError(t, err)
+
+	adjusted, err := productRepo.AdjustProductQuantity(ctx, nil, int64(product.ID), -3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), adjusted.Quantity)
+
+	//taking more than the stock on hand leaves it untouched
+	adjusted, err = productRepo.AdjustProductQuantity(ctx, nil, int64(product.ID), -3)
+	require.NoError(t, err)
+	assert.Zero(t, adjusted.ID)
+
+	//quantities adjusted within a transaction are gone once it is rolled back
+	tx, err := productRepo.BeginTx(ctx)
+	require.NoError(t, err)
+	_, err = productRepo.AdjustProductQuantity(ctx, tx, int64(product.ID), -2)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	stored, err = productRepo.GetProductByID(ctx, nil, int64(product.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Trail Runner", stored.Name)
+	assert.Empty(t, stored.Prices)
+	assert.Equal(t, int64(2), stored.Quantity)
+	assert.Equal(t, int64(3), stored.ReorderThreshold)
+	assert.Equal(t, int64(10), stored.ReorderQuantity)
+	assert.Equal(t, int64(900), stored.WeightGrams)
+
+	//seeded products have no reorder threshold
+	reorderProducts, err := productRepo.ListReorderProducts(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Trail Runner"}, productNames(reorderProducts))
+
+	quantities, err := productRepo.ListProductQuantities(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), quantities[int64(product.ID)])
+
+	missing, err := productRepo.GetProductByID(ctx, nil, 999)
+	require.NoError(t, err)
+	assert.Zero(t, missing.ID)
+
+	//the migrations seed five premium products before this one
+	maxPrice := int64(800000)
+	filter := repository.ProductFilter{Category: "Premium", MaxPrice: &maxPrice, SortBy: repository.ProductSortByPrice, SortDesc: true, Limit: 2}
+	products, err := productRepo.SearchProducts(ctx, nil, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"G-Shock Watch", "Trail Runner"}, productNames(products))
+
+	count, err := productRepo.CountProducts(ctx, nil, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	//stock held by active reservations is not in stock, expired holds no longer count
+	at := time.Now()
+	inStock := repository.ProductFilter{NameContains: "trail", InStockAt: at}
+	err = reservationRepo.CreateReservations(ctx, nil, []repository.InventoryReservation{
+		{OrderID: 1, ProductID: int64(product.ID), Quantity: 1, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+		{OrderID: 2, ProductID: int64(product.ID), Quantity: 5, Status: repository.ReservationActive, ExpiresAt: at.Add(-time.Minute)},
+	})
+	require.NoError(t, err)
+	products, err = productRepo.SearchProducts(ctx, nil, inStock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Trail Runner"}, productNames(products))
+
+	err = reservationRepo.CreateReservations(ctx, nil, []repository.InventoryReservation{
+		{OrderID: 3, ProductID: int64(product.ID), Quantity: 1, Status: repository.ReservationActive, ExpiresAt: at.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	products, err = productRepo.SearchProducts(ctx, nil, inStock)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	count, err = productRepo.CountProducts(ctx, nil, inStock)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	//archived products leave the catalog
+	require.NoError(t, productRepo.ArchiveProduct(ctx, nil, int64(product.ID), time.Now()))
+	products, err = productRepo.SearchProducts(ctx, nil, repository.ProductFilter{NameContains: "trail"})
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	stored, err = productRepo.GetProductByID(ctx, nil, int64(product.ID))
+	require.NoError(t, err)
+	assert.True(t, stored.Archived)
+	assert.False(t, stored.ArchivedAt.IsZero())
+
+	reorderProducts, err = productRepo.ListReorderProducts(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, reorderProducts)
+}
+
+// productNames returns the name of each product
+func productNames(products []repository.Product) []string {
+	names := make([]string, 0, len(products))
+	for _, product := range products {
+		names = append(names, product.Name)
+	}
+
+	return names
+}
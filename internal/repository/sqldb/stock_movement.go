@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+type stockMovementStore struct {
+	BaseRepository
+}
+
+func NewStockMovementRepo(db *sql.DB) repository.StockMovementStorer {
+	return &stockMovementStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func (ms *stockMovementStore) CreateStockMovement(ctx context.Context, tx repository.Transaction, movement repository.StockMovement) (repository.StockMovement, error) {
+	queryExecutor := ms.initiateQueryExecutor(tx)
+
+	movement.CreatedAt = ms.TimeNow()
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO stock_movements (product_id, delta, quantity_after, reason, order_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		movement.ProductID, movement.Delta, movement.QuantityAfter, movement.Reason, movement.OrderID, movement.CreatedAt,
+	).Scan(&movement.ID)
+	if err != nil {
+		return repository.StockMovement{}, err
+	}
+
+	return movement, nil
+}
+
+func (ms *stockMovementStore) ListStockMovements(ctx context.Context, tx repository.Transaction, productID int64, limit, offset int) ([]repository.StockMovement, error) {
+	movements := make([]repository.StockMovement, 0)
+
+	args := []interface{}{productID}
+	query := `SELECT id, product_id, delta, quantity_after, reason, order_id, created_at FROM stock_movements
+		WHERE product_id = $1 ORDER BY id DESC`
+
+	if limit > 0 {
+		args = append(args, limit, offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return movements, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movement repository.StockMovement
+		err = rows.Scan(&movement.ID, &movement.ProductID, &movement.Delta, &movement.QuantityAfter,
+			&movement.Reason, &movement.OrderID, &movement.CreatedAt)
+		if err != nil {
+			return movements, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
+
+func (ms *stockMovementStore) CountStockMovements(ctx context.Context, tx repository.Transaction, productID int64) (int64, error) {
+	var count int64
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx, `SELECT COUNT(*) FROM stock_movements WHERE product_id = $1`, productID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (ms *stockMovementStore) SumStockMovements(ctx context.Context, tx repository.Transaction) (map[int64]int64, error) {
+	sums := make(map[int64]int64)
+
+	queryExecutor := ms.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, `SELECT product_id, SUM(delta) FROM stock_movements GROUP BY product_id`)
+	if err != nil {
+		return sums, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, sum int64
+		err = rows.Scan(&productID, &sum)
+		if err != nil {
+			return sums, err
+		}
+
+		sums[productID] = sum
+	}
+
+	return sums, rows.Err()
+}
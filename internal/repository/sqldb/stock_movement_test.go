@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockMovementStore(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	movementRepo := NewStockMovementRepo(db)
+
+	//the migrations record the seeded quantities as opening balances
+	quantities, err := NewProductRepo(db).ListProductQuantities(ctx, nil)
+	require.NoError(t, err)
+	sums, err := movementRepo.SumStockMovements(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, quantities, sums)
+
+	_, err = movementRepo.CreateStockMovement(ctx, nil, repository.StockMovement{
+		ProductID: 1, Delta: -2, QuantityAfter: quantities[1] - 2, Reason: repository.StockReasonSale, OrderID: 7,
+	})
+	require.NoError(t, err)
+	_, err = movementRepo.CreateStockMovement(ctx, nil, repository.StockMovement{
+		ProductID: 1, Delta: 1, QuantityAfter: quantities[1] - 1, Reason: repository.StockReasonReturn, OrderID: 7,
+	})
+	require.NoError(t, err)
+
+	count, err := movementRepo.CountStockMovements(ctx, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	//newest first
+	movements, err := movementRepo.ListStockMovements(ctx, nil, 1, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, movements, 2)
+	assert.Equal(t, repository.StockReasonReturn, movements[0].Reason)
+	assert.Equal(t, int64(7), movements[0].OrderID)
+	assert.Equal(t, repository.StockReasonSale, movements[1].Reason)
+
+	movements, err = movementRepo.ListStockMovements(ctx, nil, 1, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	assert.Equal(t, repository.StockReasonInitial, movements[0].Reason)
+
+	sums, err = movementRepo.SumStockMovements(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, quantities[1]-1, sums[1])
+}
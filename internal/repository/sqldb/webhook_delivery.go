@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, last_error,
+	response_status, next_attempt_at, delivered_at, created_at, updated_at`
+
+type webhookDeliveryStore struct {
+	BaseRepository
+}
+
+func NewWebhookDeliveryRepo(db *sql.DB) repository.WebhookDeliveryStorer {
+	return &webhookDeliveryStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+func scanWebhookDelivery(row rowScanner) (repository.WebhookDelivery, error) {
+	var delivery repository.WebhookDelivery
+	var payload string
+	var deliveredAt sql.NullTime
+
+	err := row.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &delivery.LastError, &delivery.ResponseStatus,
+		&delivery.NextAttemptAt, &deliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return repository.WebhookDelivery{}, err
+	}
+
+	delivery.Payload = []byte(payload)
+	delivery.DeliveredAt = deliveredAt.Time
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) CreateWebhookDelivery(ctx context.Context, tx repository.Transaction, delivery repository.WebhookDelivery) (repository.WebhookDelivery, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	delivery.CreatedAt = ws.TimeNow()
+	delivery.UpdatedAt = delivery.CreatedAt
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, last_error,
+			response_status, next_attempt_at, delivered_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+		delivery.SubscriptionID, delivery.EventID, delivery.EventType, string(delivery.Payload), delivery.Status,
+		delivery.Attempts, delivery.LastError, delivery.ResponseStatus, delivery.NextAttemptAt.UTC(),
+		nullTime(delivery.DeliveredAt.UTC()), delivery.CreatedAt, delivery.UpdatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) GetWebhookDeliveryByID(ctx context.Context, tx repository.Transaction, deliveryID int64) (repository.WebhookDelivery, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, deliveryID)
+
+	delivery, err := scanWebhookDelivery(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) GetWebhookDeliveryByEvent(ctx context.Context, tx repository.Transaction, subscriptionID, eventID int64) (repository.WebhookDelivery, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE subscription_id = $1 AND event_id = $2`, subscriptionID, eventID)
+
+	delivery, err := scanWebhookDelivery(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (ws *webhookDeliveryStore) ListWebhookDeliveries(ctx context.Context, tx repository.Transaction, filter repository.WebhookDeliveryFilter) ([]repository.WebhookDelivery, error) {
+	deliveries := make([]repository.WebhookDelivery, 0)
+
+	where, args := webhookDeliveryFilterClause(filter)
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries ` + where + ` ORDER BY id DESC`
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	return ws.queryWebhookDeliveries(ctx, tx, deliveries, query, args...)
+}
+
+func (ws *webhookDeliveryStore) CountWebhookDeliveries(ctx context.Context, tx repository.Transaction, filter repository.WebhookDeliveryFilter) (int64, error) {
+	where, args := webhookDeliveryFilterClause(filter)
+
+	var count int64
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	err := queryExecutor.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries `+where, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (ws *webhookDeliveryStore) ListDueWebhookDeliveries(ctx context.Context, tx repository.Transaction, dueAt time.Time, limit int) ([]repository.WebhookDelivery, error) {
+	deliveries := make([]repository.WebhookDelivery, 0)
+
+	//timestamps are stored in UTC, sqlite compares them as text
+	return ws.queryWebhookDeliveries(ctx, tx, deliveries,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id LIMIT $3`,
+		repository.WebhookDeliveryPending, dueAt.UTC(), limit)
+}
+
+func (ws *webhookDeliveryStore) UpdateWebhookDelivery(ctx context.Context, tx repository.Transaction, delivery repository.WebhookDelivery) error {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	delivery.UpdatedAt = ws.TimeNow()
+	_, err := queryExecutor.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET status = $1, attempts = $2, last_error = $3, response_status = $4,
+		next_attempt_at = $5, delivered_at = $6, updated_at = $7 WHERE id = $8`,
+		delivery.Status, delivery.Attempts, delivery.LastError, delivery.ResponseStatus,
+		delivery.NextAttemptAt.UTC(), nullTime(delivery.DeliveredAt.UTC()), delivery.UpdatedAt, delivery.ID)
+	return err
+}
+
+func (ws *webhookDeliveryStore) queryWebhookDeliveries(ctx context.Context, tx repository.Transaction,
+	deliveries []repository.WebhookDelivery, query string, args ...interface{}) ([]repository.WebhookDelivery, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return deliveries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return deliveries, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// webhookDeliveryFilterClause builds the WHERE clause of the filter along with its positional arguments
+func webhookDeliveryFilterClause(filter repository.WebhookDeliveryFilter) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if filter.SubscriptionID != 0 {
+		args = append(args, filter.SubscriptionID)
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+)
+
+const webhookSubscriptionColumns = `id, url, secret, event_types, created_at, updated_at`
+
+type webhookSubscriptionStore struct {
+	BaseRepository
+}
+
+func NewWebhookSubscriptionRepo(db *sql.DB) repository.WebhookSubscriptionStorer {
+	return &webhookSubscriptionStore{
+		BaseRepository: BaseRepository{db},
+	}
+}
+
+// event types are stored comma separated, they never contain commas
+func scanWebhookSubscription(row rowScanner) (repository.WebhookSubscription, error) {
+	var subscription repository.WebhookSubscription
+	var eventTypes string
+
+	err := row.Scan(&subscription.ID, &subscription.URL, &subscription.Secret, &eventTypes,
+		&subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return repository.WebhookSubscription{}, err
+	}
+
+	subscription.EventTypes = strings.Split(eventTypes, ",")
+	return subscription, nil
+}
+
+func (ws *webhookSubscriptionStore) CreateWebhookSubscription(ctx context.Context, tx repository.Transaction, subscription repository.WebhookSubscription) (repository.WebhookSubscription, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+
+	subscription.CreatedAt = ws.TimeNow()
+	subscription.UpdatedAt = subscription.CreatedAt
+	err := queryExecutor.QueryRowContext(ctx,
+		`INSERT INTO webhook_subscriptions (url, secret, event_types, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		subscription.URL, subscription.Secret, strings.Join(subscription.EventTypes, ","),
+		subscription.CreatedAt, subscription.UpdatedAt,
+	).Scan(&subscription.ID)
+	if err != nil {
+		return repository.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (ws *webhookSubscriptionStore) GetWebhookSubscriptionByID(ctx context.Context, tx repository.Transaction, subscriptionID int64) (repository.WebhookSubscription, error) {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	row := queryExecutor.QueryRowContext(ctx, `SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, subscriptionID)
+
+	subscription, err := scanWebhookSubscription(row)
+	if err != nil && err != sql.ErrNoRows {
+		return repository.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (ws *webhookSubscriptionStore) ListWebhookSubscriptions(ctx context.Context, tx repository.Transaction) ([]repository.WebhookSubscription, error) {
+	subscriptions := make([]repository.WebhookSubscription, 0)
+
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	rows, err := queryExecutor.QueryContext(ctx, `SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return subscriptions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return subscriptions, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func (ws *webhookSubscriptionStore) DeleteWebhookSubscription(ctx context.Context, tx repository.Transaction, subscriptionID int64) error {
+	queryExecutor := ws.initiateQueryExecutor(tx)
+	_, err := queryExecutor.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, subscriptionID)
+	return err
+}
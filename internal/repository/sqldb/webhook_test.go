@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sagar23sj/go-ecommerce/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscriptionStore(t *testing.T) {
+	ctx := context.Background()
+	subscriptionRepo := NewWebhookSubscriptionRepo(newTestDatabase(t))
+
+	created, err := subscriptionRepo.CreateWebhookSubscription(ctx, nil, repository.WebhookSubscription{
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{"OrderPlaced", "OrderStatusChanged"},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+
+	found, err := subscriptionRepo.GetWebhookSubscriptionByID(ctx, nil, int64(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", found.Secret)
+	assert.Equal(t, []string{"OrderPlaced", "OrderStatusChanged"}, found.EventTypes)
+
+	subscriptions, err := subscriptionRepo.ListWebhookSubscriptions(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, subscriptions, 1)
+
+	require.NoError(t, subscriptionRepo.DeleteWebhookSubscription(ctx, nil, int64(created.ID)))
+
+	//not found returns a zero subscription
+	found, err = subscriptionRepo.GetWebhookSubscriptionByID(ctx, nil, int64(created.ID))
+	require.NoError(t, err)
+	assert.Zero(t, found.ID)
+}
+
+func TestWebhookDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	deliveryRepo := NewWebhookDeliveryRepo(newTestDatabase(t))
+
+	//times of any zone compare by instant
+	dueAt := time.Now().In(time.FixedZone("IST", 5*60*60+30*60))
+
+	first, err := deliveryRepo.CreateWebhookDelivery(ctx, nil, repository.WebhookDelivery{
+		SubscriptionID: 1, EventID: 7, EventType: "OrderPlaced", Payload: []byte(`{"id":7}`),
+		Status: repository.WebhookDeliveryPending, NextAttemptAt: dueAt,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, first.ID)
+
+	second, err := deliveryRepo.CreateWebhookDelivery(ctx, nil, repository.WebhookDelivery{
+		SubscriptionID: 2, EventID: 7, EventType: "OrderPlaced", Payload: []byte(`{"id":7}`),
+		Status: repository.WebhookDeliveryPending, NextAttemptAt: dueAt,
+	})
+	require.NoError(t, err)
+
+	//each event is queued once per subscription
+	_, err = deliveryRepo.CreateWebhookDelivery(ctx, nil, repository.WebhookDelivery{
+		SubscriptionID: 1, EventID: 7, EventType: "OrderPlaced", Status: repository.WebhookDeliveryPending,
+	})
+	assert.Error(t, err)
+
+	found, err := deliveryRepo.GetWebhookDeliveryByEvent(ctx, nil, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, found.ID)
+	assert.JSONEq(t, `{"id":7}`, string(found.Payload))
+
+	due, err := deliveryRepo.ListDueWebhookDeliveries(ctx, nil, dueAt.Add(time.Second), 10)
+	require.NoError(t, err)
+	assert.Len(t, due, 2)
+
+	//dead deliveries are no longer due but stay listed for replay
+	second.Status = repository.WebhookDeliveryDead
+	second.Attempts = 8
+	second.LastError = "subscription responded with status 500"
+	second.ResponseStatus = 500
+	require.NoError(t, deliveryRepo.UpdateWebhookDelivery(ctx, nil, second))
+
+	due, err = deliveryRepo.ListDueWebhookDeliveries(ctx, nil, dueAt.Add(time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, first.ID, due[0].ID)
+
+	filter := repository.WebhookDeliveryFilter{Status: repository.WebhookDeliveryDead, Limit: 10}
+	count, err := deliveryRepo.CountWebhookDeliveries(ctx, nil, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	dead, err := deliveryRepo.ListWebhookDeliveries(ctx, nil, filter)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 500, dead[0].ResponseStatus)
+
+	//newest first
+	all, err := deliveryRepo.ListWebhookDeliveries(ctx, nil, repository.WebhookDeliveryFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, second.ID, all[0].ID)
+}
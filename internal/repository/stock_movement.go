@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// reasons of a stock movement
+const (
+	StockReasonInitial    = "initial"
+	StockReasonSale       = "sale"
+	StockReasonCancel     = "cancel"
+	StockReasonReturn     = "return"
+	StockReasonRestock    = "restock"
+	StockReasonCorrection = "correction"
+)
+
+// StockMovementStorer keeps the append only ledger of stock changes, movements are never updated or deleted
+type StockMovementStorer interface {
+	RepositoryTransaction
+
+	CreateStockMovement(ctx context.Context, tx Transaction, movement StockMovement) (StockMovement, error)
+	// ListStockMovements returns one page of the movements of the product, newest first, a limit of 0 returns all
+	ListStockMovements(ctx context.Context, tx Transaction, productID int64, limit, offset int) ([]StockMovement, error)
+	CountStockMovements(ctx context.Context, tx Transaction, productID int64) (int64, error)
+	// SumStockMovements returns the net delta of the ledger of every product having movements
+	SumStockMovements(ctx context.Context, tx Transaction) (map[int64]int64, error)
+}
+
+// StockMovement records a change of the stock of a product by Delta units, leaving QuantityAfter on hand
+type StockMovement struct {
+	ID            uint  `storm:"id,increment"`
+	ProductID     int64 `storm:"index"`
+	Delta         int64
+	QuantityAfter int64
+	Reason        string
+	//OrderID is set for movements caused by an order
+	OrderID   int64
+	CreatedAt time.Time
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	//WebhookDeliveryDead marks deliveries which ran out of attempts, they are kept until replayed
+	WebhookDeliveryDead = "dead"
+)
+
+type WebhookDeliveryStorer interface {
+	RepositoryTransaction
+
+	CreateWebhookDelivery(ctx context.Context, tx Transaction, delivery WebhookDelivery) (WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx context.Context, tx Transaction, deliveryID int64) (WebhookDelivery, error)
+	// GetWebhookDeliveryByEvent returns the delivery of the event to the subscription, zero valued when there is none
+	GetWebhookDeliveryByEvent(ctx context.Context, tx Transaction, subscriptionID, eventID int64) (WebhookDelivery, error)
+	// ListWebhookDeliveries returns one page of the deliveries matching the filter, newest first
+	ListWebhookDeliveries(ctx context.Context, tx Transaction, filter WebhookDeliveryFilter) ([]WebhookDelivery, error)
+	// CountWebhookDeliveries returns the number of deliveries matching the filter, ignoring its page
+	CountWebhookDeliveries(ctx context.Context, tx Transaction, filter WebhookDeliveryFilter) (int64, error)
+	// ListDueWebhookDeliveries returns at most limit pending deliveries due at or before dueAt, oldest first
+	ListDueWebhookDeliveries(ctx context.Context, tx Transaction, dueAt time.Time, limit int) ([]WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, tx Transaction, delivery WebhookDelivery) error
+}
+
+// WebhookDeliveryFilter narrows down the deliveries returned by ListWebhookDeliveries, zero values leave a filter unset
+type WebhookDeliveryFilter struct {
+	SubscriptionID int64
+	Status         string
+
+	//Limit of 0 returns every matching delivery
+	Limit  int
+	Offset int
+}
+
+// WebhookDelivery is one domain event sent to one subscription
+type WebhookDelivery struct {
+	ID             uint  `storm:"id,increment"`
+	SubscriptionID int64 `storm:"index"`
+	EventID        int64
+	EventType      string
+	//Payload is the JSON body posted to the subscription
+	Payload   []byte
+	Status    string `storm:"index"`
+	Attempts  int
+	LastError string
+	//ResponseStatus is the HTTP status of the last attempt, 0 when no response was received
+	ResponseStatus int
+	NextAttemptAt  time.Time
+	DeliveredAt    time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type WebhookSubscriptionStorer interface {
+	RepositoryTransaction
+
+	CreateWebhookSubscription(ctx context.Context, tx Transaction, subscription WebhookSubscription) (WebhookSubscription, error)
+	GetWebhookSubscriptionByID(ctx context.Context, tx Transaction, subscriptionID int64) (WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, tx Transaction) ([]WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, tx Transaction, subscriptionID int64) error
+}
+
+// WebhookSubscription registers a partner endpoint for the domain events of the listed types
+type WebhookSubscription struct {
+	ID  uint `storm:"id,increment"`
+	URL string
+	//Secret signs every delivery to the subscription, it is never returned by the APIs
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}